@@ -240,8 +240,8 @@ func main() {
 	fmt.Println("\n4. Creating posts from top authors (Has relationship)...")
 	var allPosts []*Post
 
-	// First author writes 10 posts
-	author1, posts1 := factory.Has(userFactory, postFactory, 10, func(u *User, p *Post) {
+	// First author writes 10 posts, all published (the state replaces a publishing loop)
+	author1, posts1 := factory.Has(userFactory, postFactory.State("published"), 10, func(u *User, p *Post) {
 		p.AuthorID = u.ID
 	}).MustCreate(ctx)
 	allPosts = append(allPosts, posts1...)
@@ -254,12 +254,15 @@ func main() {
 	allPosts = append(allPosts, posts2...)
 	fmt.Printf("   ✅ %s wrote %d posts\n", author2.Name, len(posts2))
 
-	// STEP 5: Publish some posts (using State)
+	// STEP 5: Count published posts
 	fmt.Println("\n5. Publishing posts...")
-	for i := 0; i < 10; i++ {
-		allPosts[i].Published = true
+	published := 0
+	for _, p := range allPosts {
+		if p.Published {
+			published++
+		}
 	}
-	fmt.Printf("   ✅ Published 10 out of %d posts\n", len(allPosts))
+	fmt.Printf("   ✅ Published %d out of %d posts\n", published, len(allPosts))
 
 	// STEP 6: Add tags to posts (HasAttached - many-to-many)
 	fmt.Println("\n6. Tagging posts...")
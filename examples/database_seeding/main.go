@@ -140,12 +140,14 @@ func main() {
 		role := allRoles[roleIndex%len(allRoles)]
 		roleIndex++
 
-		_, err := userRoleFactory.Create(ctx, func(ur *UserRole) {
+		ur, err := userRoleFactory.Create(ctx, func(ur *UserRole) {
 			ur.UserID = u.ID
 			ur.RoleID = role.ID
 		})
 
 		if err == nil {
+			// Hand the pivot row back to callers that asked for artifacts
+			factory.RecordArtifact(ctx, "roles", ur)
 			fmt.Printf("   [AfterCreate] Auto-assigned '%s' to user '%s'\n", role.Name, u.Name)
 		}
 		return err
@@ -154,8 +156,12 @@ func main() {
 
 	// 3. Create regular users (roles AUTO-ASSIGNED via AfterCreate!)
 	fmt.Println("\n3. Creating 10 regular users...")
-	regularUsers := userFactory.Count(10).MustCreate(ctx)
-	fmt.Printf("   ✅ Created %d users (each with a role auto-assigned!)\n", len(regularUsers))
+	regularUsers, artifacts, err := userFactory.CreateManyWithArtifacts(ctx, 10)
+	if err != nil {
+		panic(err)
+	}
+	assigned := factory.All[UserRole](artifacts)
+	fmt.Printf("   ✅ Created %d users (with %d roles auto-assigned!)\n", len(regularUsers), len(assigned))
 
 	// 4. Create 3 more users (also auto-assigned roles)
 	fmt.Println("\n4. Creating 3 more users...")
@@ -0,0 +1,50 @@
+package factory
+
+import "context"
+
+// FactoryAny is the type-erased view of a factory, so registries, seeders, CLIs,
+// and mixers can hold factories of different types in one collection.
+// *Factory[T] implements it.
+type FactoryAny interface {
+	// Name identifies the factory (see WithName).
+	Name() string
+	// MakeAny builds one item without persisting and returns it as T.
+	MakeAny() any
+	// CreateAny creates one item and returns it as *T.
+	CreateAny(ctx context.Context) (any, error)
+}
+
+var _ FactoryAny = (*Factory[struct{}])(nil)
+
+// WithName sets the name returned by Name (e.g., "users" or "admin-users").
+func (f *Factory[T]) WithName(name string) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.name = name
+	return f
+}
+
+// Name returns the name set by WithName, or T's type name (e.g., "factory.User").
+func (f *Factory[T]) Name() string {
+	v := f.view()
+	f = &v
+	if f.name != "" {
+		return f.name
+	}
+	return typeName[T]()
+}
+
+// MakeAny is Make returning the item as any (a T value).
+func (f *Factory[T]) MakeAny() any {
+	return f.Make()
+}
+
+// CreateAny is Create returning the item as any (a *T). On error it returns a nil any,
+// not a typed nil pointer.
+func (f *Factory[T]) CreateAny(ctx context.Context) (any, error) {
+	item, err := f.Create(ctx)
+	if item == nil {
+		return nil, err
+	}
+	return item, err
+}
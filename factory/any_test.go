@@ -0,0 +1,38 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFactoryAny(t *testing.T) {
+	users := New(func(seq int64) User { return User{Name: "Ada"} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) {
+			u.ID = "u1"
+			return u, nil
+		})
+	posts := New(func(seq int64) Post { return Post{Title: "Hello"} }).WithName("posts")
+
+	registry := []FactoryAny{users, posts}
+	if registry[0].Name() != "factory.User" || registry[1].Name() != "posts" {
+		t.Fatalf("unexpected names %q, %q", registry[0].Name(), registry[1].Name())
+	}
+	if p, ok := registry[1].MakeAny().(Post); !ok || p.Title != "Hello" {
+		t.Fatalf("expected a Post value, got %#v", registry[1].MakeAny())
+	}
+
+	created, err := registry[0].CreateAny(context.Background())
+	if u, ok := created.(*User); err != nil || !ok || u.ID != "u1" {
+		t.Fatalf("expected a created *User, got %#v, %v", created, err)
+	}
+
+	failing := New(func(seq int64) User { return User{} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) { return nil, errors.New("boom") })
+	if got, err := FactoryAny(failing).CreateAny(context.Background()); got != nil || err == nil {
+		t.Fatalf("expected untyped nil and error, got %#v, %v", got, err)
+	}
+	if c := posts.Clone(); c.Name() != "posts" {
+		t.Fatalf("expected clone to keep the name, got %q", c.Name())
+	}
+}
@@ -0,0 +1,55 @@
+package factory
+
+import (
+	"context"
+	"sync"
+)
+
+// artifactBag collects what hooks derived during one call. Safe for concurrent use.
+type artifactBag struct {
+	mu sync.Mutex
+	r  *Results
+}
+
+type artifactsKey struct{}
+
+// WithArtifacts returns a context whose hooks can register derived artifacts with
+// RecordArtifact, and the bag they are collected in. Use it to collect across
+// several calls (e.g., a whole Has or scenario step).
+func WithArtifacts(ctx context.Context) (context.Context, *Results) {
+	bag := &artifactBag{r: NewResults()}
+	return context.WithValue(ctx, artifactsKey{}, bag), bag.r
+}
+
+// RecordArtifact adds items derived by a hook (e.g., the UserRole rows, tokens, or
+// URLs it created) to the call's artifact bag under key, so callers can read them
+// without global variables. Returns false (and records nothing) when the call was
+// not made with CreateWithArtifacts, CreateManyWithArtifacts, or WithArtifacts.
+// Example: factory.RecordArtifact(ctx, "roles", userRole)
+func RecordArtifact[A any](ctx context.Context, key string, items ...*A) bool {
+	bag, ok := ctx.Value(artifactsKey{}).(*artifactBag)
+	if !ok {
+		return false
+	}
+	bag.mu.Lock()
+	defer bag.mu.Unlock()
+	Add(bag.r, key, items...)
+	return true
+}
+
+// CreateWithArtifacts is like Create but also returns the artifacts hooks recorded
+// with RecordArtifact, including hooks of factories created inside them.
+// Example: user, artifacts, err := userFactory.CreateWithArtifacts(ctx); roles := All[UserRole](artifacts)
+func (f *Factory[T]) CreateWithArtifacts(ctx context.Context, ts ...Trait[T]) (*T, *Results, error) {
+	ctx, artifacts := WithArtifacts(ctx)
+	item, err := f.Create(ctx, ts...)
+	return item, artifacts, err
+}
+
+// CreateManyWithArtifacts is like CreateMany but also returns the artifacts hooks
+// recorded with RecordArtifact for the whole batch.
+func (f *Factory[T]) CreateManyWithArtifacts(ctx context.Context, count int, ts ...Trait[T]) ([]*T, *Results, error) {
+	ctx, artifacts := WithArtifacts(ctx)
+	items, err := f.CreateMany(ctx, count, ts...)
+	return items, artifacts, err
+}
@@ -0,0 +1,54 @@
+package factory
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFactory_CreateWithArtifacts(t *testing.T) {
+	roles := New(func(seq int64) UserRole { return UserRole{RoleID: "member", Active: true} }).
+		WithPersist(func(ctx context.Context, r *UserRole) (*UserRole, error) { return r, nil }).
+		AfterCreate(func(ctx context.Context, r *UserRole) error {
+			RecordArtifact(ctx, "tokens", &Role{ID: "token-" + r.UserID})
+			return nil
+		})
+	users := New(func(seq int64) User { return User{ID: "u1"} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) { return u, nil }).
+		AfterCreate(func(ctx context.Context, u *User) error {
+			role, err := roles.Create(ctx, func(r *UserRole) { r.UserID = u.ID })
+			if err != nil {
+				return err
+			}
+			RecordArtifact(ctx, "roles", role)
+			return nil
+		})
+
+	ctx := context.Background()
+	user, artifacts, err := users.CreateWithArtifacts(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := Get[[]*UserRole](artifacts, "roles")
+	if len(got) != 1 || got[0].UserID != user.ID || !got[0].Active {
+		t.Fatalf("expected the hook's role row, got %+v", got)
+	}
+	if token := First[Role](artifacts, "tokens"); token == nil || token.ID != "token-u1" {
+		t.Fatalf("expected nested hook artifact, got %+v", token)
+	}
+
+	_, batch, err := users.CreateManyWithArtifacts(ctx, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(All[UserRole](batch)); n != 3 {
+		t.Fatalf("expected 3 role artifacts for the batch, got %d", n)
+	}
+
+	// Without a bag, recording is a no-op
+	if RecordArtifact(ctx, "roles", &UserRole{}) {
+		t.Fatal("expected RecordArtifact to report no bag")
+	}
+	if _, err := users.Create(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
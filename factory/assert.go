@@ -0,0 +1,85 @@
+package factory
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Assertion checks the shape of a Scenario's results after every step has run.
+// Return a descriptive error when the dataset doesn't match.
+type Assertion func(r *Results) error
+
+// Assert adds post-run assertions. They run after the last step succeeds (and
+// before AfterAll hooks); every failure is reported, so a big seed that silently
+// produced too few rows fails loudly.
+// Example: s.Assert(AssertCount[User](14), AssertRatio[Comment, Post]("comments per post", 3, 7))
+func (s *Scenario) Assert(as ...Assertion) *Scenario {
+	s.asserts = append(s.asserts, as...)
+	return s
+}
+
+// AssertCount checks that the results hold exactly n records of type T across all keys.
+func AssertCount[T any](n int) Assertion {
+	return func(r *Results) error {
+		if got := len(All[T](r)); got != n {
+			return fmt.Errorf("factory: expected %d %s, got %d", n, typeName[T](), got)
+		}
+		return nil
+	}
+}
+
+// AssertKeyCount checks that the []*T stored under key holds exactly n records.
+func AssertKeyCount[T any](key string, n int) Assertion {
+	return func(r *Results) error {
+		items, ok := Lookup[[]*T](r, key)
+		if !ok {
+			return fmt.Errorf("factory: expected %d %s under %q, got none", n, typeName[T](), key)
+		}
+		if len(items) != n {
+			return fmt.Errorf("factory: expected %d %s under %q, got %d", n, typeName[T](), key, len(items))
+		}
+		return nil
+	}
+}
+
+// AssertRatio checks that the average number of C records per P record is within [lo, hi].
+// Example: AssertRatio[Comment, Post]("comments per post", 3, 7)
+func AssertRatio[C, P any](name string, lo, hi float64) Assertion {
+	return func(r *Results) error {
+		parents := len(All[P](r))
+		if parents == 0 {
+			return fmt.Errorf("factory: %s: no %s records", name, typeName[P]())
+		}
+		ratio := float64(len(All[C](r))) / float64(parents)
+		if ratio < lo || ratio > hi {
+			return fmt.Errorf("factory: %s: ratio %.2f outside [%g, %g]", name, ratio, lo, hi)
+		}
+		return nil
+	}
+}
+
+// AssertEach checks that every P record has between lo and hi C records, where
+// belongs reports whether a child belongs to a parent. Unlike AssertRatio it
+// catches one parent missing all its children while others have extra.
+// Example: AssertEach("posts per author", 1, 5, func(p *Post, u *User) bool { return p.AuthorID == u.ID })
+func AssertEach[C, P any](name string, lo, hi int, belongs func(c *C, p *P) bool) Assertion {
+	return func(r *Results) error {
+		children := All[C](r)
+		for i, p := range All[P](r) {
+			n := 0
+			for _, c := range children {
+				if belongs(c, p) {
+					n++
+				}
+			}
+			if n < lo || n > hi {
+				return fmt.Errorf("factory: %s: %s #%d has %d, expected [%d, %d]", name, typeName[P](), i, n, lo, hi)
+			}
+		}
+		return nil
+	}
+}
+
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
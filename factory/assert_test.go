@@ -0,0 +1,72 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestScenario_Assert(t *testing.T) {
+	s := newBlogScenario().Assert(
+		AssertCount[User](4),
+		AssertKeyCount[User]("authors", 3),
+		AssertRatio[Post, User]("posts per user", 1, 2),
+		AssertEach("posts per user", 0, 2, func(p *Post, u *User) bool { return p.AuthorID == u.ID }),
+	)
+	if _, err := s.Create(context.Background()); err != nil {
+		t.Fatalf("expected assertions to pass, got %v", err)
+	}
+}
+
+func TestScenario_Assert_Failures(t *testing.T) {
+	afterRan := false
+	s := newBlogScenario().Assert(
+		AssertCount[User](4),
+		AssertCount[Post](7),
+		AssertKeyCount[Post]("missing", 1),
+		AssertRatio[Post, User]("posts per user", 3, 7),
+		AssertEach("posts per author", 1, 5, func(p *Post, u *User) bool { return p.AuthorID == u.ID }),
+	).AfterAll("cleanup", func(ctx context.Context, r *Results) error {
+		afterRan = true
+		return nil
+	})
+
+	_, err := s.Create(context.Background())
+	if err == nil {
+		t.Fatal("expected assertion errors")
+	}
+	msg := err.Error()
+	for _, want := range []string{
+		"expected 7 factory.Post, got 6",
+		`expected 1 factory.Post under "missing", got none`,
+		"posts per user: ratio 1.50 outside [3, 7]",
+		"posts per author: factory.User #0 has 0, expected [1, 5]",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Fatalf("expected error to contain %q, got %v", want, msg)
+		}
+	}
+	if strings.Contains(msg, "factory.User, got") {
+		t.Fatalf("expected passing assertion to be silent, got %v", msg)
+	}
+	if !afterRan {
+		t.Fatal("expected AfterAll to run after failed assertions")
+	}
+}
+
+func TestScenario_Assert_SkippedOnStepError(t *testing.T) {
+	calls := 0
+	s := NewScenario("broken").
+		Step("fail", func(ctx context.Context, r *Results) error { return errors.New("boom") }).
+		Assert(func(r *Results) error {
+			calls++
+			return nil
+		})
+	if _, err := s.Create(context.Background()); err == nil {
+		t.Fatal("expected step error")
+	}
+	if calls != 0 {
+		t.Fatalf("expected assertions to be skipped after a failed step, got %d calls", calls)
+	}
+}
@@ -0,0 +1,149 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+)
+
+// AttachMany creates pivot records between existing parents and related models.
+// By default every (parent, related) pair is attached (the full bipartite set);
+// use Where or Sample to attach a subset.
+// Example: AttachMany(users, users, followFactory, func(f *Follow, a, b *User) { f.FollowerID, f.FolloweeID = a.ID, b.ID })
+func AttachMany[T any, R any, P any](
+	parents []*T,
+	related []*R,
+	pivotFactory *Factory[P],
+	linkFn func(pivot *P, parent *T, related *R),
+) *AttachManyFactory[T, R, P] {
+	return &AttachManyFactory[T, R, P]{
+		parents:      parents,
+		related:      related,
+		pivotFactory: pivotFactory,
+		linkFn:       linkFn,
+	}
+}
+
+// AttachManyFactory manages pivot records between existing models.
+type AttachManyFactory[T any, R any, P any] struct {
+	parents      []*T
+	related      []*R
+	pivotFactory *Factory[P]
+	linkFn       func(*P, *T, *R)
+	filters      []func(*T, *R) bool
+	unique       *pairGuard[T, R]
+}
+
+// pairGuard claims (parent, related) pair keys in a UniqueRegistry.
+// A nil guard allows every pair.
+type pairGuard[T any, R any] struct {
+	reg       *UniqueRegistry
+	namespace string
+	key       func(*T, *R) string
+}
+
+func (g *pairGuard[T, R]) claim(parent *T, rel *R) bool {
+	if g == nil {
+		return true
+	}
+	return g.reg.Claim(g.namespace, g.key(parent, rel))
+}
+
+// release frees a pair claimed for a pivot that failed to save, so a retry can use it.
+func (g *pairGuard[T, R]) release(parent *T, rel *R) {
+	if g != nil {
+		g.reg.Release(g.namespace, g.key(parent, rel))
+	}
+}
+
+// Where attaches only pairs for which fn returns true (e.g., skip self-follows).
+func (am *AttachManyFactory[T, R, P]) Where(fn func(parent *T, related *R) bool) *AttachManyFactory[T, R, P] {
+	am.filters = append(am.filters, fn)
+	return am
+}
+
+// Sample attaches each pair with probability p, drawing from rng.
+// A nil rng uses the math/rand global source; pass a seeded one for reproducible graphs.
+func (am *AttachManyFactory[T, R, P]) Sample(p float64, rng *rand.Rand) *AttachManyFactory[T, R, P] {
+	return am.Where(func(*T, *R) bool {
+		if rng == nil {
+			return rand.Float64() < p //nolint:gosec // test data, not security-sensitive
+		}
+		return rng.Float64() < p
+	})
+}
+
+// UniquePairs skips any (parent, related) pair whose key was already claimed in reg
+// under namespace, including pairs attached by other AttachMany/HasAttached calls
+// sharing the registry.
+func (am *AttachManyFactory[T, R, P]) UniquePairs(reg *UniqueRegistry, namespace string, key func(parent *T, related *R) string) *AttachManyFactory[T, R, P] {
+	am.unique = &pairGuard[T, R]{reg: reg, namespace: namespace, key: key}
+	return am
+}
+
+// pairs calls fn for every selected (parent, related) pair in order, releasing a
+// pair's claim when fn fails.
+func (am *AttachManyFactory[T, R, P]) pairs(fn func(parent *T, related *R) error) error {
+	for _, parent := range am.parents {
+		for _, rel := range am.related {
+			if !am.selected(parent, rel) || !am.unique.claim(parent, rel) {
+				continue
+			}
+			if err := fn(parent, rel); err != nil {
+				am.unique.release(parent, rel)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (am *AttachManyFactory[T, R, P]) selected(parent *T, rel *R) bool {
+	for _, keep := range am.filters {
+		if !keep(parent, rel) {
+			return false
+		}
+	}
+	return true
+}
+
+// Make builds pivot records for the selected pairs (in-memory only).
+func (am *AttachManyFactory[T, R, P]) Make() []P {
+	var pivots []P
+	_ = am.pairs(func(parent *T, rel *R) error {
+		pivot := am.pivotFactory.Make()
+		am.linkFn(&pivot, parent, rel)
+		pivots = append(pivots, pivot)
+		return nil
+	})
+	return pivots
+}
+
+// Create builds and persists pivot records for the selected pairs.
+// On error, returns the pivots created so far. Pivots saved with a *HookError (see
+// HookErrorsReturnRecord) are kept and their pairs stay claimed.
+func (am *AttachManyFactory[T, R, P]) Create(ctx context.Context) ([]*P, error) {
+	var pivots []*P
+	var hookErrs []error
+	err := am.pairs(func(parent *T, rel *R) error {
+		pivot, err := am.pivotFactory.Create(ctx, func(p *P) {
+			am.linkFn(p, parent, rel)
+		})
+		if pivot != nil {
+			pivots = append(pivots, pivot)
+			hookErrs = append(hookErrs, err)
+			return nil
+		}
+		return err
+	})
+	return pivots, errors.Join(append(hookErrs, err)...)
+}
+
+// MustCreate builds and persists pivot records for the selected pairs. Panics on error.
+func (am *AttachManyFactory[T, R, P]) MustCreate(ctx context.Context) []*P {
+	pivots, err := am.Create(ctx)
+	if err != nil {
+		panic("factory: AttachManyFactory.MustCreate failed: " + err.Error())
+	}
+	return pivots
+}
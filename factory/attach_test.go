@@ -0,0 +1,235 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+type Follow struct {
+	FollowerID string
+	FolloweeID string
+}
+
+func newFollowFactory() *Factory[Follow] {
+	return New(func(seq int64) Follow {
+		return Follow{}
+	}).WithPersist(func(ctx context.Context, f *Follow) (*Follow, error) {
+		return f, nil
+	})
+}
+
+func linkFollow(f *Follow, follower, followee *User) {
+	f.FollowerID = follower.ID
+	f.FolloweeID = followee.ID
+}
+
+func newUsers(n int) []*User {
+	users := make([]*User, n)
+	for i := range users {
+		users[i] = &User{ID: fmt.Sprintf("user-%d", i+1)}
+	}
+	return users
+}
+
+func TestFactory_AttachMany(t *testing.T) {
+	users := newUsers(3)
+	roles := []*Role{{ID: "role-1"}, {ID: "role-2"}}
+
+	pivots, err := AttachMany(users, roles, New(func(seq int64) UserRole {
+		return UserRole{Active: true}
+	}).WithPersist(func(ctx context.Context, ur *UserRole) (*UserRole, error) {
+		return ur, nil
+	}), func(ur *UserRole, u *User, r *Role) {
+		ur.UserID = u.ID
+		ur.RoleID = r.ID
+	}).Create(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pivots) != 6 {
+		t.Fatalf("expected full bipartite set of 6 pivots, got %d", len(pivots))
+	}
+	if pivots[0].UserID != "user-1" || pivots[0].RoleID != "role-1" {
+		t.Fatalf("unexpected first pivot: %+v", *pivots[0])
+	}
+	if pivots[5].UserID != "user-3" || pivots[5].RoleID != "role-2" {
+		t.Fatalf("unexpected last pivot: %+v", *pivots[5])
+	}
+}
+
+func TestFactory_AttachManyWhere(t *testing.T) {
+	users := newUsers(4)
+
+	follows := AttachMany(users, users, newFollowFactory(), linkFollow).
+		Where(func(a, b *User) bool { return a.ID != b.ID }).
+		Make()
+
+	if len(follows) != 12 {
+		t.Fatalf("expected 12 follows without self-follows, got %d", len(follows))
+	}
+	for _, f := range follows {
+		if f.FollowerID == f.FolloweeID {
+			t.Fatalf("unexpected self-follow: %+v", f)
+		}
+	}
+}
+
+func TestFactory_AttachManySample(t *testing.T) {
+	users := newUsers(20)
+
+	build := func() []Follow {
+		return AttachMany(users, users, newFollowFactory(), linkFollow).
+			Sample(0.25, rand.New(rand.NewSource(42))).
+			Make()
+	}
+
+	first := build()
+	if len(first) == 0 || len(first) >= 400 {
+		t.Fatalf("expected a sampled subset of 400 pairs, got %d", len(first))
+	}
+
+	second := build()
+	if len(first) != len(second) {
+		t.Fatalf("expected same seed to produce same subset, got %d and %d", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("pivot %d differs between seeded runs", i)
+		}
+	}
+}
+
+func TestFactory_AttachManyMustCreatePanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+
+	failing := New(func(seq int64) Follow {
+		return Follow{}
+	}).WithPersist(func(ctx context.Context, f *Follow) (*Follow, error) {
+		return nil, fmt.Errorf("insert failed")
+	})
+	AttachMany(newUsers(1), newUsers(1), failing, linkFollow).MustCreate(context.Background())
+}
+
+func TestFactory_AttachManyUniquePairs(t *testing.T) {
+	reg := NewUniqueRegistry()
+	users := newUsers(3)
+	key := func(a, b *User) string { return a.ID + ":" + b.ID }
+
+	first := AttachMany(users[:2], users, newFollowFactory(), linkFollow).
+		UniquePairs(reg, "follows", key).
+		MustCreate(context.Background())
+	if len(first) != 6 {
+		t.Fatalf("expected 6 follows, got %d", len(first))
+	}
+
+	// Overlapping run: only pairs for users[2] are new
+	second := AttachMany(users, users, newFollowFactory(), linkFollow).
+		UniquePairs(reg, "follows", key).
+		MustCreate(context.Background())
+	if len(second) != 3 {
+		t.Fatalf("expected 3 new follows, got %d", len(second))
+	}
+	for _, f := range second {
+		if f.FollowerID != "user-3" {
+			t.Fatalf("expected only user-3 follows, got %+v", *f)
+		}
+	}
+}
+
+func TestFactory_AttachManyUniquePairsReleasedOnError(t *testing.T) {
+	reg := NewUniqueRegistry()
+	users := newUsers(1)
+	key := func(a, b *User) string { return a.ID + ":" + b.ID }
+
+	failing := New(func(seq int64) Follow {
+		return Follow{}
+	}).WithPersist(func(ctx context.Context, f *Follow) (*Follow, error) {
+		return nil, fmt.Errorf("insert failed")
+	})
+	if _, err := AttachMany(users, users, failing, linkFollow).UniquePairs(reg, "follows", key).Create(context.Background()); err == nil {
+		t.Fatal("expected the insert to fail")
+	}
+	if reg.Has("follows", "user-1:user-1") {
+		t.Fatal("expected the failed pair to be released")
+	}
+
+	retry := AttachMany(users, users, newFollowFactory(), linkFollow).
+		UniquePairs(reg, "follows", key).
+		MustCreate(context.Background())
+	if len(retry) != 1 {
+		t.Fatalf("expected the retry to attach the pair, got %d", len(retry))
+	}
+}
+
+func TestFactory_AttachManyUniquePairsKeptOnHookError(t *testing.T) {
+	reg := NewUniqueRegistry()
+	users := newUsers(2)
+	key := func(a, b *User) string { return a.ID + ":" + b.ID }
+
+	hooked := newFollowFactory().
+		AfterCreate(func(ctx context.Context, f *Follow) error { return fmt.Errorf("notify failed") }).
+		WithHookErrorPolicy(HookErrorsReturnRecord)
+	follows, err := AttachMany(users[:1], users, hooked, linkFollow).UniquePairs(reg, "follows", key).Create(context.Background())
+	var hookErr *HookError
+	if !errors.As(err, &hookErr) || len(follows) != 2 {
+		t.Fatalf("expected both saved follows with a hook error, got %d and %v", len(follows), err)
+	}
+	if !reg.Has("follows", "user-1:user-2") {
+		t.Fatal("expected saved pairs to stay claimed")
+	}
+}
+
+func TestFactory_HasAttachedUniquePairs(t *testing.T) {
+	userFactory := New(func(seq int64) User {
+		return User{ID: "user-1"}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		return u, nil
+	})
+
+	// Only two distinct roles, so a count of 4 repeats pairs
+	roleFactory := New(func(seq int64) Role {
+		return Role{ID: fmt.Sprintf("role-%d", seq%2)}
+	}).WithPersist(func(ctx context.Context, r *Role) (*Role, error) {
+		return r, nil
+	})
+
+	pivotFactory := New(func(seq int64) UserRole {
+		return UserRole{}
+	}).WithPersist(func(ctx context.Context, ur *UserRole) (*UserRole, error) {
+		return ur, nil
+	})
+
+	link := func(ur *UserRole, u *User, r *Role) {
+		ur.UserID = u.ID
+		ur.RoleID = r.ID
+	}
+	key := func(u *User, r *Role) string { return u.ID + ":" + r.ID }
+
+	_, roles, pivots, err := HasAttached(userFactory, roleFactory, pivotFactory, 4, link).
+		UniquePairs(NewUniqueRegistry(), "user_roles", key).
+		Create(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roles) != 4 {
+		t.Fatalf("expected 4 roles, got %d", len(roles))
+	}
+	if len(pivots) != 2 {
+		t.Fatalf("expected 2 unique pivots, got %d", len(pivots))
+	}
+
+	_, _, madePivots := HasAttached(userFactory, roleFactory, pivotFactory, 4, link).
+		UniquePairs(NewUniqueRegistry(), "user_roles", key).
+		Make()
+	if len(madePivots) != 2 {
+		t.Fatalf("expected 2 unique pivots from Make, got %d", len(madePivots))
+	}
+}
@@ -0,0 +1,62 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+)
+
+// Backfill adds a final scenario pass that links each childKey record for which missing
+// is true to a random parentKey record, then saves it with update (nil skips saving).
+// Example:
+//
+//	Backfill(s, "comments", "users",
+//		func(c *Comment) bool { return c.AuthorID == "" },
+//		func(c *Comment, u *User) { c.AuthorID = u.ID },
+//		commentRepo.Update)
+func Backfill[C any, P any](
+	s *Scenario,
+	childKey, parentKey string,
+	missing func(child *C) bool,
+	link func(child *C, parent *P),
+	update func(ctx context.Context, child *C) error,
+) *Scenario {
+	fn := func(ctx context.Context, r *Results) error {
+		var orphans []*C
+		for _, child := range recordsUnder[C](r, childKey) {
+			if missing(child) {
+				orphans = append(orphans, child)
+			}
+		}
+		if len(orphans) == 0 {
+			return nil
+		}
+		parents := recordsUnder[P](r, parentKey)
+		if len(parents) == 0 {
+			return fmt.Errorf("factory: %d %s records need a parent, but no %s records are stored under '%s'",
+				len(orphans), typeName[C](), typeName[P](), parentKey)
+		}
+		for _, child := range orphans {
+			link(child, parents[s.rand.intn(len(parents))])
+			if update == nil {
+				continue
+			}
+			if err := update(ctx, child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	s.backfills = append(s.backfills, scenarioStep{name: childKey + " -> " + parentKey, fn: fn})
+	return s
+}
+
+// recordsUnder returns the records stored under key as []*T or *T (nil otherwise).
+func recordsUnder[T any](r *Results, key string) []*T {
+	switch v := records[T](r, key).(type) {
+	case []*T:
+		return v
+	case *T:
+		return []*T{v}
+	}
+	return nil
+}
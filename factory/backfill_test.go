@@ -0,0 +1,45 @@
+package factory
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestBackfill(t *testing.T) {
+	var updated []*Post
+	s := NewScenario("orphans").
+		Step("users", FixtureStep("users", User{ID: "u1"}, User{ID: "u2"})).
+		Step("posts", FixtureStep("posts", Post{ID: "p1", AuthorID: "u1"}, Post{ID: "p2"}, Post{ID: "p3"}))
+	Backfill(s, "posts", "users",
+		func(p *Post) bool { return p.AuthorID == "" },
+		func(p *Post, u *User) { p.AuthorID = u.ID },
+		func(ctx context.Context, p *Post) error {
+			updated = append(updated, p)
+			return nil
+		})
+
+	r := s.MustCreate(context.Background())
+	for _, p := range Get[[]*Post](r, "posts") {
+		if p.AuthorID != "u1" && p.AuthorID != "u2" {
+			t.Fatalf("expected every post to have an author, got %+v", p)
+		}
+	}
+	if len(updated) != 2 || updated[0].ID != "p2" || updated[1].ID != "p3" {
+		t.Fatalf("expected only the orphans to be updated, got %v", updated)
+	}
+}
+
+func TestBackfill_NoParents(t *testing.T) {
+	s := NewScenario("no parents").
+		Step("posts", FixtureStep("posts", Post{ID: "p1"}))
+	Backfill(s, "posts", "users",
+		func(p *Post) bool { return p.AuthorID == "" },
+		func(p *Post, u *User) { p.AuthorID = u.ID },
+		nil)
+
+	_, err := s.Create(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "no factory.User records") {
+		t.Fatalf("expected a missing parents error, got %v", err)
+	}
+}
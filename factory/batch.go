@@ -0,0 +1,116 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// PersistManyFn saves many *T in one call (e.g., a bulk INSERT) and returns the saved
+// items in the same order.
+type PersistManyFn[T any] func(ctx context.Context, items []*T) ([]*T, error)
+
+// WithPersistMany sets a batch persister used by CreateManyBatch, so large seed jobs
+// issue one bulk insert instead of one round trip per row.
+// Example: factory.WithPersistMany(func(ctx context.Context, us []*User) ([]*User, error) { return repo.InsertAll(ctx, us) })
+func (f *Factory[T]) WithPersistMany(p PersistManyFn[T]) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.persistMany = p
+	return f
+}
+
+// CreateManyBatch builds count items, runs BeforeCreate hooks on each, saves them all
+// with one PersistManyFn call, then runs the per-item after hooks (honoring the hook
+// error policy) and AfterCreateBatch hooks, like CreateMany.
+//
+// It falls back to CreateMany when no batch persister is set, while planning (see
+// Scenario.Plan), and when per-item routing or deduplication is configured
+// (WithShardRouter, WithIdentity). Group middleware and WithChaos wrap per-item
+// persistence only, so they do not see the bulk call.
+// Example: users, err := userFactory.CreateManyBatch(ctx, 10000)
+func (f *Factory[T]) CreateManyBatch(ctx context.Context, count int, ts ...Trait[T]) ([]*T, error) {
+	v := f.view()
+	f = &v
+	if f.persistMany == nil || f.router != nil || f.identity != nil || plannerFrom(ctx) != nil {
+		return f.CreateMany(ctx, count, ts...)
+	}
+	ctx = f.withBase(ctx)
+	if err := f.checkEnvironment(ctx); err != nil {
+		return nil, err
+	}
+
+	items := make([]*T, count)
+	for i := range items {
+		obj := f.Make(ts...)
+		f.recordBuilt(ctx, &obj)
+		for _, h := range f.before {
+			if err := h(ctx, &obj); err != nil {
+				return nil, err
+			}
+		}
+		items[i] = &obj
+	}
+
+	// Snapshot the built values; persist may modify items in place
+	made := make([]T, count)
+	if len(f.afterDiff) > 0 {
+		for i, item := range items {
+			made[i] = *item
+		}
+	}
+
+	if c := PersistCounterFrom(ctx); c != nil {
+		c.record(typeName[T]())
+	}
+	out, err := f.persistMany(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) != count {
+		return out, fmt.Errorf("factory: PersistManyFn returned %d items for a batch of %d", len(out), count)
+	}
+	var prov Provenance
+	if f.sidecar != nil {
+		prov = f.Provenance()
+	}
+	for _, item := range out {
+		recordCreated(ctx, item)
+		if f.sidecar != nil {
+			if err := f.sidecar(ctx, item, prov); err != nil {
+				return out, err
+			}
+		}
+	}
+
+	var hookErrs []error
+	for i, item := range out {
+		if f.hookPolicy == HookErrorsReturnRecord {
+			if _, err := f.runAfterHooksCollect(ctx, &made[i], item); err != nil {
+				hookErrs = append(hookErrs, err)
+			}
+			continue
+		}
+		for _, h := range f.after {
+			if err := h(ctx, item); err != nil {
+				return out, err
+			}
+		}
+		for _, h := range f.afterDiff {
+			if err := h(ctx, &made[i], item); err != nil {
+				return out, err
+			}
+		}
+	}
+	return f.finishBatch(ctx, out, errors.Join(hookErrs...))
+}
+
+// MustCreateManyBatch is CreateManyBatch that panics on error.
+func (f *Factory[T]) MustCreateManyBatch(ctx context.Context, count int, ts ...Trait[T]) []*T {
+	ctx, rec := withBuiltRecorder(ctx)
+	items, err := f.CreateManyBatch(ctx, count, ts...)
+	if err != nil {
+		panic(f.failure("MustCreateManyBatch", err, rec, len(ts)))
+	}
+	return items
+}
@@ -0,0 +1,82 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFactory_CreateManyBatch(t *testing.T) {
+	var single, bulk int
+	var order []string
+	f := New(func(seq int64) User { return User{Name: fmt.Sprintf("User %d", seq)} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) {
+			single++
+			return u, nil
+		}).
+		WithPersistMany(func(ctx context.Context, users []*User) ([]*User, error) {
+			bulk++
+			for i, u := range users {
+				u.ID = fmt.Sprint(i + 1)
+			}
+			return users, nil
+		}).
+		BeforeCreate(func(ctx context.Context, u *User) error {
+			order = append(order, "before")
+			return nil
+		}).
+		AfterCreate(func(ctx context.Context, u *User) error {
+			order = append(order, "after:"+u.ID)
+			return nil
+		}).
+		AfterCreateBatch(func(ctx context.Context, users []*User) error {
+			order = append(order, fmt.Sprintf("batch:%d", len(users)))
+			return nil
+		})
+
+	counter := NewPersistCounter()
+	users, err := f.CreateManyBatch(WithPersistCounter(context.Background(), counter), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 3 || users[2].ID != "3" || bulk != 1 || single != 0 || counter.Total() != 1 {
+		t.Fatalf("expected one bulk call, got bulk=%d single=%d counter=%d", bulk, single, counter.Total())
+	}
+	if got := strings.Join(order, ","); got != "before,before,before,after:1,after:2,after:3,batch:3" {
+		t.Fatalf("unexpected hook order %s", got)
+	}
+
+	// Falls back to per-item persistence without a batch persister
+	order = nil
+	fallback := f.UsingPersist(func(ctx context.Context, u *User) (*User, error) {
+		single++
+		return u, nil
+	})
+	if _, err := fallback.CreateManyBatch(context.Background(), 2); err != nil || single != 2 || bulk != 1 {
+		t.Fatalf("expected per-item fallback, got err=%v single=%d bulk=%d", err, single, bulk)
+	}
+}
+
+func TestFactory_CreateManyBatchErrors(t *testing.T) {
+	boom := errors.New("bulk insert failed")
+	f := New(func(seq int64) User { return User{} }).
+		WithPersistMany(func(ctx context.Context, users []*User) ([]*User, error) { return nil, boom })
+	if _, err := f.CreateManyBatch(context.Background(), 2); !errors.Is(err, boom) {
+		t.Fatalf("expected persist error, got %v", err)
+	}
+
+	short := New(func(seq int64) User { return User{} }).
+		WithPersistMany(func(ctx context.Context, users []*User) ([]*User, error) { return users[:1], nil })
+	if _, err := short.CreateManyBatch(context.Background(), 2); err == nil || !strings.Contains(err.Error(), "returned 1 items for a batch of 2") {
+		t.Fatalf("expected length mismatch error, got %v", err)
+	}
+
+	defer func() {
+		if r := recover(); r == nil || !strings.HasPrefix(r.(string), "factory: MustCreateManyBatch failed: bulk insert failed") {
+			t.Fatalf("unexpected panic %v", r)
+		}
+	}()
+	f.MustCreateManyBatch(context.Background(), 1)
+}
@@ -0,0 +1,89 @@
+package factory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Raw builds the parent and children like Make, with rawDefaults applied.
+func (hf *HasFactory[T, R]) Raw() (T, []R) {
+	return hf.build(hf.parent.Raw, hf.child.Raw)
+}
+
+// RawJSON renders the parent and its children as one nested JSON document, with the
+// children under key, for API endpoints that accept nested create payloads (e.g., an
+// order with line items). Fields named in omit are removed from each child, typically
+// the foreign key the server fills in.
+// Example: Has(orderFactory, lineFactory, 3, linkFn).RawJSON("line_items", "OrderID")
+func (hf *HasFactory[T, R]) RawJSON(key string, omit ...string) ([]byte, error) {
+	parent, children := hf.Raw()
+	return bundleJSON(parent, omit, nested{key, children})
+}
+
+// Raw builds the parent, related items, and pivots like Make, with rawDefaults applied.
+func (haf *HasAttachedFactory[T, R, P]) Raw() (T, []R, []P) {
+	return haf.build(haf.parent.Raw, haf.related.Raw, haf.pivotFactory.Raw)
+}
+
+// RawJSON renders the parent as one nested JSON document with related items under
+// relatedKey and pivots under pivotKey. An empty key leaves that list out. Fields named
+// in omit are removed from every nested item.
+// Example: HasAttached(userFactory, roleFactory, pivotFactory, 2, linkFn).RawJSON("roles", "", "UserID")
+func (haf *HasAttachedFactory[T, R, P]) RawJSON(relatedKey, pivotKey string, omit ...string) ([]byte, error) {
+	parent, related, pivots := haf.Raw()
+	return bundleJSON(parent, omit, nested{relatedKey, related}, nested{pivotKey, pivots})
+}
+
+// nested is a list embedded under key in a bundle ("" skips it).
+type nested struct {
+	key   string
+	items any
+}
+
+// bundleJSON marshals parent as an object with each list embedded under its key.
+func bundleJSON(parent any, omit []string, lists ...nested) ([]byte, error) {
+	doc, err := toObject(parent)
+	if err != nil {
+		return nil, fmt.Errorf("factory: bundle parent: %w", err)
+	}
+
+	for _, list := range lists {
+		if list.key == "" {
+			continue
+		}
+		var items []map[string]any
+		if err := roundTrip(list.items, &items); err != nil {
+			return nil, fmt.Errorf("factory: bundle %q: %w", list.key, err)
+		}
+		for _, item := range items {
+			for _, field := range omit {
+				delete(item, field)
+			}
+		}
+		doc[list.key] = items
+	}
+	return json.Marshal(doc)
+}
+
+// toObject converts v to a JSON object, keeping numbers exact.
+func toObject(v any) (map[string]any, error) {
+	var doc map[string]any
+	if err := roundTrip(v, &doc); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("%T does not encode as a JSON object", v)
+	}
+	return doc, nil
+}
+
+func roundTrip(v, out any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(out)
+}
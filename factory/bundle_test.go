@@ -0,0 +1,95 @@
+package factory
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+type Order struct {
+	ID       string `json:"id"`
+	Customer string `json:"customer"`
+}
+
+type LineItem struct {
+	OrderID  string `json:"order_id"`
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+func TestHasFactory_RawJSON(t *testing.T) {
+	orderFactory := New(func(seq int64) Order {
+		return Order{ID: fmt.Sprintf("order-%d", seq)}
+	}).WithRawDefaults(func(o *Order) {
+		o.Customer = "api-client"
+	})
+	lineFactory := New(func(seq int64) LineItem {
+		return LineItem{SKU: fmt.Sprintf("SKU-%d", seq), Quantity: int(seq)}
+	})
+
+	data, err := Has(orderFactory, lineFactory, 2, func(o *Order, l *LineItem) {
+		l.OrderID = o.ID
+	}).RawJSON("line_items", "order_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"customer":"api-client","id":"order-1","line_items":[{"quantity":1,"sku":"SKU-1"},{"quantity":2,"sku":"SKU-2"}]}`
+	if string(data) != want {
+		t.Fatalf("expected %s, got %s", want, data)
+	}
+}
+
+func TestHasAttachedFactory_RawJSON(t *testing.T) {
+	userFactory := New(func(seq int64) User {
+		return User{ID: fmt.Sprintf("user-%d", seq)}
+	})
+	roleFactory := New(func(seq int64) Role {
+		return Role{ID: fmt.Sprintf("role-%d", seq), Name: "editor"}
+	})
+	pivotFactory := New(func(seq int64) UserRole {
+		return UserRole{Active: true}
+	})
+
+	data, err := HasAttached(userFactory, roleFactory, pivotFactory, 2, func(p *UserRole, u *User, r *Role) {
+		p.UserID, p.RoleID = u.ID, r.ID
+	}).RawJSON("Roles", "Memberships", "UserID")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		ID          string
+		Roles       []map[string]any
+		Memberships []map[string]any
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.ID != "user-1" || len(doc.Roles) != 2 || len(doc.Memberships) != 2 {
+		t.Fatalf("expected user with 2 roles and memberships, got %s", data)
+	}
+	if _, ok := doc.Memberships[0]["UserID"]; ok {
+		t.Fatalf("expected UserID to be omitted, got %v", doc.Memberships[0])
+	}
+	if doc.Memberships[1]["RoleID"] != "role-2" {
+		t.Fatalf("expected pivot to keep RoleID, got %v", doc.Memberships[1])
+	}
+
+	rolesOnly, err := HasAttached(userFactory, roleFactory, pivotFactory, 1, func(p *UserRole, u *User, r *Role) {}).RawJSON("Roles", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]any
+	_ = json.Unmarshal(rolesOnly, &m)
+	if _, ok := m["Memberships"]; ok || m["Roles"] == nil {
+		t.Fatalf("expected only roles to be embedded, got %s", rolesOnly)
+	}
+}
+
+func TestHasFactory_RawJSONNonObjectParent(t *testing.T) {
+	_, err := Has(New(func(seq int64) int { return int(seq) }), New(func(seq int64) Post { return Post{} }), 1, nil).RawJSON("posts")
+	if err == nil {
+		t.Fatal("expected error for parent that is not a JSON object")
+	}
+}
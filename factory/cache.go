@@ -0,0 +1,48 @@
+package factory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// CacheSetter writes a value to a cache such as Redis or memcached.
+// Adapt a client with CacheSetterFunc, e.g. for go-redis:
+//
+//	factory.CacheSetterFunc(func(ctx context.Context, key string, v []byte, ttl time.Duration) error {
+//		return rdb.Set(ctx, key, v, ttl).Err()
+//	})
+type CacheSetter interface {
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// CacheSetterFunc adapts a function to CacheSetter.
+type CacheSetterFunc func(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+// Set calls fn.
+func (fn CacheSetterFunc) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return fn(ctx, key, value, ttl)
+}
+
+// CachePersist wraps p so every saved record is also written, JSON-encoded, to cache
+// under key(record). Tests of cache-aside code then start with a warm cache that
+// matches the rows the factory created. A ttl of 0 means no expiry (as in Redis SET).
+// Example: userFactory.WithPersist(CachePersist(repo.Save, cache, func(u *User) string { return "user:" + u.ID }, time.Hour))
+func CachePersist[T any](p PersistFn[T], cache CacheSetter, key func(*T) string, ttl time.Duration) PersistFn[T] {
+	return func(ctx context.Context, t *T) (*T, error) {
+		out, err := p(ctx, t)
+		if err != nil {
+			return out, err
+		}
+		value, err := json.Marshal(out)
+		if err != nil {
+			return out, fmt.Errorf("factory: encoding cache value: %w", err)
+		}
+		k := key(out)
+		if err := cache.Set(ctx, k, value, ttl); err != nil {
+			return out, fmt.Errorf("factory: warming cache key %s: %w", k, err)
+		}
+		return out, nil
+	}
+}
@@ -0,0 +1,62 @@
+package factory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCachePersist(t *testing.T) {
+	cache := map[string][]byte{}
+	var ttls []time.Duration
+	setter := CacheSetterFunc(func(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+		cache[key] = value
+		ttls = append(ttls, ttl)
+		return nil
+	})
+
+	f := New(func(seq int64) User {
+		return User{Name: fmt.Sprintf("User %d", seq)}
+	}).WithPersist(CachePersist(func(ctx context.Context, u *User) (*User, error) {
+		u.ID = fmt.Sprintf("db-%d", len(cache)+1)
+		return u, nil
+	}, setter, func(u *User) string { return "user:" + u.ID }, time.Hour))
+
+	f.MustCreateMany(context.Background(), 2)
+
+	var cached User
+	if err := json.Unmarshal(cache["user:db-2"], &cached); err != nil {
+		t.Fatalf("expected cached JSON for saved ID, got %v", cache)
+	}
+	if cached.Name != "User 2" || ttls[0] != time.Hour {
+		t.Fatalf("expected cached record with TTL, got %+v %v", cached, ttls)
+	}
+}
+
+func TestCachePersist_Errors(t *testing.T) {
+	boom := errors.New("redis down")
+	calls := 0
+	setter := CacheSetterFunc(func(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+		calls++
+		return boom
+	})
+	key := func(u *User) string { return u.ID }
+
+	_, err := CachePersist(func(ctx context.Context, u *User) (*User, error) {
+		return u, nil
+	}, setter, key, 0)(context.Background(), &User{ID: "1"})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected cache error, got %v", err)
+	}
+
+	calls = 0
+	_, err = CachePersist(func(ctx context.Context, u *User) (*User, error) {
+		return nil, errors.New("insert failed")
+	}, setter, key, 0)(context.Background(), &User{})
+	if err == nil || calls != 0 {
+		t.Fatalf("expected persist error without caching, got %v, calls=%d", err, calls)
+	}
+}
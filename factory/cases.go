@@ -0,0 +1,30 @@
+package factory
+
+// TestCase is one factory variant for a table-driven test.
+type TestCase[T any] struct {
+	Name  string // Subtest name: the state, or "default" for the base factory
+	Input T
+	State string // State applied ("" for the default case)
+}
+
+// Cases builds one TestCase per state, for use directly in t.Run loops. With no
+// arguments it covers the base factory (named "default") followed by every state
+// from States, in sorted order. Each case is built like State(name).Make(), sharing
+// the factory's sequence counter. Panics with the same message as State if a name is not defined.
+// Example: for _, tc := range userFactory.Cases() { t.Run(tc.Name, func(t *testing.T) { ... tc.Input ... }) }
+func (f *Factory[T]) Cases(states ...string) []TestCase[T] {
+	if len(states) == 0 {
+		states = append([]string{""}, f.States()...)
+	}
+	cases := make([]TestCase[T], len(states))
+	for i, name := range states {
+		if name == "" {
+			cases[i] = TestCase[T]{Name: "default", Input: f.Make()}
+			continue
+		}
+		state := f.State(name)
+		state.sharedSeq = f.counter()
+		cases[i] = TestCase[T]{Name: name, Input: state.Make(), State: name}
+	}
+	return cases
+}
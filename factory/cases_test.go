@@ -0,0 +1,58 @@
+package factory
+
+import "testing"
+
+func TestFactory_Cases(t *testing.T) {
+	f := New(func(seq int64) User { return User{Name: "User", Email: "user@example.com"} }).
+		DefineState("no-email", func(u *User) { u.Email = "" }).
+		DefineState("admin", func(u *User) { u.Name = "Admin" })
+
+	var names []string
+	for _, tc := range f.Cases() {
+		t.Run(tc.Name, func(t *testing.T) {
+			names = append(names, tc.Name)
+			switch tc.State {
+			case "":
+				if tc.Input.Name != "User" || tc.Input.Email == "" {
+					t.Fatalf("expected base item, got %+v", tc.Input)
+				}
+			case "admin":
+				if tc.Input.Name != "Admin" {
+					t.Fatalf("expected admin state, got %+v", tc.Input)
+				}
+			case "no-email":
+				if tc.Input.Email != "" {
+					t.Fatalf("expected no-email state, got %+v", tc.Input)
+				}
+			}
+		})
+	}
+	if len(names) != 3 || names[0] != "default" || names[1] != "admin" || names[2] != "no-email" {
+		t.Fatalf("unexpected cases %v", names)
+	}
+
+	if cases := f.Cases("admin"); len(cases) != 1 || cases[0].State != "admin" {
+		t.Fatalf("expected only the requested state, got %+v", cases)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for unknown state")
+		}
+	}()
+	f.Cases("missing")
+}
+
+func TestFactory_CasesMatchState(t *testing.T) {
+	f := New(func(seq int64) User { return User{Name: "User"} }).
+		DefineState("admin", func(u *User) { u.Name = "Admin" }).
+		Sequence(func(u *User) { u.Name = "Sequenced" })
+
+	want := f.State("admin").Make()
+	if got := f.Cases("admin")[0].Input; got != want {
+		t.Fatalf("expected the same input as State, got %+v, want %+v", got, want)
+	}
+	if f.CurrentSequence() != 1 {
+		t.Fatalf("expected the case to advance the shared sequence, got %d", f.CurrentSequence())
+	}
+}
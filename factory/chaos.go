@@ -0,0 +1,51 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrChaos is the error injected by WithChaos. Use errors.Is to tell injected
+// failures apart from real ones.
+var ErrChaos = errors.New("factory: injected persist failure")
+
+// chaos holds the settings of WithChaos.
+type chaos struct {
+	failureRate float64
+	latency     func() time.Duration
+}
+
+// WithChaos makes each persist call wait latency() (nil means none), then fail with
+// ErrChaos with probability failureRate, for testing retries and error handling.
+// Panics if failureRate is outside [0, 1].
+// Example: userFactory.WithChaos(0.1, func() time.Duration { return 5 * time.Millisecond })
+func (f *Factory[T]) WithChaos(failureRate float64, latency func() time.Duration) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	if !(failureRate >= 0 && failureRate <= 1) { // Also rejects NaN
+		panic("factory: WithChaos failure rate must be between 0 and 1")
+	}
+	f.chaos = &chaos{failureRate: failureRate, latency: latency}
+	return f
+}
+
+// wrapChaos returns p with the factory's chaos settings applied.
+func (f *Factory[T]) wrapChaos(p PersistFn[T]) PersistFn[T] {
+	c := f.chaos
+	return func(ctx context.Context, t *T) (*T, error) {
+		if c.latency != nil {
+			timer := time.NewTimer(c.latency())
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+		}
+		if c.failureRate > 0 && f.group.float64() < c.failureRate {
+			return nil, ErrChaos
+		}
+		return p(ctx, t)
+	}
+}
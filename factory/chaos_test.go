@@ -0,0 +1,75 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFactory_WithChaos(t *testing.T) {
+	saved := 0
+	f := NewIn(NewGroup().WithSeed(1), func(seq int64) User { return User{} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) {
+			saved++
+			return u, nil
+		}).
+		WithChaos(0.5, nil)
+
+	failed := 0
+	for i := 0; i < 200; i++ {
+		if _, err := f.Create(context.Background()); err != nil {
+			if !errors.Is(err, ErrChaos) {
+				t.Fatalf("expected ErrChaos, got %v", err)
+			}
+			failed++
+		}
+	}
+	if failed < 60 || failed > 140 || saved != 200-failed {
+		t.Fatalf("expected about half to fail, got failed=%d saved=%d", failed, saved)
+	}
+
+	// Never fails at rate 0, always at rate 1
+	if _, err := f.Clone().WithChaos(0, nil).Create(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Clone().WithChaos(1, nil).Create(context.Background()); !errors.Is(err, ErrChaos) {
+		t.Fatalf("expected ErrChaos, got %v", err)
+	}
+}
+
+func TestFactory_WithChaos_Latency(t *testing.T) {
+	f := New(func(seq int64) User { return User{} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) { return u, nil }).
+		WithChaos(0, func() time.Duration { return 20 * time.Millisecond })
+
+	start := time.Now()
+	if _, err := f.Create(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(start) < 20*time.Millisecond {
+		t.Fatal("expected injected latency")
+	}
+
+	// A canceled context stops the wait
+	f.WithChaos(0, func() time.Duration { return time.Hour })
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := f.Create(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestFactory_WithChaos_InvalidRate(t *testing.T) {
+	for _, rate := range []float64{-0.1, 1.5, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for rate %v", rate)
+				}
+			}()
+			New(func(seq int64) User { return User{} }).WithChaos(rate, nil)
+		}()
+	}
+}
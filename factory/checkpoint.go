@@ -0,0 +1,173 @@
+package factory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// Sequencer is a sequence counter a checkpoint can save and restore.
+// *Factory[T] implements it.
+type Sequencer interface {
+	CurrentSequence() int64
+	SwapSequence(n int64) int64
+}
+
+// Checkpoint is the on-disk progress of a Scenario run with WithCheckpoint.
+type Checkpoint struct {
+	Scenario  string             `json:"scenario"`
+	Completed []string           `json:"completed"`
+	Results   []CheckpointResult `json:"results"`
+	Sequences map[string]int64   `json:"sequences,omitempty"`
+	Seed      int64              `json:"seed"`
+}
+
+// CheckpointResult is one saved Results entry.
+type CheckpointResult struct {
+	Key   string          `json:"key"`
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+type trackedSequence struct {
+	name string
+	seq  Sequencer
+}
+
+type checkpointKey struct{}
+
+// WithCheckpoint records progress to path after every completed step, so an
+// aborted run resumes from the last checkpoint instead of starting over.
+// On resume, completed steps are skipped, their results are restored (decoded
+// on first read, so values must round-trip through JSON), and tracked sequences
+// and the random seed are restored. BeforeAll hooks run again on every attempt.
+// The file is removed once the run succeeds.
+// Example: s.WithCheckpoint("testdata/seed.checkpoint.json").TrackSequence("users", userFactory)
+func (s *Scenario) WithCheckpoint(path string) *Scenario {
+	s.checkpoint = path
+	return s
+}
+
+// TrackSequence saves and restores a sequence counter with the checkpoint, so
+// resumed steps continue numbering where the aborted run stopped.
+func (s *Scenario) TrackSequence(name string, seq Sequencer) *Scenario {
+	s.sequences = append(s.sequences, trackedSequence{name: name, seq: seq})
+	return s
+}
+
+// TrackRand reseeds g with seed plus the step index before each step, so a
+// resumed step draws the same random values as it would have in the aborted run
+// (math/rand state itself cannot be saved).
+func (s *Scenario) TrackRand(g *Group, seed int64) *Scenario {
+	s.rand = g
+	s.seed = seed
+	return s
+}
+
+// SaveCheckpoint writes the current progress of a step mid-way (e.g., after each
+// batch of a long CreateBatchStep). It is a no-op outside a checkpointed scenario.
+func SaveCheckpoint(ctx context.Context, r *Results) error {
+	save, ok := ctx.Value(checkpointKey{}).(func(*Results) error)
+	if !ok {
+		return nil
+	}
+	return save(r)
+}
+
+// CreateBatchStep returns a step that creates count items in batches of size and
+// stores them as []*T under key, saving a checkpoint after each batch. When
+// resumed, items already restored under key count toward count.
+// Example: s.Step("events", CreateBatchStep("events", eventFactory, 100000, 5000))
+func CreateBatchStep[T any](key string, f *Factory[T], count, size int, ts ...Trait[T]) Step {
+	if size < 1 {
+		panic("factory: CreateBatchStep requires a positive batch size")
+	}
+	return func(ctx context.Context, r *Results) error {
+		done, _ := Lookup[[]*T](r, key)
+		if done == nil {
+			r.Set(key, []*T{})
+		}
+		for remaining := count - len(done); remaining > 0; remaining -= size {
+			n := size
+			if remaining < n {
+				n = remaining
+			}
+			items, err := f.CreateMany(ctx, n, ts...)
+			Add(r, key, items...)
+			if err != nil {
+				return err
+			}
+			if err := SaveCheckpoint(ctx, r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// loadCheckpoint reads the checkpoint file, restoring results and sequences.
+// Returns a nil checkpoint when there is no file.
+func (s *Scenario) loadCheckpoint(r *Results) (*Checkpoint, error) {
+	data, err := os.ReadFile(s.checkpoint)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("factory: checkpoint %s: %w", s.checkpoint, err)
+	}
+	if cp.Scenario != s.name {
+		return nil, fmt.Errorf("factory: checkpoint %s belongs to scenario %q", s.checkpoint, cp.Scenario)
+	}
+	if s.rand != nil && cp.Seed != s.seed {
+		return nil, fmt.Errorf("factory: checkpoint %s was made with seed %d, not %d", s.checkpoint, cp.Seed, s.seed)
+	}
+	for _, res := range cp.Results {
+		r.Set(res.Key, restored{data: res.Value, typ: res.Type})
+	}
+	for _, ts := range s.sequences {
+		if n, ok := cp.Sequences[ts.name]; ok {
+			ts.seq.SwapSequence(n)
+		}
+	}
+	return &cp, nil
+}
+
+// saveCheckpoint writes progress atomically (via a temporary file and rename).
+func (s *Scenario) saveCheckpoint(completed []string, r *Results) error {
+	cp := Checkpoint{Scenario: s.name, Completed: completed, Seed: s.seed}
+	for _, key := range r.keys {
+		v := r.values[key]
+		if rv, ok := v.(restored); ok {
+			cp.Results = append(cp.Results, CheckpointResult{Key: key, Type: rv.typ, Value: rv.data})
+			continue
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("factory: checkpoint result %q: %w", key, err)
+		}
+		cp.Results = append(cp.Results, CheckpointResult{Key: key, Type: fmt.Sprintf("%T", v), Value: data})
+	}
+	if len(s.sequences) > 0 {
+		cp.Sequences = make(map[string]int64, len(s.sequences))
+		for _, ts := range s.sequences {
+			cp.Sequences[ts.name] = ts.seq.CurrentSequence()
+		}
+	}
+
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.checkpoint + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.checkpoint)
+}
@@ -0,0 +1,120 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScenario_WithCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seed.json")
+	g := NewGroup()
+	userFactory := NewIn(g, func(seq int64) User {
+		return User{ID: fmt.Sprint(seq), Name: fmt.Sprintf("User %d", g.Intn(1000))}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		return u, nil
+	})
+
+	failPosts := true
+	newScenario := func() *Scenario {
+		return NewScenario("resumable").
+			WithCheckpoint(path).
+			TrackSequence("users", userFactory).
+			TrackRand(g, 42).
+			Step("users", CreateStep("users", userFactory, 3)).
+			Step("posts", func(ctx context.Context, r *Results) error {
+				if failPosts {
+					return errors.New("connection lost")
+				}
+				for _, u := range Get[[]*User](r, "users") {
+					Add(r, "posts", &Post{AuthorID: u.ID})
+				}
+				return nil
+			}).
+			Step("more users", CreateStep("more", userFactory, 1))
+	}
+
+	first, err := newScenario().Create(context.Background())
+	if err == nil {
+		t.Fatal("expected first run to fail")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected checkpoint file, got %v", err)
+	}
+
+	// Simulate a new process: the counter starts over
+	userFactory.ResetSequence()
+	failPosts = false
+	r, err := newScenario().Create(context.Background())
+	if err != nil {
+		t.Fatalf("expected resume to succeed, got %v", err)
+	}
+
+	users := Get[[]*User](r, "users")
+	firstUsers := Get[[]*User](first, "users")
+	if len(users) != 3 || users[2].Name != firstUsers[2].Name {
+		t.Fatalf("expected restored users %+v, got %+v", firstUsers, users)
+	}
+	if posts := All[Post](r); len(posts) != 3 || posts[0].AuthorID != "1" {
+		t.Fatalf("expected posts for restored users, got %+v", posts)
+	}
+	if more := First[User](r, "more"); more == nil || more.ID != "4" {
+		t.Fatalf("expected restored sequence to continue at 4, got %+v", more)
+	}
+	if got := len(All[User](r)); got != 4 {
+		t.Fatalf("expected All to include restored users, got %d", got)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected checkpoint to be removed after success, got %v", err)
+	}
+}
+
+func TestScenario_WithCheckpoint_Mismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seed.json")
+	if err := os.WriteFile(path, []byte(`{"scenario":"other"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err := NewScenario("mine").WithCheckpoint(path).Create(context.Background())
+	if err == nil {
+		t.Fatal("expected error for checkpoint of another scenario")
+	}
+}
+
+func TestCreateBatchStep(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batch.json")
+	calls := 0
+	f := New(func(seq int64) User { return User{ID: fmt.Sprint(seq)} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) {
+			calls++
+			if calls == 4 {
+				return nil, errors.New("timeout")
+			}
+			return u, nil
+		})
+
+	s := NewScenario("batched").
+		WithCheckpoint(path).
+		TrackSequence("users", f).
+		Step("users", CreateBatchStep("users", f, 5, 2))
+
+	if _, err := s.Create(context.Background()); err == nil {
+		t.Fatal("expected batch failure")
+	}
+
+	// The first batch was checkpointed; resume creates the remaining three
+	f.ResetSequence()
+	r, err := s.Create(context.Background())
+	if err != nil {
+		t.Fatalf("expected resume to succeed, got %v", err)
+	}
+	users := Get[[]*User](r, "users")
+	if len(users) != 5 {
+		t.Fatalf("expected 5 users, got %d", len(users))
+	}
+	if users[0].ID != "1" || users[4].ID != "5" {
+		t.Fatalf("expected IDs 1..5 resumed after the first batch, got %s..%s", users[0].ID, users[4].ID)
+	}
+}
@@ -0,0 +1,36 @@
+package factory
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCollector_Tap(t *testing.T) {
+	c := Collect[User]()
+	f := New(func(seq int64) User { return User{Name: "User"} }).Tap(c.Add)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f.MakeMany(25)
+		}()
+	}
+	wg.Wait()
+
+	if c.Count() != 200 || len(c.Items()) != 200 {
+		t.Fatalf("expected 200 collected items, got %d", c.Count())
+	}
+
+	items := c.Items()
+	items[0].Name = "changed"
+	if c.Items()[0].Name != "User" {
+		t.Fatal("expected Items to return a copy")
+	}
+
+	c.Reset()
+	if c.Count() != 0 {
+		t.Fatalf("expected Reset to clear items, got %d", c.Count())
+	}
+}
@@ -0,0 +1,35 @@
+package factory
+
+import "context"
+
+// CreateIf creates an item only when cond is true; otherwise returns (nil, nil).
+// Useful for feature-flagged or environment-specific rows in seeders.
+// Example: factory.CreateIf(ctx, cfg.BillingEnabled, withPlan)
+func (f *Factory[T]) CreateIf(ctx context.Context, cond bool, ts ...Trait[T]) (*T, error) {
+	if !cond {
+		return nil, nil
+	}
+	return f.Create(ctx, ts...)
+}
+
+// CreateUnlessExists builds an item and passes it to existsFn, which looks it up
+// (usually by a natural key). If existsFn returns a record, that record is returned
+// and nothing is persisted; otherwise the built item is created as usual.
+// Example: factory.CreateUnlessExists(ctx, func(ctx context.Context, u *User) (*User, error) { return repo.FindByEmail(ctx, u.Email) })
+func (f *Factory[T]) CreateUnlessExists(ctx context.Context, existsFn func(ctx context.Context, t *T) (*T, error), ts ...Trait[T]) (*T, error) {
+	v := f.view()
+	f = &v
+	if !f.canPersist() {
+		panic("factory: CreateUnlessExists called without persist function; use WithPersist")
+	}
+	obj := f.Make(ts...)
+
+	existing, err := existsFn(ctx, &obj)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+	return f.save(ctx, &obj)
+}
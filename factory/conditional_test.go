@@ -0,0 +1,74 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFactory_CreateIf(t *testing.T) {
+	calls := 0
+	f := New(func(seq int64) User {
+		return User{Name: fmt.Sprintf("User %d", seq)}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		calls++
+		return u, nil
+	})
+
+	ctx := context.Background()
+	u, err := f.CreateIf(ctx, false)
+	if err != nil || u != nil {
+		t.Fatalf("expected (nil, nil) when condition is false, got (%v, %v)", u, err)
+	}
+
+	u, err = f.CreateIf(ctx, true, func(u *User) { u.Email = "flagged@example.com" })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u == nil || u.Email != "flagged@example.com" {
+		t.Fatalf("expected created user with trait applied, got %+v", u)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 persist call, got %d", calls)
+	}
+}
+
+func TestFactory_CreateUnlessExists(t *testing.T) {
+	db := map[string]*User{}
+	f := New(func(seq int64) User {
+		return User{Email: "admin@example.com"}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		u.ID = fmt.Sprintf("user-%d", len(db)+1)
+		db[u.Email] = u
+		return u, nil
+	})
+	exists := func(ctx context.Context, u *User) (*User, error) {
+		return db[u.Email], nil
+	}
+
+	ctx := context.Background()
+	first, err := f.CreateUnlessExists(ctx, exists)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := f.CreateUnlessExists(ctx, exists)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first != second {
+		t.Fatal("expected existing record to be returned")
+	}
+	if len(db) != 1 {
+		t.Fatalf("expected 1 persisted user, got %d", len(db))
+	}
+
+	boom := errors.New("lookup failed")
+	_, err = f.CreateUnlessExists(ctx, func(ctx context.Context, u *User) (*User, error) {
+		return nil, boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected lookup error, got %v", err)
+	}
+}
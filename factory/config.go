@@ -0,0 +1,280 @@
+package factory
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Config describes per-factory counts, states, and probabilities, so the same
+// seed binary can be tuned per environment without recompiling.
+//
+// JSON example:
+//
+//	{"factories": {"users": {"count": 50, "states": ["verified"], "probabilities": {"admin": 0.1}}}}
+type Config struct {
+	Factories map[string]FactoryConfig `json:"factories"`
+}
+
+// FactoryConfig tunes a single factory.
+type FactoryConfig struct {
+	Count         int                `json:"count"`         // Items to create (0 keeps the caller's default)
+	States        []string           `json:"states"`        // Named states applied to every item
+	Probabilities map[string]float64 `json:"probabilities"` // Named states applied to each item with a probability
+	Seed          int64              `json:"seed"`          // Seed for probabilities (0 uses the math/rand global source)
+
+	Distribution map[string]int `json:"distribution,omitempty"` // Exact per-state counts (see Distribute; must sum to Count)
+	Sequence     int64          `json:"sequence,omitempty"`     // Last sequence number handed out (FromConfig only)
+	Defined      []string       `json:"defined,omitempty"`      // States the factory defines (exported for tools; checked by FromConfig)
+}
+
+// ConfigureFromFile reads a JSON config file.
+func ConfigureFromFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is provided by the caller
+	if err != nil {
+		return nil, err
+	}
+	var c Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("factory: invalid config %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// ConfigureFromEnv reads a config from environment variables starting with prefix.
+// Factory and state names are lowercased. Recognized variables (prefix "SEED"):
+//
+//	SEED_USERS_COUNT=50
+//	SEED_USERS_STATES=verified,active
+//	SEED_USERS_PROBABILITY_ADMIN=0.1
+//	SEED_USERS_SEED=42
+func ConfigureFromEnv(prefix string) (*Config, error) {
+	c := &Config{Factories: make(map[string]FactoryConfig)}
+	prefix = strings.ToUpper(prefix) + "_"
+
+	for _, kv := range os.Environ() {
+		key, value, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(key, prefix)
+
+		var err error
+		switch {
+		// Checked first, so states named COUNT, STATES, or SEED are not read as settings
+		case strings.Contains(rest, "_PROBABILITY_"):
+			factoryPart, state, _ := strings.Cut(rest, "_PROBABILITY_")
+			name := strings.ToLower(factoryPart)
+			fc := c.Factories[name]
+			if fc.Probabilities == nil {
+				fc.Probabilities = make(map[string]float64)
+			}
+			var p float64
+			p, err = strconv.ParseFloat(value, 64)
+			fc.Probabilities[strings.ToLower(state)] = p
+			c.Factories[name] = fc
+		case strings.HasSuffix(rest, "_COUNT"):
+			name := envName(rest, "_COUNT")
+			fc := c.Factories[name]
+			fc.Count, err = strconv.Atoi(value)
+			c.Factories[name] = fc
+		case strings.HasSuffix(rest, "_STATES"):
+			name := envName(rest, "_STATES")
+			fc := c.Factories[name]
+			fc.States = splitList(value)
+			c.Factories[name] = fc
+		case strings.HasSuffix(rest, "_SEED"):
+			name := envName(rest, "_SEED")
+			fc := c.Factories[name]
+			fc.Seed, err = strconv.ParseInt(value, 10, 64)
+			c.Factories[name] = fc
+		}
+		if err != nil {
+			return nil, fmt.Errorf("factory: invalid %s: %w", key, err)
+		}
+	}
+	return c, nil
+}
+
+func envName(key, suffix string) string {
+	return strings.ToLower(strings.TrimSuffix(key, suffix))
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, strings.ToLower(part))
+		}
+	}
+	return out
+}
+
+// Factory returns the configuration for the named factory (zero value if absent).
+func (c *Config) Factory(name string) FactoryConfig {
+	return c.Factories[name]
+}
+
+// Merge returns a new config where non-zero settings from other override c.
+// Typical use: file defaults, then environment overrides.
+func (c *Config) Merge(other *Config) *Config {
+	merged := &Config{Factories: make(map[string]FactoryConfig)}
+	for name, fc := range c.Factories {
+		merged.Factories[name] = fc
+	}
+	for name, o := range other.Factories {
+		fc := merged.Factories[name]
+		if o.Count != 0 {
+			fc.Count = o.Count
+		}
+		if o.States != nil {
+			fc.States = o.States
+		}
+		if o.Seed != 0 {
+			fc.Seed = o.Seed
+		}
+		if o.Distribution != nil {
+			fc.Distribution = o.Distribution
+		}
+		if o.Sequence != 0 {
+			fc.Sequence = o.Sequence
+		}
+		if o.Defined != nil {
+			fc.Defined = o.Defined
+		}
+		if len(o.Probabilities) > 0 {
+			probs := make(map[string]float64, len(fc.Probabilities)+len(o.Probabilities))
+			for k, v := range fc.Probabilities {
+				probs[k] = v
+			}
+			for k, v := range o.Probabilities {
+				probs[k] = v
+			}
+			fc.Probabilities = probs
+		}
+		merged.Factories[name] = fc
+	}
+	return merged
+}
+
+// ApplyConfig returns a CountedFactory with fc's states, probabilities, and
+// distribution applied. The count defaults to 1 when fc.Count is 0. The original
+// factory is not modified. Returns an error if fc references a state that was not
+// defined or the distribution does not add up to the count.
+// Example: users, err := ApplyConfig(userFactory, cfg.Factory("users"))
+func ApplyConfig[T any](f *Factory[T], fc FactoryConfig) (*CountedFactory[T], error) {
+	out := f
+	for _, name := range fc.States {
+		trait, ok := f.state(name)
+		if !ok {
+			return nil, fmt.Errorf("%w '%s' in config", ErrUnknownState, name)
+		}
+		out = out.withTrait("state:"+name, trait)
+	}
+
+	// Sorted so seeded runs are reproducible
+	names := make([]string, 0, len(fc.Probabilities))
+	for name := range fc.Probabilities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// A seeded group's source is locked, so concurrent Make calls can share it; a nil
+	// group falls back to the math/rand global source
+	var rng *Group
+	if fc.Seed != 0 {
+		rng = NewGroup().WithSeed(fc.Seed)
+	}
+	for _, name := range names {
+		trait, ok := f.state(name)
+		if !ok {
+			return nil, fmt.Errorf("%w '%s' in config", ErrUnknownState, name)
+		}
+		p := fc.Probabilities[name]
+		out = out.withTrait(fmt.Sprintf("state:%s (p=%.2f)", name, p), func(t *T) {
+			if rng.float64() < p {
+				trait(t)
+			}
+		})
+		out.probTraits = grow(out.probTraits, len(out.traits)-1)
+	}
+	if len(names) > 0 {
+		// out is a copy by now, so f is untouched
+		out.probConfig = FactoryConfig{Probabilities: fc.Probabilities, Seed: fc.Seed}
+	}
+
+	count := fc.Count
+	if count == 0 {
+		count = 1
+	}
+	if fc.Distribution == nil {
+		return out.Count(count), nil
+	}
+	total := 0
+	for name, n := range fc.Distribution {
+		if _, ok := f.state(name); name != "" && !ok {
+			return nil, fmt.Errorf("%w '%s' in config distribution", ErrUnknownState, name)
+		}
+		total += n
+	}
+	if total != count {
+		return nil, fmt.Errorf("factory: config distribution sums to %d, want %d", total, count)
+	}
+	return out.Count(count).Distribute(fc.Distribution), nil
+}
+
+// ExportConfig returns the declarative parts of f (applied states, probabilities and
+// seed from ApplyConfig, the sequence position, and the defined states) as a
+// FactoryConfig, for external tools and config files. Closures are not exported.
+// Example: data, _ := json.Marshal(userFactory.State("admin").ExportConfig())
+func (f *Factory[T]) ExportConfig() FactoryConfig {
+	v := f.view()
+	f = &v
+	fc := FactoryConfig{
+		Probabilities: f.probConfig.Probabilities,
+		Seed:          f.probConfig.Seed,
+		Sequence:      f.CurrentSequence(),
+		Defined:       f.States(),
+	}
+	for i, name := range f.traitNames {
+		if state, ok := strings.CutPrefix(name, "state:"); ok && !slices.Contains(f.probTraits, i) {
+			fc.States = append(fc.States, state)
+		}
+	}
+	return fc
+}
+
+// ExportConfig is Factory.ExportConfig plus the batch count and distribution.
+func (cf *CountedFactory[T]) ExportConfig() FactoryConfig {
+	fc := cf.factory.ExportConfig()
+	fc.Count = cf.count
+	if cf.distribution != nil {
+		fc.Distribution = make(map[string]int, len(cf.distribution))
+		for _, sc := range cf.distribution {
+			fc.Distribution[sc.name] = sc.n
+		}
+	}
+	return fc
+}
+
+// FromConfig builds a configured clone of f from fc (typically read from JSON
+// written by ExportConfig): fc's sequence position is restored on the clone's own
+// counter, then ApplyConfig applies the rest. Returns an error if a state in
+// fc.Defined is not defined on f, so tools notice when config and code drift apart.
+// Example: users, err := FromConfig(userFactory, fc)
+func FromConfig[T any](f *Factory[T], fc FactoryConfig) (*CountedFactory[T], error) {
+	for _, name := range fc.Defined {
+		if _, ok := f.state(name); !ok {
+			return nil, fmt.Errorf("%w '%s' in config", ErrUnknownState, name)
+		}
+	}
+	clone := f.Clone()
+	if fc.Sequence > 0 {
+		clone.SwapSequence(fc.Sequence)
+	}
+	return ApplyConfig(clone, fc)
+}
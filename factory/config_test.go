@@ -0,0 +1,248 @@
+package factory
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+type Member struct {
+	Role     string
+	Verified bool
+}
+
+func newMemberFactory() *Factory[Member] {
+	return New(func(seq int64) Member {
+		return Member{Role: "user"}
+	}).DefineState("admin", func(m *Member) {
+		m.Role = "admin"
+	}).DefineState("verified", func(m *Member) {
+		m.Verified = true
+	})
+}
+
+func TestConfigureFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seed.json")
+	data := `{"factories": {"members": {"count": 20, "states": ["verified"], "probabilities": {"admin": 0.5}, "seed": 7}}}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := ConfigureFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counted, err := ApplyConfig(newMemberFactory(), cfg.Factory("members"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	members := counted.Make()
+	if len(members) != 20 {
+		t.Fatalf("expected 20 members, got %d", len(members))
+	}
+
+	admins := 0
+	for _, m := range members {
+		if !m.Verified {
+			t.Fatal("expected every member to be verified")
+		}
+		if m.Role == "admin" {
+			admins++
+		}
+	}
+	if admins == 0 || admins == 20 {
+		t.Fatalf("expected some but not all admins, got %d", admins)
+	}
+
+	// Same seed reproduces the same composition
+	again, _ := ApplyConfig(newMemberFactory(), cfg.Factory("members"))
+	for i, m := range again.Make() {
+		if m != members[i] {
+			t.Fatalf("member %d differs between seeded runs", i)
+		}
+	}
+}
+
+func TestConfigureFromFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seed.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ConfigureFromFile(path); err == nil {
+		t.Fatal("expected error for invalid JSON")
+	}
+	if _, err := ConfigureFromFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatal("expected error for missing file")
+	}
+}
+
+func TestConfigureFromEnv(t *testing.T) {
+	t.Setenv("SEED_MEMBERS_COUNT", "3")
+	t.Setenv("SEED_MEMBERS_STATES", "Admin, verified")
+	t.Setenv("SEED_MEMBERS_PROBABILITY_ADMIN", "0.25")
+	t.Setenv("SEED_MEMBERS_SEED", "9")
+	t.Setenv("SEED_MEMBERS_PROBABILITY_SEED", "0.1") // A state named "seed"
+
+	cfg, err := ConfigureFromEnv("seed")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fc := cfg.Factory("members")
+	if fc.Count != 3 || fc.Seed != 9 {
+		t.Fatalf("unexpected count/seed: %+v", fc)
+	}
+	if len(fc.States) != 2 || fc.States[0] != "admin" || fc.States[1] != "verified" {
+		t.Fatalf("unexpected states: %v", fc.States)
+	}
+	if fc.Probabilities["admin"] != 0.25 || fc.Probabilities["seed"] != 0.1 {
+		t.Fatalf("unexpected probabilities: %v", fc.Probabilities)
+	}
+	if _, ok := cfg.Factories["members_probability"]; ok {
+		t.Fatalf("expected the seed state not to be read as a factory seed: %v", cfg.Factories)
+	}
+
+	t.Setenv("SEED_MEMBERS_COUNT", "lots")
+	if _, err := ConfigureFromEnv("seed"); err == nil {
+		t.Fatal("expected error for invalid count")
+	}
+}
+
+func TestApplyConfig_SeededProbabilityConcurrent(t *testing.T) {
+	cf, err := ApplyConfig(newMemberFactory(), FactoryConfig{Count: 10, Probabilities: map[string]float64{"admin": 0.5}, Seed: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cf.Make()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConfig_Merge(t *testing.T) {
+	base := &Config{Factories: map[string]FactoryConfig{
+		"members": {Count: 10, States: []string{"verified"}, Probabilities: map[string]float64{"admin": 0.1}},
+	}}
+	override := &Config{Factories: map[string]FactoryConfig{
+		"members": {Count: 2},
+		"posts":   {Count: 5},
+	}}
+
+	merged := base.Merge(override)
+	members := merged.Factory("members")
+	if members.Count != 2 {
+		t.Fatalf("expected overridden count 2, got %d", members.Count)
+	}
+	if len(members.States) != 1 || members.Probabilities["admin"] != 0.1 {
+		t.Fatalf("expected unset fields to be kept, got %+v", members)
+	}
+	if merged.Factory("posts").Count != 5 {
+		t.Fatal("expected new factory config to be added")
+	}
+	if base.Factory("members").Count != 10 {
+		t.Fatal("expected base config to be unchanged")
+	}
+}
+
+func TestApplyConfig_UnknownState(t *testing.T) {
+	if _, err := ApplyConfig(newMemberFactory(), FactoryConfig{States: []string{"banned"}}); !errors.Is(err, ErrUnknownState) {
+		t.Fatalf("expected ErrUnknownState, got %v", err)
+	}
+	if _, err := ApplyConfig(newMemberFactory(), FactoryConfig{Probabilities: map[string]float64{"banned": 1}}); err == nil {
+		t.Fatal("expected error for unknown probability state")
+	}
+
+	counted, err := ApplyConfig(newMemberFactory(), FactoryConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(counted.Make()); got != 1 {
+		t.Fatalf("expected default count of 1, got %d", got)
+	}
+}
+
+func TestFactory_ExportConfig_RoundTrip(t *testing.T) {
+	base := newMemberFactory()
+	configured, err := ApplyConfig(base.State("verified"), FactoryConfig{
+		Count:         4,
+		Probabilities: map[string]float64{"admin": 0.25},
+		Seed:          7,
+		Distribution:  map[string]int{"admin": 1, "": 3},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	configured.Factory().Make()
+	configured.Factory().Make()
+
+	data, err := json.Marshal(configured.ExportConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fc FactoryConfig
+	if err := json.Unmarshal(data, &fc); err != nil {
+		t.Fatal(err)
+	}
+	if fc.Count != 4 || fc.Sequence != 2 || fc.Seed != 7 || fc.Probabilities["admin"] != 0.25 ||
+		len(fc.States) != 1 || fc.States[0] != "verified" || fc.Distribution["admin"] != 1 ||
+		len(fc.Defined) != 2 {
+		t.Fatalf("unexpected exported config %s", data)
+	}
+	if base.ExportConfig().Probabilities != nil {
+		t.Fatal("expected ApplyConfig to leave the original factory untouched")
+	}
+
+	clone, err := FromConfig(base, fc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	members := clone.Make()
+	admins := 0
+	for _, m := range members {
+		if !m.Verified {
+			t.Fatalf("expected verified state on every member, got %+v", m)
+		}
+		if m.Role == "admin" {
+			admins++
+		}
+	}
+	if len(members) != 4 || admins < 1 {
+		t.Fatalf("expected 4 members with at least the distributed admin, got %+v", members)
+	}
+	if got := clone.Factory().CurrentSequence(); got != 6 {
+		t.Fatalf("expected the clone to continue from sequence 2, got %d", got)
+	}
+	if base.CurrentSequence() != 0 {
+		t.Fatalf("expected the base sequence to be untouched, got %d", base.CurrentSequence())
+	}
+}
+
+func TestFactory_ExportConfig_StateNamedLikeProbability(t *testing.T) {
+	f := newMemberFactory().DefineState("odd (p=0.50)", func(m *Member) { m.Role = "odd" })
+	configured, err := ApplyConfig(f.State("odd (p=0.50)"), FactoryConfig{Probabilities: map[string]float64{"admin": 0.5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := configured.ExportConfig().States; len(got) != 1 || got[0] != "odd (p=0.50)" {
+		t.Fatalf("expected only the applied state to be exported, got %q", got)
+	}
+}
+
+func TestFromConfig_Errors(t *testing.T) {
+	if _, err := FromConfig(newMemberFactory(), FactoryConfig{Defined: []string{"admin", "banned"}}); !errors.Is(err, ErrUnknownState) {
+		t.Fatalf("expected ErrUnknownState for drifted defined states, got %v", err)
+	}
+	if _, err := FromConfig(newMemberFactory(), FactoryConfig{Count: 2, Distribution: map[string]int{"admin": 1}}); err == nil {
+		t.Fatal("expected error for a distribution that does not sum to the count")
+	}
+}
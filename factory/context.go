@@ -0,0 +1,37 @@
+package factory
+
+import "context"
+
+// WithContext sets a base context whose values are visible to Create calls, hooks, and
+// persist functions. Useful when helpers call MustCreate(context.Background()) but the
+// factory is bound to a test's context holding a transaction or tenant.
+// Only values are merged: deadlines and cancellation still come from the call's context,
+// and values on the call's context take precedence.
+// Example: users := userFactory.Clone().WithContext(txCtx)
+func (f *Factory[T]) WithContext(ctx context.Context) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.baseCtx = ctx
+	return f
+}
+
+// withBase merges f.baseCtx's values into ctx.
+func (f *Factory[T]) withBase(ctx context.Context) context.Context {
+	if f.baseCtx == nil {
+		return ctx
+	}
+	return mergedContext{Context: ctx, base: f.baseCtx}
+}
+
+// mergedContext is ctx with Value falling back to base.
+type mergedContext struct {
+	context.Context
+	base context.Context
+}
+
+func (c mergedContext) Value(key any) any {
+	if v := c.Context.Value(key); v != nil {
+		return v
+	}
+	return c.base.Value(key)
+}
@@ -0,0 +1,67 @@
+package factory
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type ctxKey string
+
+func TestFactory_WithContext(t *testing.T) {
+	var seen []any
+	record := func(ctx context.Context) {
+		seen = append(seen, ctx.Value(ctxKey("tx")), ctx.Value(ctxKey("tenant")))
+	}
+
+	base := context.WithValue(context.Background(), ctxKey("tx"), "tx-1")
+	base = context.WithValue(base, ctxKey("tenant"), "base-tenant")
+
+	f := New(func(seq int64) User {
+		return User{}
+	}).BeforeCreate(func(ctx context.Context, u *User) error {
+		record(ctx)
+		return nil
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		record(ctx)
+		return u, nil
+	}).AfterCreateBatch(func(ctx context.Context, items []*User) error {
+		record(ctx)
+		return nil
+	}).WithContext(base)
+
+	f.MustCreate(context.Background())
+	if seen[0] != "tx-1" || seen[2] != "tx-1" {
+		t.Fatalf("expected base values in hooks and persist, got %v", seen)
+	}
+
+	// Call values take precedence
+	seen = nil
+	f.MustCreateMany(context.WithValue(context.Background(), ctxKey("tenant"), "call-tenant"), 1)
+	if seen[1] != "call-tenant" || seen[4] != "tx-1" || seen[5] != "call-tenant" {
+		t.Fatalf("expected call values to win and batch hooks to see base, got %v", seen)
+	}
+}
+
+func TestFactory_WithContextKeepsCallCancellation(t *testing.T) {
+	base, cancelBase := context.WithCancel(context.Background())
+	cancelBase()
+
+	f := New(func(seq int64) User {
+		return User{}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if _, ok := ctx.Deadline(); !ok {
+			t.Fatal("expected call deadline")
+		}
+		return u, nil
+	}).WithContext(base)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if _, err := f.Create(ctx); err != nil {
+		t.Fatalf("expected base cancellation to be ignored, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package factory
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// FieldCoverage lists the sources that set one field of T.
+type FieldCoverage struct {
+	Field string
+	SetBy []string // e.g., "make", "defaults", "verified", "state:admin" (empty when always zero)
+}
+
+// CoverageReport is the per-field result of Coverage, in struct field order.
+type CoverageReport []FieldCoverage
+
+// Zero returns the fields that no source ever set, so they always stay zero.
+func (r CoverageReport) Zero() []string {
+	var out []string
+	for _, fc := range r {
+		if len(fc.SetBy) == 0 {
+			out = append(out, fc.Field)
+		}
+	}
+	return out
+}
+
+// String formats the report one field per line, e.g. "Name: make, state:admin".
+func (r CoverageReport) String() string {
+	lines := make([]string, len(r))
+	for i, fc := range r {
+		if len(fc.SetBy) == 0 {
+			lines[i] = fc.Field + ": never set (always zero)"
+			continue
+		}
+		lines[i] = fc.Field + ": " + strings.Join(fc.SetBy, ", ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Coverage reports which exported top-level fields of T are set by the make function,
+// defaults, global traits, sequence traits, and each defined state, by building
+// samples items (seq 1..samples) and diffing the value after every stage. Fields
+// that nothing sets are listed by Zero, to catch gaps in a factory. A source counts
+// only when it changes a field. Per-call traits and Tap are not run, and the
+// factory's sequence is not advanced. Panics if T is not a struct or samples < 1.
+// Example: t.Log(userFactory.Coverage(20))
+func (f *Factory[T]) Coverage(samples int) CoverageReport {
+	v := f.view()
+	f = &v
+	var zero T
+	rt := reflect.TypeOf(zero)
+	if rt == nil || rt.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("factory: Coverage requires a struct type, got %T", zero))
+	}
+	if samples < 1 {
+		panic("factory: Coverage requires samples >= 1")
+	}
+
+	setBy := make(map[string][]string)
+	step := func(source string, t *T, fn func(*T)) {
+		before := *t
+		fn(t)
+		for _, field := range changedFields(&before, t) {
+			if !slices.Contains(setBy[field], source) {
+				setBy[field] = append(setBy[field], source)
+			}
+		}
+	}
+	states := f.States()
+	for seq := int64(1); seq <= int64(samples); seq++ {
+		var t T
+		step("make", &t, func(t *T) { *t = f.makeFn(seq) })
+		for _, d := range f.defaults {
+			step("defaults", &t, func(t *T) { d(seq, t) })
+		}
+		for i, tr := range f.traits {
+			name := f.traitName(i)
+			if name == "" {
+				name = fmt.Sprintf("traits[%d]", i)
+			}
+			step(name, &t, tr)
+		}
+		if len(f.sequences) > 0 {
+			idx := int((seq - 1) % int64(len(f.sequences)))
+			step(fmt.Sprintf("sequence[%d]", idx), &t, f.sequences[idx])
+		}
+		step("run-prefix", &t, f.applyRunPrefix)
+		step("deterministic-id", &t, f.applyDeterministicID)
+		for _, name := range states {
+			s, trait := t, f.Apply(name)
+			step("state:"+name, &s, trait)
+		}
+	}
+
+	report := make(CoverageReport, 0, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		if sf := rt.Field(i); sf.IsExported() {
+			report = append(report, FieldCoverage{Field: sf.Name, SetBy: setBy[sf.Name]})
+		}
+	}
+	return report
+}
@@ -0,0 +1,52 @@
+package factory
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type Profile struct {
+	ID    string
+	Name  string
+	Email string
+	Admin bool
+	Plan  string
+	Bio   string
+}
+
+func TestFactory_Coverage(t *testing.T) {
+	f := New(func(seq int64) Profile { return Profile{Name: fmt.Sprintf("Profile %d", seq)} }).
+		WithDefaults(func(a *Profile) { a.Email = "a@example.com" }).
+		WithNamedTrait("free", func(a *Profile) { a.Plan = "free" }).
+		Sequence(func(a *Profile) {}, func(a *Profile) { a.Plan = "pro" }).
+		DefineState("admin", func(a *Profile) { a.Admin = true })
+
+	report := f.Coverage(2)
+	want := strings.Join([]string{
+		"ID: never set (always zero)",
+		"Name: make",
+		"Email: defaults",
+		"Admin: state:admin",
+		"Plan: free, sequence[1]",
+		"Bio: never set (always zero)",
+	}, "\n")
+	if got := report.String(); got != want {
+		t.Fatalf("unexpected report:\n%s\nwant:\n%s", got, want)
+	}
+	if zero := strings.Join(report.Zero(), ","); zero != "ID,Bio" {
+		t.Fatalf("expected ID and Bio to be zero, got %s", zero)
+	}
+	if f.CurrentSequence() != 0 {
+		t.Fatalf("expected the sequence to be untouched, got %d", f.CurrentSequence())
+	}
+}
+
+func TestFactory_CoveragePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for non-struct type")
+		}
+	}()
+	New(func(seq int64) int { return 0 }).Coverage(1)
+}
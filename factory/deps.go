@@ -0,0 +1,57 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+)
+
+// DepStep is a Scenario step that reads the output of earlier steps through SeedDeps
+// instead of package-level variables.
+type DepStep func(ctx context.Context, deps SeedDeps) error
+
+// SeedDeps gives a DepStep typed access to the results of the steps it depends on
+// (see Scenario.StepAfter).
+type SeedDeps struct {
+	step  string
+	needs map[string]bool
+	r     *Results
+}
+
+// StepAfter appends a named step that fails unless earlier steps stored every key in
+// needs. The step reads them with Dep and stores its output with SeedDeps.Set.
+func (s *Scenario) StepAfter(name string, needs []string, fn DepStep) *Scenario {
+	set := make(map[string]bool, len(needs))
+	for _, key := range needs {
+		set[key] = true
+	}
+	return s.Step(name, func(ctx context.Context, r *Results) error {
+		for _, key := range needs {
+			if _, ok := r.values[key]; !ok {
+				return fmt.Errorf("factory: needs '%s', which no earlier step stored", key)
+			}
+		}
+		return fn(ctx, SeedDeps{step: name, needs: set, r: r})
+	})
+}
+
+// Dep returns the records stored under key (as []*T or *T) by an earlier step.
+// Panics if key is not one of the step's declared needs or holds another type
+// (programming errors).
+// Example: users := Dep[User](deps, "users")
+func Dep[T any](deps SeedDeps, key string) []*T {
+	if !deps.needs[key] {
+		panic(fmt.Sprintf("factory: step '%s' reads '%s' without declaring it in StepAfter", deps.step, key))
+	}
+	switch v := records[T](deps.r, key).(type) {
+	case []*T:
+		return v
+	case *T:
+		return []*T{v}
+	}
+	panic(fmt.Sprintf("factory: result '%s' does not hold %s records", key, typeName[T]()))
+}
+
+// Set stores v under the step's name, for later steps to depend on.
+func (d SeedDeps) Set(v any) {
+	d.r.Set(d.step, v)
+}
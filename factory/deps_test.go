@@ -0,0 +1,52 @@
+package factory
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestScenario_StepAfter(t *testing.T) {
+	users := New(func(seq int64) User { return User{ID: "u1"} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) { return u, nil })
+	posts := New(func(seq int64) Post { return Post{} }).
+		WithPersist(func(ctx context.Context, p *Post) (*Post, error) { return p, nil })
+
+	postsStep := func(ctx context.Context, deps SeedDeps) error {
+		author := Dep[User](deps, "users")[0]
+		created, err := RecycleCount(posts.Count(2), author, func(p *Post, u *User) { p.AuthorID = u.ID }).Create(ctx)
+		deps.Set(created)
+		return err
+	}
+
+	r := NewScenario("blog").
+		Step("users", CreateStep("users", users, 1)).
+		StepAfter("posts", []string{"users"}, postsStep).
+		MustCreate(context.Background())
+	if got := Get[[]*Post](r, "posts"); len(got) != 2 || got[0].AuthorID != "u1" {
+		t.Fatalf("expected two posts by u1, got %+v", got)
+	}
+
+	_, err := NewScenario("reordered").
+		StepAfter("posts", []string{"users"}, postsStep).
+		Step("users", CreateStep("users", users, 1)).
+		Create(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "needs 'users'") {
+		t.Fatalf("expected a missing dependency error, got %v", err)
+	}
+}
+
+func TestDep_UndeclaredPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "without declaring") {
+			t.Fatalf("expected undeclared dependency panic, got %v", r)
+		}
+	}()
+	_, _ = NewScenario("undeclared").
+		Step("users", FixtureStep("users", User{ID: "u1"})).
+		StepAfter("posts", nil, func(ctx context.Context, deps SeedDeps) error {
+			Dep[User](deps, "users")
+			return nil
+		}).
+		Create(context.Background())
+}
@@ -0,0 +1,99 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// stateCount is one group of a distributed batch.
+type stateCount struct {
+	name string // "" means no state
+	n    int
+}
+
+// Distribute splits the batch across named states with exact counts, instead of
+// the approximation a Sequence gives. Use "" for items built without a state.
+// Groups are built in state-name order and share the factory's sequence counter.
+// Panics if a state is undefined or the counts do not add up to the batch size.
+// Example: factory.Count(100).Distribute(map[string]int{"admin": 5, "moderator": 10, "": 85}).Create(ctx)
+func (cf *CountedFactory[T]) Distribute(counts map[string]int) *CountedFactory[T] {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dist := make([]stateCount, 0, len(names))
+	total := 0
+	for _, name := range names {
+		if _, ok := cf.factory.state(name); name != "" && !ok {
+			panic(cf.factory.unknownState(name).Error())
+		}
+		n := counts[name]
+		if n < 0 {
+			panic(fmt.Sprintf("factory: Distribute count for '%s' is negative", name))
+		}
+		total += n
+		dist = append(dist, stateCount{name: name, n: n})
+	}
+	if total != cf.count {
+		panic(fmt.Sprintf("factory: Distribute counts sum to %d, want %d", total, cf.count))
+	}
+
+	return &CountedFactory[T]{
+		factory:      cf.factory,
+		count:        cf.count,
+		distribution: dist,
+		start:        cf.start,
+		percents:     cf.percents,
+	}
+}
+
+// group returns the factory for one distribution group, built from the snapshot base
+// (see view) and sharing its sequence.
+func (cf *CountedFactory[T]) group(base *Factory[T], sc stateCount) *Factory[T] {
+	if sc.name == "" {
+		return base
+	}
+	f := base.State(sc.name)
+	f.sharedSeq = base.counter()
+	return f
+}
+
+func (cf *CountedFactory[T]) distributedMake(ts ...Trait[T]) []T {
+	v := cf.factory.view()
+	items := make([]T, 0, cf.count)
+	for _, sc := range cf.distribution {
+		items = append(items, cf.group(&v, sc).MakeMany(sc.n, ts...)...)
+	}
+	return items
+}
+
+func (cf *CountedFactory[T]) distributedRaw(ts ...Trait[T]) []T {
+	v := cf.factory.view()
+	items := make([]T, 0, cf.count)
+	for _, sc := range cf.distribution {
+		items = append(items, cf.group(&v, sc).RawMany(sc.n, ts...)...)
+	}
+	return items
+}
+
+func (cf *CountedFactory[T]) distributedCreate(ctx context.Context, ts ...Trait[T]) ([]*T, error) {
+	v := cf.factory.view()
+	f := &v
+	items := make([]*T, 0, cf.count)
+	var hookErrs []error
+	for _, sc := range cf.distribution {
+		created, err := cf.group(f, sc).createEach(ctx, sc.n, ts...)
+		items = append(items, created...)
+		var hookErr *HookError
+		if err != nil && !errors.As(err, &hookErr) {
+			return items, err
+		}
+		hookErrs = append(hookErrs, err)
+	}
+	// Batch hooks see the whole distributed batch at once
+	return f.finishBatch(ctx, items, errors.Join(hookErrs...))
+}
@@ -0,0 +1,118 @@
+package factory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func newRoleUserFactory() *Factory[User] {
+	return New(func(seq int64) User {
+		return User{ID: fmt.Sprintf("user-%d", seq), Name: "user"}
+	}).DefineState("admin", func(u *User) {
+		u.Name = "admin"
+	}).DefineState("moderator", func(u *User) {
+		u.Name = "moderator"
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		return u, nil
+	})
+}
+
+func TestCountedFactory_Distribute(t *testing.T) {
+	f := newRoleUserFactory()
+	users := f.Count(100).Distribute(map[string]int{
+		"admin":     5,
+		"moderator": 10,
+		"":          85,
+	}).Make()
+
+	if len(users) != 100 {
+		t.Fatalf("expected 100 users, got %d", len(users))
+	}
+
+	counts := map[string]int{}
+	ids := map[string]bool{}
+	for _, u := range users {
+		counts[u.Name]++
+		ids[u.ID] = true
+	}
+	if counts["admin"] != 5 || counts["moderator"] != 10 || counts["user"] != 85 {
+		t.Fatalf("unexpected distribution: %v", counts)
+	}
+	if len(ids) != 100 {
+		t.Fatalf("expected groups to share the sequence, got %d unique IDs", len(ids))
+	}
+}
+
+func TestCountedFactory_DistributeCreateAndRaw(t *testing.T) {
+	f := newRoleUserFactory()
+	counted := f.Count(3).Distribute(map[string]int{"admin": 1, "moderator": 2})
+
+	created := counted.MustCreate(context.Background())
+	if len(created) != 3 || created[0].Name != "admin" || created[2].Name != "moderator" {
+		t.Fatalf("unexpected created users: %v", created)
+	}
+
+	var raw []User
+	if err := json.Unmarshal(counted.MustRawJSON(), &raw); err != nil {
+		t.Fatal(err)
+	}
+	if len(raw) != 3 || raw[0].Name != "admin" {
+		t.Fatalf("unexpected raw users: %v", raw)
+	}
+
+	// State on a distributed batch keeps the distribution
+	verified := f.DefineState("verified", func(u *User) { u.Email = "verified" })
+	users := verified.Count(3).Distribute(map[string]int{"admin": 1, "": 2}).State("verified").Make()
+	if len(users) != 3 || users[2].Name != "admin" {
+		t.Fatalf("expected distribution to be preserved, got %v", users)
+	}
+	for _, u := range users {
+		if u.Email != "verified" {
+			t.Fatalf("expected verified state on every user, got %+v", u)
+		}
+	}
+}
+
+func TestCountedFactory_DistributePanics(t *testing.T) {
+	tests := map[string]map[string]int{
+		"wrong total":   {"admin": 1},
+		"unknown state": {"banned": 2},
+		"negative":      {"admin": -1, "": 3},
+	}
+	for name, counts := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Fatal("expected panic")
+				}
+			}()
+			newRoleUserFactory().Count(2).Distribute(counts)
+		})
+	}
+}
+
+func TestCountedFactory_DistributeConcurrentBuilders(t *testing.T) {
+	f := newRoleUserFactory()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			if _, err := f.Count(3).Distribute(map[string]int{"admin": 1, "": 2}).Create(ctx); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			f.AfterCreateBatch(func(ctx context.Context, users []*User) error { return nil })
+		}
+	}()
+	wg.Wait()
+}
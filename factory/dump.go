@@ -0,0 +1,93 @@
+package factory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// builtRecorder remembers the last item a Must* call tried to save, so its panic
+// can show what was built.
+type builtRecorder struct {
+	mu   sync.Mutex
+	item any
+	mask func(any) any // The building factory's MarkPII masking, if any
+}
+
+type builtKey struct{}
+
+func withBuiltRecorder(ctx context.Context) (context.Context, *builtRecorder) {
+	rec := &builtRecorder{}
+	return context.WithValue(ctx, builtKey{}, rec), rec
+}
+
+// recordBuilt stores obj and f's masking when ctx comes from a Must* call.
+func (f *Factory[T]) recordBuilt(ctx context.Context, obj *T) {
+	rec, ok := ctx.Value(builtKey{}).(*builtRecorder)
+	if !ok {
+		return
+	}
+	var mask func(any) any
+	if len(f.pii) > 0 {
+		mask = func(item any) any { return f.Mask(item.(T)) }
+	}
+	rec.mu.Lock()
+	rec.item = *obj
+	rec.mask = mask
+	rec.mu.Unlock()
+}
+
+// failure formats a Must* panic: the error, then the applied traits and the last
+// built item (the offending one, possibly from a nested factory) as indented JSON,
+// with MarkPII fields masked, so CI failures are debuggable without rerunning locally.
+func (f *Factory[T]) failure(method string, err error, rec *builtRecorder, perCall int) string {
+	v := f.view()
+	f = &v
+	var b strings.Builder
+	fmt.Fprintf(&b, "factory: %s failed: %v", method, err)
+	fmt.Fprintf(&b, "\n  traits: %s", f.describeTraits(perCall))
+
+	rec.mu.Lock()
+	item, mask := rec.item, rec.mask
+	rec.mu.Unlock()
+	if item == nil {
+		return b.String()
+	}
+	// The last item may come from a nested factory (e.g., a Has child), so it is
+	// masked by the factory that built it
+	if mask != nil {
+		item = mask(item)
+	}
+	data, jsonErr := json.MarshalIndent(item, "  ", "  ")
+	if jsonErr != nil {
+		fmt.Fprintf(&b, "\n  built %T: %+v", item, item)
+		return b.String()
+	}
+	fmt.Fprintf(&b, "\n  built %T: %s", item, data)
+	return b.String()
+}
+
+// describeTraits lists named global traits and states, then counts anonymous and per-call ones.
+func (f *Factory[T]) describeTraits(perCall int) string {
+	var parts []string
+	anonymous := 0
+	for i := range f.traits {
+		if name := f.traitName(i); name != "" {
+			parts = append(parts, name)
+		} else {
+			anonymous++
+		}
+	}
+	if anonymous > 0 {
+		parts = append(parts, fmt.Sprintf("%d anonymous", anonymous))
+	}
+	if perCall > 0 {
+		parts = append(parts, fmt.Sprintf("%d per-call", perCall))
+	}
+	if len(parts) == 0 {
+		return "(none)"
+	}
+	return strings.Join(parts, ", ")
+}
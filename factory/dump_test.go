@@ -0,0 +1,92 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func mustPanicMessage(t *testing.T, fn func()) string {
+	t.Helper()
+	var msg string
+	func() {
+		defer func() {
+			r := recover()
+			if r == nil {
+				t.Fatal("expected panic")
+			}
+			msg, _ = r.(string)
+		}()
+		fn()
+	}()
+	return msg
+}
+
+func TestFactory_MustCreate_FailureDump(t *testing.T) {
+	f := New(func(seq int64) User {
+		return User{ID: "u1", Name: "Ada", Email: "ada@example.com"}
+	}).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) {
+			return nil, errors.New("duplicate key")
+		}).
+		DefineState("admin", func(u *User) { u.Name = "Admin" }).
+		WithNamedTrait("verified-email", func(u *User) {}).
+		MarkPII("Email")
+
+	msg := mustPanicMessage(t, func() {
+		f.State("admin").MustCreate(context.Background(), func(u *User) {})
+	})
+	for _, want := range []string{
+		"factory: MustCreate failed: duplicate key",
+		"traits: verified-email, state:admin, 1 per-call",
+		"built factory.User: {",
+		`"Name": "Admin"`,
+		`"Email": "masked:`,
+	} {
+		if !strings.Contains(msg, want) {
+			t.Fatalf("expected panic to contain %q, got:\n%s", want, msg)
+		}
+	}
+	if strings.Contains(msg, "ada@example.com") {
+		t.Fatalf("expected PII to be redacted, got:\n%s", msg)
+	}
+
+	msg = mustPanicMessage(t, func() { f.MustCreateMany(context.Background(), 2) })
+	if !strings.HasPrefix(msg, "factory: MustCreateMany failed: duplicate key\n  traits: verified-email\n  built factory.User") {
+		t.Fatalf("unexpected MustCreateMany panic:\n%s", msg)
+	}
+}
+
+func TestFactory_MustCreate_FailureDump_NestedChild(t *testing.T) {
+	users := New(func(seq int64) User { return User{ID: "u1"} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) { return u, nil })
+	posts := New(func(seq int64) Post { return Post{Title: "Broken"} }).
+		WithPersist(func(ctx context.Context, p *Post) (*Post, error) { return nil, errors.New("fk violation") })
+	parents := users.AfterCreate(func(ctx context.Context, u *User) error {
+		_, err := posts.Create(ctx, func(p *Post) { p.AuthorID = u.ID })
+		return err
+	})
+
+	msg := mustPanicMessage(t, func() { parents.MustCreate(context.Background()) })
+	if !strings.Contains(msg, "built factory.Post: {") || !strings.Contains(msg, `"AuthorID": "u1"`) {
+		t.Fatalf("expected the failing child to be dumped, got:\n%s", msg)
+	}
+}
+
+func TestFactory_MustCreate_FailureDump_MasksNestedChild(t *testing.T) {
+	users := New(func(seq int64) User { return User{ID: "u1"} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) { return u, nil })
+	profiles := New(func(seq int64) Profile { return Profile{Email: "ada@example.com"} }).
+		WithPersist(func(ctx context.Context, p *Profile) (*Profile, error) { return nil, errors.New("fk violation") }).
+		MarkPII("Email")
+	parents := users.AfterCreate(func(ctx context.Context, u *User) error {
+		_, err := profiles.Create(ctx)
+		return err
+	})
+
+	msg := mustPanicMessage(t, func() { parents.MustCreate(context.Background()) })
+	if !strings.Contains(msg, "built factory.Profile") || strings.Contains(msg, "ada@example.com") {
+		t.Fatalf("expected the child's PII fields to be masked, got:\n%s", msg)
+	}
+}
@@ -0,0 +1,67 @@
+package factory
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinel errors returned by the Try* methods. Use errors.Is to check them.
+var (
+	// ErrUnknownState means a named state was used without being defined via DefineState.
+	ErrUnknownState = errors.New("factory: unknown state")
+	// ErrNoPersist means a creation method was called without WithPersist or WithShardRouter.
+	ErrNoPersist = errors.New("factory: no persist function; use WithPersist")
+	// ErrEnvironmentNotAllowed means Create ran outside the environments given to WithEnvironmentGuard.
+	ErrEnvironmentNotAllowed = errors.New("factory: environment not allowed")
+)
+
+// unknownState returns an ErrUnknownState error that lists the defined states.
+func (f *Factory[T]) unknownState(name string) error {
+	defined := f.States()
+	if len(defined) == 0 {
+		return fmt.Errorf("%w '%s' (no states defined)", ErrUnknownState, name)
+	}
+	return fmt.Errorf("%w '%s' (defined: %s)", ErrUnknownState, name, strings.Join(defined, ", "))
+}
+
+// HookErrorPolicy controls what Create returns when an AfterCreate or AfterCreateDiff hook fails.
+type HookErrorPolicy int
+
+const (
+	// HookErrorsStop returns nil and the first hook error (the default). The record is
+	// already persisted, so the caller loses its reference.
+	HookErrorsStop HookErrorPolicy = iota
+	// HookErrorsReturnRecord runs every after hook and returns the persisted record
+	// together with a *HookError, so callers can clean up or continue.
+	HookErrorsReturnRecord
+)
+
+// HookError reports after-hook failures for a record that was persisted.
+// Err joins every hook error; use errors.Is/As to inspect them.
+type HookError struct {
+	Err error
+}
+
+func (e *HookError) Error() string {
+	return "factory: after-create hook failed: " + e.Err.Error()
+}
+
+func (e *HookError) Unwrap() error {
+	return e.Err
+}
+
+// PersistStepError reports which step of an AddPersist pipeline failed.
+type PersistStepError struct {
+	Step int    // 1-based position in the pipeline
+	Name string // Step name
+	Err  error
+}
+
+func (e *PersistStepError) Error() string {
+	return fmt.Sprintf("factory: persist step %d (%s): %v", e.Step, e.Name, e.Err)
+}
+
+func (e *PersistStepError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,30 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+)
+
+// StepAfter steps read earlier results through Dep; reordering them fails loudly
+// instead of seeding orphans.
+func ExampleScenario_StepAfter() {
+	userFactory := New(func(seq int64) User { return User{ID: fmt.Sprintf("u%d", seq)} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) { return u, nil })
+	postFactory := New(func(seq int64) Post { return Post{} }).
+		WithPersist(func(ctx context.Context, p *Post) (*Post, error) { return p, nil })
+	linkFn := func(p *Post, u *User) { p.AuthorID = u.ID }
+
+	r := NewScenario("blog").
+		Step("users", CreateStep("users", userFactory, 10)).
+		StepAfter("posts", []string{"users"}, func(ctx context.Context, deps SeedDeps) error {
+			author := Dep[User](deps, "users")[0]
+			posts, err := RecycleCount(postFactory.Count(20), author, linkFn).Create(ctx)
+			deps.Set(posts)
+			return err
+		}).
+		MustCreate(context.Background())
+
+	posts := Get[[]*Post](r, "posts")
+	fmt.Println(len(posts), posts[0].AuthorID)
+	// Output: 20 u1
+}
@@ -0,0 +1,51 @@
+package factory
+
+import (
+	"fmt"
+	"time"
+)
+
+// ExampleSeed seeds the private group copy Examples builds with.
+const ExampleSeed int64 = 1
+
+// ExampleTime is the fixed time the private group copy Examples builds with reports.
+var ExampleTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Example is a canonical instance for API docs and OpenAPI examples.
+type Example[T any] struct {
+	Name  string `json:"name"`            // "example-1", ... or the state name
+	State string `json:"state,omitempty"` // State applied ("" for plain builds)
+	Value T      `json:"value"`
+}
+
+// Examples returns n plain examples followed by one example per state (see States),
+// built with Make on a Clone so the factory's sequence is untouched. Plain examples and
+// each state example start from sequence 1 on a private copy of the group seeded with
+// ExampleSeed and clocked at ExampleTime; the shared group is not changed, so make
+// functions and traits that capture it (including Jitter) are not pinned. Tap, run
+// prefixes, and provenance stamps are skipped.
+// Example: data, _ := json.MarshalIndent(userFactory.Examples(2), "", "  ")
+func (f *Factory[T]) Examples(n int) []Example[T] {
+	c := f.Clone()
+	c.tapFn, c.runPrefix, c.provenance = nil, "", nil
+	shared := c.group
+	pin := func(f *Factory[T]) *Factory[T] {
+		if shared != nil {
+			f.group = shared.fork(ExampleSeed, ExampleTime)
+		}
+		return f
+	}
+	states := c.States()
+
+	out := make([]Example[T], 0, n+len(states))
+	pin(c)
+	for i := 1; i <= n; i++ {
+		out = append(out, Example[T]{Name: fmt.Sprintf("example-%d", i), Value: c.Make()})
+	}
+	for _, name := range states {
+		state := pin(c.State(name))
+		state.ResetSequence()
+		out = append(out, Example[T]{Name: name, State: name, Value: state.Make()})
+	}
+	return out
+}
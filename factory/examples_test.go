@@ -0,0 +1,70 @@
+package factory
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type Offer struct {
+	ID      string
+	Title   string
+	Price   int
+	Status  string
+	Created time.Time
+}
+
+func newOfferFactory() *Factory[Offer] {
+	g := NewGroup().WithSeed(7)
+	return NewIn(g, func(seq int64) Offer {
+		return Offer{
+			ID:      fmt.Sprintf("offer-%d", seq),
+			Title:   "Offer",
+			Price:   100 + int(seq)*10,
+			Status:  "draft",
+			Created: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+	}).
+		DefineState("published", func(l *Offer) { l.Status = "published" })
+}
+
+func TestFactory_Examples(t *testing.T) {
+	f := newOfferFactory()
+	f.MakeMany(3) // Earlier builds must not shift the examples
+
+	examples := f.Examples(2)
+	if len(examples) != 3 {
+		t.Fatalf("expected 2 plain examples and 1 state example, got %d", len(examples))
+	}
+	first, published := examples[0], examples[2]
+	if first.Name != "example-1" || first.Value.ID != "offer-1" || examples[1].Value.ID != "offer-2" {
+		t.Fatalf("expected sequences from 1, got %+v", examples[:2])
+	}
+	if published.Name != "published" || published.State != "published" || published.Value.Status != "published" || published.Value.ID != "offer-1" {
+		t.Fatalf("unexpected state example %+v", published)
+	}
+
+	again := newOfferFactory().Examples(2)
+	if !reflect.DeepEqual(examples, again) {
+		t.Fatalf("expected identical examples across factories, got %+v and %+v", examples, again)
+	}
+	if f.CurrentSequence() != 3 {
+		t.Fatalf("expected the factory's sequence to be untouched, got %d", f.CurrentSequence())
+	}
+}
+
+func TestFactory_ExamplesLeaveSharedGroup(t *testing.T) {
+	f := newOfferFactory()
+	want := NewGroup().WithSeed(7)
+	want.Intn(1000)
+
+	f.Group().Intn(1000)
+	f.Examples(2)
+	if got, next := f.Group().Intn(1000), want.Intn(1000); got != next {
+		t.Fatalf("expected the shared random sequence to continue, got %d, want %d", got, next)
+	}
+	if f.Group().Seed() != 7 || f.Group().Now().Equal(ExampleTime) {
+		t.Fatal("expected the group's seed and clock to be untouched")
+	}
+}
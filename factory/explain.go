@@ -0,0 +1,72 @@
+package factory
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExplainStep is one trait application in the order Make would run it.
+type ExplainStep struct {
+	Stage string // "make", "defaults", "traits", "sequence", "call", or "tap"
+	Index int    // Position within the stage (sequence: the index that will fire)
+	Name  string // Human-readable name, or "" for anonymous traits
+}
+
+// String formats the step as "stage[index] name".
+func (s ExplainStep) String() string {
+	label := fmt.Sprintf("%s[%d]", s.Stage, s.Index)
+	if s.Name == "" {
+		return label
+	}
+	return label + " " + s.Name
+}
+
+// Explanation is the ordered list of steps for one Make call.
+type Explanation []ExplainStep
+
+// String formats the explanation one step per line, for logging.
+func (e Explanation) String() string {
+	lines := make([]string, len(e))
+	for i, s := range e {
+		lines[i] = fmt.Sprintf("%d. %s", i+1, s)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Explain returns the ordered stages and traits the next Make(ts...) call would apply,
+// including the sequence number and which Sequence trait will fire, to debug
+// "who overwrote this field" issues. It does not build anything or advance the sequence.
+// States applied with State() are named "state:<name>".
+// Example: t.Log(factory.State("admin").Explain())
+func (f *Factory[T]) Explain(ts ...Trait[T]) Explanation {
+	v := f.view()
+	f = &v
+	seq := f.CurrentSequence() + 1
+	steps := Explanation{{Stage: "make", Index: 0, Name: fmt.Sprintf("seq=%d", seq)}}
+
+	for i := range f.defaults {
+		steps = append(steps, ExplainStep{Stage: "defaults", Index: i})
+	}
+	for i := range f.traits {
+		steps = append(steps, ExplainStep{Stage: "traits", Index: i, Name: f.traitName(i)})
+	}
+	if len(f.sequences) > 0 {
+		idx := int((seq - 1) % int64(len(f.sequences)))
+		steps = append(steps, ExplainStep{Stage: "sequence", Index: idx})
+	}
+	for i := range ts {
+		steps = append(steps, ExplainStep{Stage: "call", Index: i})
+	}
+	if f.tapFn != nil {
+		steps = append(steps, ExplainStep{Stage: "tap", Index: 0})
+	}
+	return steps
+}
+
+// traitName returns the name of the global trait at index i ("" when anonymous).
+func (f *Factory[T]) traitName(i int) string {
+	if i < len(f.traitNames) {
+		return f.traitNames[i]
+	}
+	return ""
+}
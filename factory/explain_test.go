@@ -0,0 +1,97 @@
+package factory
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFactory_Explain(t *testing.T) {
+	f := New(func(seq int64) User {
+		return User{Name: fmt.Sprintf("User %d", seq)}
+	}).WithDefaults(func(u *User) {
+		u.Email = "default@example.com"
+	}).WithTraits(func(u *User) {
+		u.Email = "trait@example.com"
+	}).Sequence(
+		func(u *User) { u.ID = "a" },
+		func(u *User) { u.ID = "b" },
+		func(u *User) { u.ID = "c" },
+	).DefineState("admin", func(u *User) {
+		u.Email = "admin@example.com"
+	}).Tap(func(u User) {})
+
+	f.MakeMany(4)
+	admin := f.State("admin")
+
+	got := admin.Explain(func(u *User) {}).String()
+	want := `1. make[0] seq=5
+2. defaults[0]
+3. traits[0]
+4. traits[1] state:admin
+5. sequence[1]
+6. call[0]
+7. tap[0]`
+	if got != want {
+		t.Fatalf("unexpected explanation:\n%s\nwant:\n%s", got, want)
+	}
+
+	// Explain does not advance the sequence
+	if u := admin.Make(); u.Name != "User 5" || u.ID != "b" {
+		t.Fatalf("expected seq 5 with sequence index 1, got %+v", u)
+	}
+}
+
+func TestFactory_ExplainMinimal(t *testing.T) {
+	f := New(func(seq int64) User { return User{} })
+
+	steps := f.Explain()
+	if len(steps) != 1 || steps[0].Stage != "make" {
+		t.Fatalf("expected only the make step, got %v", steps)
+	}
+}
+
+func TestFactory_WithNamedTrait(t *testing.T) {
+	f := New(func(seq int64) User {
+		return User{}
+	}).WithTraits(func(u *User) {
+		u.Name = "anonymous"
+	}).WithNamedTrait("verified-email", func(u *User) {
+		u.Email = "verified@example.com"
+	})
+
+	if u := f.Make(); u.Email != "verified@example.com" || u.Name != "anonymous" {
+		t.Fatalf("expected both traits to apply, got %+v", u)
+	}
+
+	steps := f.Explain()
+	if steps[1].Name != "" || steps[2].Name != "verified-email" {
+		t.Fatalf("expected named trait in explanation, got %v", steps)
+	}
+
+	// Names survive Clone and State copies
+	clone := f.Clone().DefineState("admin", func(u *User) {}).State("admin")
+	steps = clone.Explain()
+	if steps[2].Name != "verified-email" || steps[3].Name != "state:admin" {
+		t.Fatalf("expected names to be retained, got %v", steps)
+	}
+}
+
+func TestFactory_UnknownStateListsDefined(t *testing.T) {
+	f := New(func(seq int64) User {
+		return User{}
+	}).DefineState("verified", func(u *User) {}).DefineState("admin", func(u *User) {})
+
+	if states := f.States(); len(states) != 2 || states[0] != "admin" || states[1] != "verified" {
+		t.Fatalf("expected sorted state names, got %v", states)
+	}
+
+	_, err := f.TryState("banned")
+	if err == nil || err.Error() != "factory: unknown state 'banned' (defined: admin, verified)" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = New(func(seq int64) User { return User{} }).TryState("admin")
+	if err == nil || err.Error() != "factory: unknown state 'admin' (no states defined)" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
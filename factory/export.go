@@ -0,0 +1,291 @@
+package factory
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportFormat selects the output of Export.
+type ExportFormat int
+
+const (
+	// ExportNDJSON writes one JSON object per line (struct json tags apply).
+	ExportNDJSON ExportFormat = iota
+	// ExportCSV writes a header of column names, then one row per item.
+	ExportCSV
+	// ExportSQL writes one INSERT statement per item into ExportOptions.Table.
+	ExportSQL
+)
+
+// ExportOptions configures Export.
+type ExportOptions struct {
+	Format ExportFormat
+	Table  string // Table name for ExportSQL
+	Masked bool   // Hide MarkPII fields (see Mask)
+}
+
+// Export writes items in the chosen format, masking PII fields when opts.Masked is set.
+// CSV and SQL use the exported top-level fields in struct order, named like the NDJSON
+// keys (the json tag name, else the field name; json:"-" fields are left out), so all
+// formats share column names. Untagged embedded structs are flattened as encoding/json
+// does (embedded pointers and unexported embedded structs are not). Nested values are
+// written as JSON and times as RFC 3339.
+// SQL identifiers are double-quoted; a dotted table name is quoted per part.
+// Example: userFactory.Export(w, users, ExportOptions{Format: ExportCSV, Masked: true})
+func (f *Factory[T]) Export(w io.Writer, items []T, opts ExportOptions) error {
+	v := f.view()
+	f = &v
+	if f.requireMask && len(f.pii) > 0 && !opts.Masked {
+		return ErrUnmaskedPII
+	}
+	rows := items
+	if opts.Masked && len(f.pii) > 0 {
+		rows = make([]T, len(items))
+		for i, item := range items {
+			rows[i] = f.Mask(item)
+		}
+	}
+
+	switch opts.Format {
+	case ExportNDJSON:
+		enc := json.NewEncoder(w)
+		for _, row := range rows {
+			if err := enc.Encode(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	case ExportCSV:
+		return exportCSV(w, rows)
+	case ExportSQL:
+		if opts.Table == "" {
+			return errors.New("factory: ExportSQL requires a table name")
+		}
+		return exportSQL(w, opts.Table, rows)
+	default:
+		return fmt.Errorf("factory: unknown export format %d", opts.Format)
+	}
+}
+
+func exportCSV[T any](w io.Writer, rows []T) error {
+	cols, err := exportColumns[T]()
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	header := make([]string, len(cols))
+	for i, c := range cols {
+		header[i] = c.name
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for i := range rows {
+		v := reflect.ValueOf(rows[i])
+		record := make([]string, len(cols))
+		for j, c := range cols {
+			if record[j], err = csvValue(columnValue(v, c.index)); err != nil {
+				return err
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func exportSQL[T any](w io.Writer, table string, rows []T) error {
+	cols, err := exportColumns[T]()
+	if err != nil {
+		return err
+	}
+	names := make([]string, len(cols))
+	for i, c := range cols {
+		names[i] = quoteIdent(c.name)
+	}
+	parts := strings.Split(table, ".")
+	for i, part := range parts {
+		parts[i] = quoteIdent(part)
+	}
+	prefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES (", strings.Join(parts, "."), strings.Join(names, ", "))
+
+	for i := range rows {
+		v := reflect.ValueOf(rows[i])
+		values := make([]string, len(cols))
+		for j, c := range cols {
+			if values[j], err = sqlValue(columnValue(v, c.index)); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, prefix+strings.Join(values, ", ")+");\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportColumn is one CSV or SQL column.
+type exportColumn struct {
+	name   string
+	index  []int
+	tagged bool // Named by a json tag, which wins over untagged names at the same depth
+}
+
+// exportColumns returns the exported top-level fields of T, named as encoding/json would.
+func exportColumns[T any]() ([]exportColumn, error) {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("factory: CSV and SQL exports require a struct type, got %T", zero)
+	}
+	cols := structColumns(t, nil, map[reflect.Type]bool{t: true})
+
+	// Like encoding/json, a name at a shallower depth hides deeper ones; at the same
+	// depth a single tagged field wins, and otherwise colliding names are dropped
+	type rank struct{ depth, tagged, untagged int }
+	ranks := make(map[string]rank, len(cols))
+	for _, c := range cols {
+		r, ok := ranks[c.name]
+		if !ok || len(c.index) < r.depth {
+			r = rank{depth: len(c.index)}
+		} else if len(c.index) > r.depth {
+			continue
+		}
+		if c.tagged {
+			r.tagged++
+		} else {
+			r.untagged++
+		}
+		ranks[c.name] = r
+	}
+	out := cols[:0]
+	for _, c := range cols {
+		r := ranks[c.name]
+		if len(c.index) == r.depth && (r.tagged == 1 && c.tagged || r.tagged == 0 && r.untagged == 1) {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// structColumns lists t's exported fields, flattening untagged exported embedded
+// structs and struct pointers. seen holds the struct types on the current path, so
+// recursive embeds stop.
+func structColumns(t reflect.Type, parent []int, seen map[reflect.Type]bool) []exportColumn {
+	var cols []exportColumn
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		index := append(append([]int{}, parent...), i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		tagged := name != ""
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if !seen[ft] {
+					seen[ft] = true
+					cols = append(cols, structColumns(ft, index, seen)...)
+					delete(seen, ft)
+				}
+				continue
+			}
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		cols = append(cols, exportColumn{name: name, index: index, tagged: tagged})
+	}
+	return cols
+}
+
+// columnValue returns the field at index in v. A nil embedded pointer on the way
+// yields a nil pointer of the field's type, which exports as empty or NULL.
+func columnValue(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				ft := v.Type().Elem().FieldByIndex(index[i:]).Type
+				if ft.Kind() != reflect.Ptr {
+					ft = reflect.PointerTo(ft)
+				}
+				return reflect.Zero(ft)
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// quoteIdent double-quotes a SQL identifier, doubling any quotes inside it.
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// csvValue formats a field for CSV; nil pointers are empty.
+func csvValue(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	switch x := v.Interface().(type) {
+	case string:
+		return x, nil
+	case time.Time:
+		return x.Format(time.RFC3339Nano), nil
+	}
+	switch v.Kind() {
+	case reflect.String: // Named string types (type Role string)
+		return v.String(), nil
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v.Interface()), nil
+	}
+	data, err := json.Marshal(v.Interface())
+	return string(data), err
+}
+
+// sqlValue formats a field as a SQL literal; nil pointers, slices, and maps are NULL.
+func sqlValue(v reflect.Value) (string, error) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		if v.IsNil() {
+			return "NULL", nil
+		}
+	}
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return strings.ToUpper(strconv.FormatBool(v.Bool())), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v.Interface()), nil
+	}
+	s, err := csvValue(v)
+	if err != nil {
+		return "", err
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'", nil
+}
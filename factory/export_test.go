@@ -0,0 +1,229 @@
+package factory
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type exportRow struct {
+	ID     int
+	Name   string
+	Active bool
+	Note   *string
+	Tags   []string
+}
+
+func TestFactory_Export(t *testing.T) {
+	f := New(func(seq int64) exportRow {
+		return exportRow{ID: int(seq), Name: "O'Brien", Active: true, Tags: []string{"a"}}
+	}).MarkPII("Name")
+	rows := f.MakeMany(2)
+
+	var b strings.Builder
+	if err := f.Export(&b, rows, ExportOptions{Format: ExportCSV}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "ID,Name,Active,Note,Tags\n1,O'Brien,true,,\"[\"\"a\"\"]\"\n2,O'Brien,true,,\"[\"\"a\"\"]\"\n"
+	if b.String() != want {
+		t.Fatalf("expected CSV %q, got %q", want, b.String())
+	}
+
+	b.Reset()
+	if err := f.Export(&b, rows[:1], ExportOptions{Format: ExportSQL, Table: "rows"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = "INSERT INTO \"rows\" (\"ID\", \"Name\", \"Active\", \"Note\", \"Tags\") VALUES (1, 'O''Brien', TRUE, NULL, '[\"a\"]');\n"
+	if b.String() != want {
+		t.Fatalf("expected SQL %q, got %q", want, b.String())
+	}
+
+	b.Reset()
+	if err := f.Export(&b, rows, ExportOptions{Format: ExportNDJSON, Masked: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 2 || strings.Contains(b.String(), "Brien") || !strings.Contains(lines[0], `"Name":"masked:`) {
+		t.Fatalf("expected 2 masked lines, got %q", b.String())
+	}
+	if rows[0].Name != "O'Brien" {
+		t.Fatalf("expected export not to modify items, got %q", rows[0].Name)
+	}
+}
+
+type taggedExportRow struct {
+	ID       int    `json:"id"`
+	FullName string `json:"full_name,omitempty"`
+	Secret   string `json:"-"`
+}
+
+func TestFactory_Export_ColumnNames(t *testing.T) {
+	f := New(func(seq int64) taggedExportRow {
+		return taggedExportRow{ID: int(seq), FullName: "Ada", Secret: "x"}
+	})
+	rows := f.MakeMany(1)
+
+	var b strings.Builder
+	if err := f.Export(&b, rows, ExportOptions{Format: ExportCSV}); err != nil {
+		t.Fatal(err)
+	}
+	if header, _, _ := strings.Cut(b.String(), "\n"); header != "id,full_name" {
+		t.Fatalf("expected json tag names in the CSV header, got %q", header)
+	}
+
+	b.Reset()
+	if err := f.Export(&b, rows, ExportOptions{Format: ExportSQL, Table: `app.user"s`}); err != nil {
+		t.Fatal(err)
+	}
+	want := `INSERT INTO "app"."user""s" ("id", "full_name") VALUES (1, 'Ada');` + "\n"
+	if b.String() != want {
+		t.Fatalf("expected SQL %q, got %q", want, b.String())
+	}
+
+	b.Reset()
+	if err := f.Export(&b, rows, ExportOptions{Format: ExportNDJSON}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), `"full_name":"Ada"`) || strings.Contains(b.String(), "Secret") {
+		t.Fatalf("expected NDJSON keys to match the CSV columns, got %q", b.String())
+	}
+}
+
+type exportRole string
+
+type ExportAudit struct {
+	CreatedBy string `json:"created_by"`
+	Note      string // Hidden by the outer Note
+}
+
+type enumExportRow struct {
+	ExportAudit
+	ID   int
+	Role exportRole
+	Note string
+}
+
+func TestFactory_Export_NamedStringsAndEmbedded(t *testing.T) {
+	f := New(func(seq int64) enumExportRow {
+		return enumExportRow{ExportAudit: ExportAudit{CreatedBy: "seed", Note: "inner"}, ID: int(seq), Role: "admin", Note: "outer"}
+	})
+	rows := f.MakeMany(1)
+
+	var b strings.Builder
+	if err := f.Export(&b, rows, ExportOptions{Format: ExportCSV}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "created_by,ID,Role,Note\nseed,1,admin,outer\n"; b.String() != want {
+		t.Fatalf("expected CSV %q, got %q", want, b.String())
+	}
+
+	b.Reset()
+	if err := f.Export(&b, rows, ExportOptions{Format: ExportSQL, Table: "rows"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := `INSERT INTO "rows" ("created_by", "ID", "Role", "Note") VALUES ('seed', 1, 'admin', 'outer');` + "\n"; b.String() != want {
+		t.Fatalf("expected SQL %q, got %q", want, b.String())
+	}
+}
+
+type exportBase struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type embeddedExportRow struct {
+	exportBase
+	ID int `json:"id"`
+}
+
+func TestFactory_Export_SkipsUnexportedEmbedded(t *testing.T) {
+	f := New(func(seq int64) embeddedExportRow {
+		return embeddedExportRow{ID: int(seq)}
+	})
+	rows := f.MakeMany(1)
+	rows[0].CreatedBy = "seed"
+
+	var b strings.Builder
+	if err := f.Export(&b, rows, ExportOptions{Format: ExportCSV}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "id\n1\n"; b.String() != want {
+		t.Fatalf("expected unexported embedded structs to be skipped, got %q", b.String())
+	}
+}
+
+type ExportTimestamps struct {
+	CreatedAt string `json:"created_at"`
+	Note      string
+}
+
+type ExportLabel struct {
+	Note string `json:"Note"` // Tagged, so it wins over ExportTimestamps.Note at the same depth
+}
+
+type pointerEmbedRow struct {
+	*ExportTimestamps
+	ExportLabel
+	ID int `json:"id"`
+}
+
+func TestFactory_Export_PointerEmbedsMatchJSON(t *testing.T) {
+	f := New(func(seq int64) pointerEmbedRow {
+		return pointerEmbedRow{ID: int(seq), ExportLabel: ExportLabel{Note: "label"}}
+	})
+	rows := f.MakeMany(2)
+	rows[0].ExportTimestamps = &ExportTimestamps{CreatedAt: "2024-01-01", Note: "n"}
+
+	var b strings.Builder
+	if err := f.Export(&b, rows, ExportOptions{Format: ExportCSV}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "created_at,Note,id\n2024-01-01,label,1\n,label,2\n"; b.String() != want {
+		t.Fatalf("expected CSV %q, got %q", want, b.String())
+	}
+
+	// The CSV header follows the keys encoding/json writes
+	var obj map[string]any
+	data, _ := json.Marshal(rows[0])
+	if err := json.Unmarshal(data, &obj); err != nil {
+		t.Fatal(err)
+	}
+	header := strings.Split(strings.SplitN(b.String(), "\n", 2)[0], ",")
+	if len(obj) != len(header) {
+		t.Fatalf("expected columns %q to match JSON keys %s", header, data)
+	}
+	for _, name := range header {
+		if _, ok := obj[name]; !ok {
+			t.Fatalf("column %q is not a JSON key in %s", name, data)
+		}
+	}
+
+	b.Reset()
+	if err := f.Export(&b, rows[1:], ExportOptions{Format: ExportSQL, Table: "rows"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := `INSERT INTO "rows" ("created_at", "Note", "id") VALUES (NULL, 'label', 2);` + "\n"; b.String() != want {
+		t.Fatalf("expected SQL %q, got %q", want, b.String())
+	}
+}
+
+func TestFactory_Export_RequireMasked(t *testing.T) {
+	f := New(func(seq int64) User { return User{Email: "a@example.com"} }).
+		MarkPII("Email").
+		RequireMaskedExports()
+	users := f.MakeMany(1)
+
+	var b strings.Builder
+	if err := f.Export(&b, users, ExportOptions{Format: ExportCSV}); !errors.Is(err, ErrUnmaskedPII) {
+		t.Fatalf("expected ErrUnmaskedPII, got %v", err)
+	}
+	if err := f.Export(&b, users, ExportOptions{Format: ExportCSV, Masked: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(b.String(), "a@example.com") {
+		t.Fatalf("expected email to be masked, got %q", b.String())
+	}
+	if err := f.Export(&b, users, ExportOptions{Format: ExportSQL, Masked: true}); err == nil {
+		t.Fatal("expected error for SQL export without table")
+	}
+}
@@ -3,6 +3,11 @@ package factory
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
 	"sync/atomic"
 )
 
@@ -15,63 +20,156 @@ type BeforeCreate[T any] func(ctx context.Context, t *T) error
 // AfterCreate runs after persistence (e.g., create related rows).
 type AfterCreate[T any] func(ctx context.Context, t *T) error
 
+// AfterCreateDiff runs after persistence with both the value as built (after
+// BeforeCreate hooks) and the persisted result, to detect what the database changed.
+type AfterCreateDiff[T any] func(ctx context.Context, made *T, saved *T) error
+
+// AfterCreateBatch runs once after a CreateMany/Count batch with all created items.
+type AfterCreateBatch[T any] func(ctx context.Context, items []*T) error
+
 // PersistFn saves *T (user provides DB logic) and returns possibly updated *T.
 type PersistFn[T any] func(ctx context.Context, t *T) (*T, error)
 
 // Factory builds Ts with defaults, traits, and optional persistence.
+//
+// A factory is safe for concurrent use: Make, Raw, Create, CreateMany, and State may
+// run from many goroutines, and builder methods (When, WithTraits, DefineState,
+// AfterCreate, ...) may run concurrently with them. Builders still change the
+// factory in place, so a build that starts after a builder returns sees its change,
+// while builds already running use the configuration they started with. Use State
+// or Clone to derive variations without touching a shared factory, or Immutable to
+// make every builder return a copy.
 type Factory[T any] struct {
+	name        string // Display name (see WithName)
 	makeFn      func(seq int64) T
-	defaults    []Trait[T]          // Applied first (for faker/defaults)
+	defaults    []func(int64, *T)   // Applied first (for faker/defaults; receive the sequence)
 	rawDefaults []Trait[T]          // Applied only for Raw/RawJSON methods
 	traits      []Trait[T]          // Applied second (global traits)
+	traitNames  []string            // Names of traits by index ("" when anonymous)
+	probTraits  []int               // Indexes into traits applied only with a probability (see ApplyConfig)
 	sequences   []Trait[T]          // Cycled through for each item
 	states      map[string]Trait[T] // Named states (like Laravel state methods)
+	invalid     map[string]string   // Reasons for states that make an item invalid (see DefineInvalid)
 	persist     PersistFn[T]
-	before      []BeforeCreate[T] // Hooks before persistence
-	after       []AfterCreate[T]  // Hooks after persistence
-	tapFn       func(T)           // Tap function for debugging
-	seq         int64
-	count       int // Count for fluent API (0 means not set)
+	steps       []persistStep[T]      // Pipeline behind persist (see AddPersist)
+	router      func(*T) PersistFn[T] // Picks a persist function per item (sharding)
+	before      []BeforeCreate[T]     // Hooks before persistence
+	after       []AfterCreate[T]      // Hooks after persistence
+	planHooks   []int                 // Indexes into after that Plan also runs (see relationHook)
+	afterDiff   []AfterCreateDiff[T]  // Hooks after persistence that see the built value
+	afterBatch  []AfterCreateBatch[T] // Hooks after a whole CreateMany batch
+	hookPolicy  HookErrorPolicy       // What Create returns when an after hook fails
+	tapFn       func(T)               // Tap function for debugging
+	pool        *sync.Pool            // Scratch values for RawJSON (nil means not pooled)
+	group       *Group                // Shared project-wide settings (nil when ungrouped)
+	baseCtx     context.Context       // Values merged into Create contexts (see WithContext)
+	fallbackIDs *int64                // Auto-ID counter when Create falls back to Make (nil when off)
+	pii         []string              // Fields masked by masked exports (see MarkPII)
+	requireMask bool                  // Reject unmasked exports when PII is marked
+	runPrefix   string                // Prepended to prefixed fields (see WithRunPrefix)
+	prefixed    []func(*T) *string    // Fields that receive runPrefix
+	idKey       func(*T) string       // Natural key for derived IDs (see WithDeterministicID)
+	probConfig  FactoryConfig         // Probabilities and seed applied by ApplyConfig (see ExportConfig)
+	envAllowed  []string              // Environments Create may run in (nil means unguarded)
+	envFn       func() string         // Reports the current environment (nil reads EnvironmentEnv)
+	identity    func(*T) string       // Logical identity for graph deduplication (see WithIdentity)
+	overrides   *slog.Logger          // Warns when per-call traits overwrite state fields (nil means off)
+	chaos       *chaos                // Injected persist failures and latency (see WithChaos)
+	batchSeq    bool                  // Count batches number from 1 (see WithBatchSequences)
+	immutable   bool                  // Builders return modified copies (see Immutable)
+	finder      Finder[T]             // Fetches a persisted T by ID (see WithFinder)
+	required    []string              // Fields kept by Minimal (see WithRequired)
+	persistMany PersistManyFn[T]      // Bulk persister for CreateManyBatch (see WithPersistMany)
+	provenance  func(*T) *string      // Field stamped with Provenance (see WithProvenance)
+	sidecar     SidecarFn[T]          // Receives provenance after persist (see WithProvenanceSidecar)
+	seq         *int64                // Own sequence counter (see counter)
+	autoSeq     *int64                // In-memory ID counter when WithFallbackToMake is off (see assignAutoID)
+	sharedSeq   *int64                // Counter shared with another factory (see CloneWithSequence)
+	count       int                   // Count for fluent API (0 means not set)
+	mu          *sync.RWMutex         // Guards configuration against concurrent builders (see view)
 }
 
 // CountedFactory is a fluent wrapper that knows how many items to create.
 type CountedFactory[T any] struct {
-	factory *Factory[T]
-	count   int
+	factory      *Factory[T]
+	count        int
+	distribution []stateCount       // Exact per-state counts (see Distribute)
+	start        int64              // First sequence number of the batch (0 means continue the factory's; see StartingSeqAt)
+	percents     []percentTraits[T] // Traits applied to a share of the batch (see Percent)
 }
 
 // New constructs a factory with a default make function (receives a sequence number).
 func New[T any](makeFn func(seq int64) T) *Factory[T] {
 	return &Factory[T]{
-		makeFn: makeFn,
-		states: make(map[string]Trait[T]),
+		makeFn:  makeFn,
+		states:  make(map[string]Trait[T]),
+		seq:     new(int64),
+		autoSeq: new(int64),
+		mu:      new(sync.RWMutex),
 	}
 }
 
 // WithDefaults sets default traits applied first (ideal for faker/default values).
 // These are applied before WithTraits and per-call traits.
 func (f *Factory[T]) WithDefaults(ts ...Trait[T]) *Factory[T] {
-	f.defaults = append(f.defaults, ts...)
+	f = f.edit()
+	defer f.mu.Unlock()
+	for _, tr := range ts {
+		f.defaults = grow(f.defaults, ignoreSeq(tr))
+	}
+	return f
+}
+
+// WithDefaultsSeq adds defaults that also receive the item's sequence number,
+// so faker-style defaults can build unique values without duplicating makeFn logic.
+// Runs in registration order with WithDefaults traits.
+// Example: factory.WithDefaultsSeq(func(seq int64, u *User) { u.Email = fmt.Sprintf("user%d@example.com", seq) })
+func (f *Factory[T]) WithDefaultsSeq(fns ...func(seq int64, t *T)) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.defaults = grow(f.defaults, fns...)
 	return f
 }
 
+// ignoreSeq adapts a Trait to the sequence-aware form used for defaults.
+func ignoreSeq[T any](tr Trait[T]) func(int64, *T) {
+	return func(_ int64, t *T) { tr(t) }
+}
+
 // WithRawDefaults sets traits applied ONLY when using Raw/RawJSON methods.
 // Useful for adding fields needed for API testing but not for persistence.
 // Example: Add validation fields, computed fields, or API-specific attributes.
 func (f *Factory[T]) WithRawDefaults(ts ...Trait[T]) *Factory[T] {
-	f.rawDefaults = append(f.rawDefaults, ts...)
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.rawDefaults = grow(f.rawDefaults, ts...)
+	return f
+}
+
+// WithNamedTrait appends a global trait with a human-readable name, shown by
+// Explain and in error messages instead of an anonymous function.
+// Example: factory.WithNamedTrait("verified", func(u *User) { u.Verified = true })
+func (f *Factory[T]) WithNamedTrait(name string, tr Trait[T]) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.traitNames = namesFor(f.traitNames, len(f.traits), name)
+	f.traits = grow(f.traits, tr)
 	return f
 }
 
 // WithTraits appends global traits applied to every Make/Create call.
 func (f *Factory[T]) WithTraits(ts ...Trait[T]) *Factory[T] {
-	f.traits = append(f.traits, ts...)
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.traits = grow(f.traits, ts...)
 	return f
 }
 
 // Sequence sets traits that cycle through for each created item (like Laravel's sequence()).
 // Example: Sequence(trait1, trait2) will alternate: trait1, trait2, trait1, trait2...
 func (f *Factory[T]) Sequence(ts ...Trait[T]) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
 	f.sequences = ts
 	return f
 }
@@ -79,96 +177,358 @@ func (f *Factory[T]) Sequence(ts ...Trait[T]) *Factory[T] {
 // DefineState registers a named state that can be applied later (like Laravel state methods).
 // Example: factory.DefineState("admin", func(u *User) { u.Role = "admin" })
 func (f *Factory[T]) DefineState(name string, trait Trait[T]) *Factory[T] {
-	f.states[name] = trait
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.defineState(name, trait)
 	return f
 }
 
+// defineState adds a state to a copy of the states map, so snapshots taken by
+// running builds keep theirs. The caller holds f's lock.
+func (f *Factory[T]) defineState(name string, trait Trait[T]) {
+	states := make(map[string]Trait[T], len(f.states)+1)
+	for k, v := range f.states {
+		states[k] = v
+	}
+	states[name] = trait
+	f.states = states
+}
+
+// States returns the names of all defined states, sorted, including group states
+// that apply to T (see DefineGroupState).
+func (f *Factory[T]) States() []string {
+	v := f.view()
+	f = &v
+	names := make([]string, 0, len(f.states))
+	for name := range f.states {
+		names = append(names, name)
+	}
+	if f.group != nil {
+		f.group.mu.RLock()
+		groupStates := f.group.states
+		f.group.mu.RUnlock()
+		for name := range groupStates {
+			if _, own := f.states[name]; !own {
+				if _, ok := f.groupState(name); ok {
+					names = append(names, name)
+				}
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// state returns the named state, falling back to the group's states.
+func (f *Factory[T]) state(name string) (Trait[T], bool) {
+	f.mu.RLock()
+	trait, ok := f.states[name]
+	f.mu.RUnlock()
+	if ok {
+		return trait, true
+	}
+	return f.groupState(name)
+}
+
 // State applies a previously defined named state by adding it as a trait.
 // Returns a new factory instance with the state applied.
 // Example: factory.State("admin").Make()
 func (f *Factory[T]) State(name string) *Factory[T] {
-	trait, ok := f.states[name]
+	trait, ok := f.state(name)
+	if !ok {
+		panic(f.unknownState(name).Error())
+	}
+	return f.withTrait("state:"+name, trait)
+}
+
+// TryState is like State but returns ErrUnknownState instead of panicking.
+func (f *Factory[T]) TryState(name string) (*Factory[T], error) {
+	trait, ok := f.state(name)
 	if !ok {
-		panic("factory: unknown state '" + name + "'")
+		return nil, f.unknownState(name)
+	}
+	return f.withTrait("state:"+name, trait), nil
+}
+
+// Apply returns a per-call trait that applies the named states in order,
+// without building an intermediate factory copy for one-off calls.
+// Panics with the same message as State if a name is not defined.
+// Example: factory.Create(ctx, factory.Apply("admin", "verified"))
+func (f *Factory[T]) Apply(names ...string) Trait[T] {
+	trait, err := f.TryApply(names...)
+	if err != nil {
+		panic(err.Error())
 	}
-	// Create a shallow copy with the state trait added
-	copy := *f
-	copy.traits = append([]Trait[T]{}, f.traits...)
-	copy.traits = append(copy.traits, trait)
-	return &copy
+	return trait
+}
+
+// TryApply is like Apply but returns ErrUnknownState instead of panicking.
+func (f *Factory[T]) TryApply(names ...string) (Trait[T], error) {
+	traits := make([]Trait[T], len(names))
+	for i, name := range names {
+		trait, ok := f.state(name)
+		if !ok {
+			return nil, f.unknownState(name)
+		}
+		traits[i] = trait
+	}
+	return func(t *T) {
+		for _, trait := range traits {
+			trait(t)
+		}
+	}, nil
+}
+
+// withTrait returns a shallow copy of the factory with a named trait appended to its global traits.
+func (f *Factory[T]) withTrait(name string, trait Trait[T]) *Factory[T] {
+	copy := f.shallow()
+	copy.traits = grow(copy.traits, trait)
+	copy.traitNames = namesFor(copy.traitNames, len(copy.traits)-1, name)
+	return copy
+}
+
+// namesFor returns a copy of names padded to index i, with name set at i.
+func namesFor(names []string, i int, name string) []string {
+	out := make([]string, i+1)
+	copy(out, names)
+	out[i] = name
+	return out
 }
 
 // WithPersist sets how to save T (optional; required for Create()).
 func (f *Factory[T]) WithPersist(p PersistFn[T]) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
 	f.persist = p
+	f.steps = nil
 	return f
 }
 
+// UsingPersist returns a copy of the factory that saves with p instead, leaving the
+// original untouched. The copy shares the original's sequence counter.
+// Example: factory.UsingPersist(txRepo.Save).Create(ctx)
+func (f *Factory[T]) UsingPersist(p PersistFn[T]) *Factory[T] {
+	copy := f.shallow()
+	copy.persist = p
+	copy.steps = nil
+	copy.router = nil
+	copy.persistMany = nil
+	copy.sharedSeq = f.counter()
+	return copy
+}
+
 // BeforeCreate adds hooks executed before persistence.
 func (f *Factory[T]) BeforeCreate(h BeforeCreate[T]) *Factory[T] {
-	f.before = append(f.before, h)
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.before = grow(f.before, h)
 	return f
 }
 
 // AfterCreate adds hooks executed after persistence.
 func (f *Factory[T]) AfterCreate(h AfterCreate[T]) *Factory[T] {
-	f.after = append(f.after, h)
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.after = grow(f.after, h)
+	return f
+}
+
+// relationHook adds an after hook that only creates records through other
+// factories, so Plan runs it to count them (see WithOutbox). Callers hold f's lock.
+func (f *Factory[T]) relationHook(h AfterCreate[T]) {
+	f.planHooks = grow(f.planHooks, len(f.after))
+	f.after = grow(f.after, h)
+}
+
+// WithHookErrorPolicy sets what Create returns when an after hook fails.
+// With HookErrorsReturnRecord, Create returns the persisted record together with a
+// *HookError, and CreateMany keeps creating the remaining items.
+// Example: u, err := factory.WithHookErrorPolicy(HookErrorsReturnRecord).Create(ctx)
+func (f *Factory[T]) WithHookErrorPolicy(p HookErrorPolicy) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.hookPolicy = p
+	return f
+}
+
+// AfterCreateDiff adds hooks executed after persistence (and after AfterCreate hooks)
+// that receive a snapshot of the value passed to persist alongside the saved result.
+// Example: detect columns filled by database defaults or triggers.
+func (f *Factory[T]) AfterCreateDiff(h AfterCreateDiff[T]) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.afterDiff = grow(f.afterDiff, h)
+	return f
+}
+
+// AfterCreateBatch adds hooks executed once per CreateMany/Count batch with all
+// created items, after every per-item hook has run. Useful for bulk follow-up work
+// (e.g., one bulk insert of audit rows). Not run for single Create calls or failed batches.
+func (f *Factory[T]) AfterCreateBatch(h AfterCreateBatch[T]) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.afterBatch = grow(f.afterBatch, h)
 	return f
 }
 
 // Tap sets a function to be called with each created item (useful for debugging/logging).
 func (f *Factory[T]) Tap(fn func(T)) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
 	f.tapFn = fn
 	return f
 }
 
 // When applies traits only if the condition is true.
 func (f *Factory[T]) When(condition bool, ts ...Trait[T]) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
 	if condition {
-		f.traits = append(f.traits, ts...)
+		f.traits = grow(f.traits, ts...)
 	}
 	return f
 }
 
 // Unless applies traits only if the condition is false.
 func (f *Factory[T]) Unless(condition bool, ts ...Trait[T]) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
 	if !condition {
-		f.traits = append(f.traits, ts...)
+		f.traits = grow(f.traits, ts...)
 	}
 	return f
 }
 
 // Clone creates a deep copy of the factory for creating variations.
 func (f *Factory[T]) Clone() *Factory[T] {
+	v := f.view()
+	f = &v
 	clone := &Factory[T]{
+		name:        f.name,
 		makeFn:      f.makeFn,
-		defaults:    append([]Trait[T]{}, f.defaults...),
+		defaults:    append([]func(int64, *T){}, f.defaults...),
 		rawDefaults: append([]Trait[T]{}, f.rawDefaults...),
 		traits:      append([]Trait[T]{}, f.traits...),
+		traitNames:  append([]string{}, f.traitNames...),
+		probTraits:  append([]int(nil), f.probTraits...),
 		sequences:   append([]Trait[T]{}, f.sequences...),
 		states:      make(map[string]Trait[T]),
 		persist:     f.persist,
+		steps:       append([]persistStep[T](nil), f.steps...),
+		router:      f.router,
 		before:      append([]BeforeCreate[T]{}, f.before...),
 		after:       append([]AfterCreate[T]{}, f.after...),
+		planHooks:   append([]int(nil), f.planHooks...),
+		afterDiff:   append([]AfterCreateDiff[T]{}, f.afterDiff...),
+		afterBatch:  append([]AfterCreateBatch[T]{}, f.afterBatch...),
 		tapFn:       f.tapFn,
-		seq:         0, // Reset sequence for clone
+		pool:        f.pool,
+		group:       f.group,
+		baseCtx:     f.baseCtx,
+		fallbackIDs: cloneCounter(f.fallbackIDs),
+		pii:         append([]string{}, f.pii...),
+		requireMask: f.requireMask,
+		runPrefix:   f.runPrefix,
+		prefixed:    append([]func(*T) *string{}, f.prefixed...),
+		idKey:       f.idKey,
+		probConfig:  f.probConfig,
+		envAllowed:  append([]string(nil), f.envAllowed...),
+		envFn:       f.envFn,
+		identity:    f.identity,
+		overrides:   f.overrides,
+		chaos:       f.chaos,
+		batchSeq:    f.batchSeq,
+		immutable:   f.immutable,
+		finder:      f.finder,
+		required:    append([]string(nil), f.required...),
+		persistMany: f.persistMany,
+		provenance:  f.provenance,
+		sidecar:     f.sidecar,
+		seq:         new(int64), // Reset sequence for clone
+		autoSeq:     new(int64),
+		mu:          new(sync.RWMutex),
+		hookPolicy:  f.hookPolicy,
 		count:       f.count,
 	}
 	// Deep copy states map
 	for k, v := range f.states {
 		clone.states[k] = v
 	}
+	if f.invalid != nil {
+		clone.invalid = make(map[string]string, len(f.invalid))
+		for k, v := range f.invalid {
+			clone.invalid[k] = v
+		}
+	}
 	return clone
 }
 
+// view returns a shallow snapshot of f's configuration for one build. Builders never
+// modify slices or maps in place (see grow), so the snapshot stays consistent while
+// they run. It shares f's lock and counters.
+func (f *Factory[T]) view() Factory[T] {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return *f
+}
+
+// shallow returns an independent shallow copy of f with its own lock and counters,
+// starting where f's are, for methods that derive a factory (State, ForModel, ...).
+func (f *Factory[T]) shallow() *Factory[T] {
+	c := f.view()
+	c.mu = new(sync.RWMutex)
+	c.seq = new(int64)
+	*c.seq = atomic.LoadInt64(f.seq)
+	c.autoSeq = new(int64)
+	*c.autoSeq = atomic.LoadInt64(f.autoSeq)
+	return &c
+}
+
+// edit returns the factory a builder should modify, locked: f itself, or for an
+// Immutable factory a fresh copy sharing f's sequence counter. Builders defer Unlock
+// on the result.
+func (f *Factory[T]) edit() *Factory[T] {
+	f.mu.Lock()
+	if !f.immutable {
+		return f
+	}
+	f.mu.Unlock()
+	c := f.shallow()
+	c.sharedSeq = f.counter()
+	c.mu.Lock()
+	return c
+}
+
+// grow appends items to a copy of s, never writing into s's spare capacity, so
+// snapshots and copies sharing s's backing array are unaffected.
+func grow[E any](s []E, items ...E) []E {
+	return append(s[:len(s):len(s)], items...)
+}
+
+// nextSeq hands out the next sequence number. Counters never go negative (see
+// SwapSequence), so a non-positive result means the int64 wrapped around; that panics
+// rather than silently reusing numbers.
 func (f *Factory[T]) nextSeq() int64 {
-	return atomic.AddInt64(&f.seq, 1)
+	seq := atomic.AddInt64(f.counter(), 1)
+	if seq <= 0 {
+		panic("factory: sequence overflowed int64; use ResetSequence or SwapSequence")
+	}
+	return seq
+}
+
+// counter returns the sequence counter in use, which may be shared with another factory.
+func (f *Factory[T]) counter() *int64 {
+	if f.sharedSeq != nil {
+		return f.sharedSeq
+	}
+	return f.seq
 }
 
 // ResetSequence resets the sequence counter to 0.
 // Useful for test isolation to get predictable sequence numbers.
+// Use SwapSequence to also learn the previous value.
 func (f *Factory[T]) ResetSequence() *Factory[T] {
-	atomic.StoreInt64(&f.seq, 0)
+	atomic.StoreInt64(f.counter(), 0)
 	return f
 }
 
@@ -176,10 +536,14 @@ func (f *Factory[T]) ResetSequence() *Factory[T] {
 // Returns a CountedFactory that has Make() and Create() methods for multiple items.
 // Example: factory.Count(10).Make() or factory.Count(5).State("admin").Create(ctx)
 func (f *Factory[T]) Count(n int) *CountedFactory[T] {
-	return &CountedFactory[T]{
+	cf := &CountedFactory[T]{
 		factory: f,
 		count:   n,
 	}
+	if v := f.view(); v.batchSeq {
+		cf.start = 1 // Private window (see WithBatchSequences)
+	}
+	return cf
 }
 
 // Times is an alias for Count (more semantic in some contexts).
@@ -190,26 +554,27 @@ func (f *Factory[T]) Times(n int) *CountedFactory[T] {
 // Make builds but does not persist (like Laravel's make()).
 // Applies traits in order: defaults → global traits → sequence → per-call traits.
 func (f *Factory[T]) Make(ts ...Trait[T]) T {
+	v := f.view()
+	f = &v
 	seq := f.nextSeq()
 	t := f.makeFn(seq)
 
 	// Apply defaults first (faker/default values)
 	for _, tr := range f.defaults {
-		tr(&t)
+		tr(seq, &t)
 	}
 	// Then global traits
-	for _, tr := range f.traits {
-		tr(&t)
-	}
+	set := f.applyGlobal(&t)
 	// Then sequence trait (cycles through)
 	if len(f.sequences) > 0 {
 		idx := int((seq - 1) % int64(len(f.sequences)))
 		f.sequences[idx](&t)
 	}
 	// Finally per-call traits
-	for _, tr := range ts {
-		tr(&t)
-	}
+	f.applyPerCall(&t, ts, set)
+	f.applyRunPrefix(&t)
+	f.applyDeterministicID(&t)
+	f.applyProvenance(&t)
 	// Call tap function if set
 	if f.tapFn != nil {
 		f.tapFn(t)
@@ -221,35 +586,41 @@ func (f *Factory[T]) Make(ts ...Trait[T]) T {
 // Applies: defaults → rawDefaults → global traits → sequence → per-call traits.
 // Useful for getting attribute values for testing validation or API requests.
 func (f *Factory[T]) Raw(ts ...Trait[T]) T {
+	var t T
+	f.rawInto(&t, ts...)
+	return t
+}
+
+// rawInto runs the Raw pipeline in place, so pooled values can be reused.
+func (f *Factory[T]) rawInto(t *T, ts ...Trait[T]) {
+	v := f.view()
+	f = &v
 	seq := f.nextSeq()
-	t := f.makeFn(seq)
+	*t = f.makeFn(seq)
 
 	// Apply defaults first (faker/default values)
 	for _, tr := range f.defaults {
-		tr(&t)
+		tr(seq, t)
 	}
 	// Then raw-specific defaults
 	for _, tr := range f.rawDefaults {
-		tr(&t)
+		tr(t)
 	}
 	// Then global traits
-	for _, tr := range f.traits {
-		tr(&t)
-	}
+	set := f.applyGlobal(t)
 	// Then sequence trait (cycles through)
 	if len(f.sequences) > 0 {
 		idx := int((seq - 1) % int64(len(f.sequences)))
-		f.sequences[idx](&t)
+		f.sequences[idx](t)
 	}
 	// Finally per-call traits
-	for _, tr := range ts {
-		tr(&t)
-	}
+	f.applyPerCall(t, ts, set)
+	f.applyRunPrefix(t)
+	f.applyDeterministicID(t)
 	// Call tap function if set
 	if f.tapFn != nil {
-		f.tapFn(t)
+		f.tapFn(*t)
 	}
-	return t
 }
 
 // RawMany builds count items without persisting, with rawDefaults applied.
@@ -264,45 +635,159 @@ func (f *Factory[T]) RawMany(count int, ts ...Trait[T]) []T {
 // RawJSON builds and returns JSON representation (like Laravel's raw()).
 // Useful for testing API endpoints without persistence.
 func (f *Factory[T]) RawJSON(ts ...Trait[T]) ([]byte, error) {
+	v := f.view()
+	f = &v
+	if f.pool != nil {
+		return f.pooledRawJSON(ts...)
+	}
 	obj := f.Raw(ts...)
 	return json.Marshal(obj)
 }
 
 // RawManyJSON builds count items and returns JSON array.
 func (f *Factory[T]) RawManyJSON(count int, ts ...Trait[T]) ([]byte, error) {
+	v := f.view()
+	f = &v
+	if f.pool != nil {
+		return f.pooledRawManyJSON(count, ts...)
+	}
 	items := f.RawMany(count, ts...)
 	return json.Marshal(items)
 }
 
 // Create builds, persists, runs hooks, and returns *T (like Laravel's create()).
 func (f *Factory[T]) Create(ctx context.Context, ts ...Trait[T]) (*T, error) {
-	if f.persist == nil {
+	v := f.view()
+	return v.create(ctx, ts...)
+}
+
+// create is Create on a factory that is already a snapshot (see view).
+func (f *Factory[T]) create(ctx context.Context, ts ...Trait[T]) (*T, error) {
+	if !f.canPersist() {
 		panic("factory: Create called without persist function; use WithPersist")
 	}
 	obj := f.Make(ts...)
+	return f.save(ctx, &obj)
+}
+
+// save runs hooks and persists an already built item, reusing the record already
+// created for its identity when ctx carries an identity map (see WithIdentity).
+func (f *Factory[T]) save(ctx context.Context, obj *T) (*T, error) {
+	ctx = f.withBase(ctx)
+	if m := identitiesFrom(ctx); m != nil && f.identity != nil {
+		return resolveIdentity(m, typeName[T]()+"\x00"+f.identity(obj), func() (*T, error) {
+			return f.saveNew(ctx, obj)
+		})
+	}
+	return f.saveNew(ctx, obj)
+}
+
+// saveNew runs hooks and persistence for obj.
+func (f *Factory[T]) saveNew(ctx context.Context, obj *T) (*T, error) {
+	f.recordBuilt(ctx, obj)
+	if p := plannerFrom(ctx); p != nil {
+		return f.planSave(ctx, p, obj)
+	}
+	if err := f.checkEnvironment(ctx); err != nil {
+		return nil, err
+	}
 
 	// Run before hooks
 	for _, h := range f.before {
-		if err := h(ctx, &obj); err != nil {
+		if err := h(ctx, obj); err != nil {
 			return nil, err
 		}
 	}
 
-	// Persist
-	out, err := f.persist(ctx, &obj)
+	// Snapshot the built value; persist may modify obj in place
+	var made T
+	if len(f.afterDiff) > 0 {
+		made = *obj
+	}
+
+	// Persist (routed to a shard if a router is set)
+	persist, err := f.persistFor(obj)
 	if err != nil {
 		return nil, err
 	}
+	if c := PersistCounterFrom(ctx); c != nil {
+		c.record(fmt.Sprintf("%T", *obj))
+	}
+	out, err := persist(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	recordCreated(ctx, out)
+	if f.sidecar != nil {
+		if err := f.sidecar(ctx, out, f.Provenance()); err != nil {
+			return nil, err
+		}
+	}
 
 	// Run after hooks
+	if f.hookPolicy == HookErrorsReturnRecord {
+		return f.runAfterHooksCollect(ctx, &made, out)
+	}
 	for _, h := range f.after {
 		if err := h(ctx, out); err != nil {
 			return nil, err
 		}
 	}
+	for _, h := range f.afterDiff {
+		if err := h(ctx, &made, out); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// runAfterHooksCollect runs every after hook even if some fail, returning the
+// persisted record with a *HookError listing the failures.
+func (f *Factory[T]) runAfterHooksCollect(ctx context.Context, made, out *T) (*T, error) {
+	var errs []error
+	for _, h := range f.after {
+		if err := h(ctx, out); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, h := range f.afterDiff {
+		if err := h(ctx, made, out); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return out, &HookError{Err: errors.Join(errs...)}
+	}
 	return out, nil
 }
 
+// TryCreate is like Create but returns ErrNoPersist instead of panicking
+// when no persist function is configured.
+func (f *Factory[T]) TryCreate(ctx context.Context, ts ...Trait[T]) (*T, error) {
+	v := f.view()
+	f = &v
+	if !f.canPersist() {
+		return nil, ErrNoPersist
+	}
+	return f.Create(ctx, ts...)
+}
+
+// TryCreateMany is like CreateMany but returns ErrNoPersist instead of panicking.
+func (f *Factory[T]) TryCreateMany(ctx context.Context, count int, ts ...Trait[T]) ([]*T, error) {
+	v := f.view()
+	f = &v
+	if !f.canPersist() {
+		return nil, ErrNoPersist
+	}
+	return f.CreateMany(ctx, count, ts...)
+}
+
+// CreateWith is like Create but saves with p for this call only.
+// Useful for redirecting a single creation into a transaction or a spy.
+func (f *Factory[T]) CreateWith(ctx context.Context, p PersistFn[T], ts ...Trait[T]) (*T, error) {
+	return f.UsingPersist(p).Create(ctx, ts...)
+}
+
 // MakeMany builds count items without persisting (like Laravel's count()->make()).
 func (f *Factory[T]) MakeMany(count int, ts ...Trait[T]) []T {
 	items := make([]T, count)
@@ -312,38 +797,128 @@ func (f *Factory[T]) MakeMany(count int, ts ...Trait[T]) []T {
 	return items
 }
 
+// MakeManyIndexed is like MakeMany but passes each item's index in the batch
+// (0-based, unlike the global sequence) to fn, applied after the per-call traits,
+// for batch-local logic like "the first item is the owner, the rest are members".
+// Example: factory.MakeManyIndexed(5, func(i int, m *Member) { m.Owner = i == 0 })
+func (f *Factory[T]) MakeManyIndexed(count int, fn func(i int, t *T), ts ...Trait[T]) []T {
+	items := make([]T, count)
+	for i := 0; i < count; i++ {
+		items[i] = f.Make(withIndex(ts, i, fn)...)
+	}
+	return items
+}
+
+// CreateManyIndexed is like CreateMany but passes each item's index in the batch to fn
+// (see MakeManyIndexed).
+func (f *Factory[T]) CreateManyIndexed(ctx context.Context, count int, fn func(i int, t *T), ts ...Trait[T]) ([]*T, error) {
+	v := f.view()
+	f = &v
+	if !f.canPersist() {
+		panic("factory: CreateManyIndexed called without persist function; use WithPersist")
+	}
+	items, err := f.createEachIndexed(ctx, count, fn, ts...)
+	return f.finishBatch(ctx, items, err)
+}
+
+// withIndex returns ts followed by a trait calling fn with index i.
+func withIndex[T any](ts []Trait[T], i int, fn func(int, *T)) []Trait[T] {
+	out := make([]Trait[T], len(ts), len(ts)+1)
+	copy(out, ts)
+	return append(out, func(t *T) { fn(i, t) })
+}
+
 // CreateMany builds, persists, and runs hooks for count items (like Laravel's count()->create()).
 func (f *Factory[T]) CreateMany(ctx context.Context, count int, ts ...Trait[T]) ([]*T, error) {
-	if f.persist == nil {
+	v := f.view()
+	f = &v
+	if !f.canPersist() {
 		panic("factory: CreateMany called without persist function; use WithPersist")
 	}
+	items, err := f.createEach(ctx, count, ts...)
+	return f.finishBatch(ctx, items, err)
+}
+
+// createEach creates count items one by one, without running batch hooks.
+func (f *Factory[T]) createEach(ctx context.Context, count int, ts ...Trait[T]) ([]*T, error) {
+	return f.createEachIndexed(ctx, count, nil, ts...)
+}
+
+// createEachIndexed is createEach with an optional per-index trait. f must be a
+// snapshot (see view).
+func (f *Factory[T]) createEachIndexed(ctx context.Context, count int, fn func(int, *T), ts ...Trait[T]) ([]*T, error) {
 	items := make([]*T, 0, count)
+	var hookErrs []error
 	for i := 0; i < count; i++ {
-		item, err := f.Create(ctx, ts...)
+		callTraits := ts
+		if fn != nil {
+			callTraits = withIndex(ts, i, fn)
+		}
+		item, err := f.create(ctx, callTraits...)
+		if item != nil {
+			items = append(items, item)
+		}
+		var hookErr *HookError
+		if errors.As(err, &hookErr) {
+			hookErrs = append(hookErrs, err)
+			continue
+		}
 		if err != nil {
 			return items, err
 		}
-		items = append(items, item)
 	}
-	return items, nil
+	return items, errors.Join(hookErrs...)
+}
+
+// finishBatch runs batch hooks unless creation failed outright. Hook errors collected
+// under HookErrorsReturnRecord are returned alongside any batch hook error.
+func (f *Factory[T]) finishBatch(ctx context.Context, items []*T, err error) ([]*T, error) {
+	var hookErr *HookError
+	if err != nil && !errors.As(err, &hookErr) {
+		return items, err
+	}
+	return items, errors.Join(err, f.runBatchHooks(ctx, items))
+}
+
+// runBatchHooks runs AfterCreateBatch hooks once for a finished batch.
+func (f *Factory[T]) runBatchHooks(ctx context.Context, items []*T) error {
+	ctx = f.withBase(ctx)
+	for _, h := range f.afterBatch {
+		if err := h(ctx, items); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Must* Variants (panic on error instead of returning error)
 
 // MustCreate builds, persists, and returns *T. Panics on error (useful in tests).
 func (f *Factory[T]) MustCreate(ctx context.Context, ts ...Trait[T]) *T {
+	ctx, rec := withBuiltRecorder(ctx)
 	item, err := f.Create(ctx, ts...)
 	if err != nil {
-		panic("factory: MustCreate failed: " + err.Error())
+		panic(f.failure("MustCreate", err, rec, len(ts)))
 	}
 	return item
 }
 
 // MustCreateMany builds, persists, and returns []*T. Panics on error (useful in tests).
 func (f *Factory[T]) MustCreateMany(ctx context.Context, count int, ts ...Trait[T]) []*T {
+	ctx, rec := withBuiltRecorder(ctx)
 	items, err := f.CreateMany(ctx, count, ts...)
 	if err != nil {
-		panic("factory: MustCreateMany failed: " + err.Error())
+		panic(f.failure("MustCreateMany", err, rec, len(ts)))
+	}
+	return items
+}
+
+// MustCreateManyIndexed is like CreateManyIndexed but panics on error (useful in tests).
+func (f *Factory[T]) MustCreateManyIndexed(ctx context.Context, count int, fn func(i int, t *T), ts ...Trait[T]) []*T {
+	ctx, rec := withBuiltRecorder(ctx)
+	items, err := f.CreateManyIndexed(ctx, count, fn, ts...)
+	if err != nil {
+		panic(f.failure("MustCreateManyIndexed", err, rec, len(ts)+1))
 	}
 	return items
 }
@@ -370,40 +945,112 @@ func (f *Factory[T]) MustRawManyJSON(count int, ts ...Trait[T]) []byte {
 
 // Make builds count items without persisting.
 func (cf *CountedFactory[T]) Make(ts ...Trait[T]) []T {
+	if cf.start > 0 {
+		return cf.window().Make(ts...)
+	}
+	ts = cf.withPercents(ts)
+	if cf.distribution != nil {
+		return cf.distributedMake(ts...)
+	}
 	return cf.factory.MakeMany(cf.count, ts...)
 }
 
 // Create builds, persists, and runs hooks for count items.
 func (cf *CountedFactory[T]) Create(ctx context.Context, ts ...Trait[T]) ([]*T, error) {
+	if cf.start > 0 {
+		return cf.window().Create(ctx, ts...)
+	}
+	ts = cf.withPercents(ts)
+	if cf.distribution != nil {
+		return cf.distributedCreate(ctx, ts...)
+	}
 	return cf.factory.CreateMany(ctx, cf.count, ts...)
 }
 
 // Raw builds count items without persisting, with rawDefaults applied.
 func (cf *CountedFactory[T]) Raw(ts ...Trait[T]) []T {
+	if cf.start > 0 {
+		return cf.window().Raw(ts...)
+	}
+	ts = cf.withPercents(ts)
+	if cf.distribution != nil {
+		return cf.distributedRaw(ts...)
+	}
 	return cf.factory.RawMany(cf.count, ts...)
 }
 
 // RawJSON builds count items and returns JSON array.
 func (cf *CountedFactory[T]) RawJSON(ts ...Trait[T]) ([]byte, error) {
+	if cf.start > 0 {
+		return cf.window().RawJSON(ts...)
+	}
+	ts = cf.withPercents(ts)
+	if cf.distribution != nil {
+		return json.Marshal(cf.distributedRaw(ts...))
+	}
 	return cf.factory.RawManyJSON(cf.count, ts...)
 }
 
 // State applies a named state to the underlying factory and returns a new CountedFactory.
 func (cf *CountedFactory[T]) State(name string) *CountedFactory[T] {
+	return cf.with(cf.factory.State(name))
+}
+
+// TryState is like State but returns ErrUnknownState instead of panicking.
+func (cf *CountedFactory[T]) TryState(name string) (*CountedFactory[T], error) {
+	f, err := cf.factory.TryState(name)
+	if err != nil {
+		return nil, err
+	}
+	return cf.with(f), nil
+}
+
+// Factory returns the underlying factory.
+func (cf *CountedFactory[T]) Factory() *Factory[T] {
+	return cf.factory
+}
+
+// with returns a CountedFactory over f with the same count and distribution.
+func (cf *CountedFactory[T]) with(f *Factory[T]) *CountedFactory[T] {
 	return &CountedFactory[T]{
-		factory: cf.factory.State(name),
-		count:   cf.count,
+		factory:      f,
+		count:        cf.count,
+		distribution: cf.distribution,
+		start:        cf.start,
+		percents:     cf.percents,
 	}
 }
 
 // MustCreate builds, persists, and returns []*T. Panics on error (useful in tests).
 func (cf *CountedFactory[T]) MustCreate(ctx context.Context, ts ...Trait[T]) []*T {
+	if cf.start > 0 {
+		return cf.window().MustCreate(ctx, ts...)
+	}
+	ts = cf.withPercents(ts)
+	if cf.distribution != nil {
+		ctx, rec := withBuiltRecorder(ctx)
+		items, err := cf.distributedCreate(ctx, ts...)
+		if err != nil {
+			panic(cf.factory.failure("MustCreateMany", err, rec, len(ts)))
+		}
+		return items
+	}
 	return cf.factory.MustCreateMany(ctx, cf.count, ts...)
 }
 
 // MustRawJSON builds count items and returns JSON array. Panics on error (useful in tests).
 func (cf *CountedFactory[T]) MustRawJSON(ts ...Trait[T]) []byte {
-	return cf.factory.MustRawManyJSON(cf.count, ts...)
+	if cf.start > 0 {
+		return cf.window().MustRawJSON(ts...)
+	}
+	if cf.distribution != nil {
+		data, err := cf.RawJSON(ts...)
+		if err != nil {
+			panic("factory: MustRawManyJSON failed: " + err.Error())
+		}
+		return data
+	}
+	return cf.factory.MustRawManyJSON(cf.count, cf.withPercents(ts)...)
 }
 
 // Relationship Helpers
@@ -413,18 +1060,16 @@ func (cf *CountedFactory[T]) MustRawJSON(ts ...Trait[T]) []byte {
 // Example: For(postFactory, userFactory, func(p *Post, u *User) { p.AuthorID = u.ID })
 func For[T any, R any](f *Factory[T], relatedFactory *Factory[R], linkFn func(*T, *R)) *Factory[T] {
 	// Create a copy of the factory with an added trait
-	copy := *f
-	copy.traits = append([]Trait[T]{}, f.traits...)
+	copy := f.shallow()
 
 	// Add a trait that will create the related model when Make is called
 	// Note: This only works for Make/Raw, not Create (which needs context)
-	copy.defaults = append([]Trait[T]{}, f.defaults...)
-	copy.defaults = append(copy.defaults, func(t *T) {
+	copy.defaults = grow(copy.defaults, func(_ int64, t *T) {
 		related := relatedFactory.Make()
 		linkFn(t, &related)
 	})
 
-	return &copy
+	return copy
 }
 
 // ForModel sets up a belongs-to relationship using an existing model instance.
@@ -432,13 +1077,12 @@ func For[T any, R any](f *Factory[T], relatedFactory *Factory[R], linkFn func(*T
 // Example: ForModel(postFactory, user, func(p *Post, u *User) { p.AuthorID = u.ID })
 func ForModel[T any, R any](f *Factory[T], related *R, linkFn func(*T, *R)) *Factory[T] {
 	// Create a copy with an added trait
-	copy := *f
-	copy.traits = append([]Trait[T]{}, f.traits...)
-	copy.traits = append(copy.traits, func(t *T) {
+	copy := f.shallow()
+	copy.traits = grow(copy.traits, func(t *T) {
 		linkFn(t, related)
 	})
 
-	return &copy
+	return copy
 }
 
 // Recycle is an alias for ForModel - reuse the same related model across multiple creations.
@@ -447,6 +1091,38 @@ func Recycle[T any, R any](f *Factory[T], related *R, linkFn func(*T, *R)) *Fact
 	return ForModel(f, related, linkFn)
 }
 
+// ForCount is For for a CountedFactory: every item gets its own related model.
+// The count and any Distribute settings are kept.
+// Example: ForCount(postFactory.Count(10), userFactory, linkFn).State("published").MustCreate(ctx)
+func ForCount[T any, R any](cf *CountedFactory[T], relatedFactory *Factory[R], linkFn func(*T, *R)) *CountedFactory[T] {
+	return cf.with(For(cf.factory, relatedFactory, linkFn))
+}
+
+// RecycleCount is Recycle for a CountedFactory: every item links to the same related model.
+// The count and any Distribute settings are kept.
+// Example: RecycleCount(postFactory.Count(10), user, linkFn).State("published").Create(ctx)
+func RecycleCount[T any, R any](cf *CountedFactory[T], related *R, linkFn func(*T, *R)) *CountedFactory[T] {
+	return cf.with(ForModel(cf.factory, related, linkFn))
+}
+
+// HasCount is Has with the child count taken from a CountedFactory.
+// Distribute settings on children are not used.
+// Example: HasCount(userFactory, postFactory.Count(3).State("published"), linkFn)
+func HasCount[T any, R any](parentFactory *Factory[T], children *CountedFactory[R], linkFn func(parent *T, child *R)) *HasFactory[T, R] {
+	return Has(parentFactory, children.factory, children.count, linkFn)
+}
+
+// HasAttachedCount is HasAttached with the related count taken from a CountedFactory.
+// Example: HasAttachedCount(userFactory, roleFactory.Count(2), pivotFactory, linkFn)
+func HasAttachedCount[T any, R any, P any](
+	parentFactory *Factory[T],
+	related *CountedFactory[R],
+	pivotFactory *Factory[P],
+	linkFn func(pivot *P, parent *T, related *R),
+) *HasAttachedFactory[T, R, P] {
+	return HasAttached(parentFactory, related.factory, pivotFactory, related.count, linkFn)
+}
+
 // Has creates a parent model with child models (inverse of For).
 // Creates one parent, then creates 'count' children linked to that parent.
 // Returns a factory that when Create() is called, will create parent + children.
@@ -465,6 +1141,21 @@ func Has[T any, R any](
 	}
 }
 
+// HasFn is Has with the number of children decided per parent by count, which sees
+// the built parent (in Create, the persisted one), for non-uniform cardinality such
+// as 1-10 posts per user. Negative counts create no children.
+// Example: HasFn(userFactory, postFactory, func(u *User) int { return 1 + rand.Intn(10) }, linkFn)
+func HasFn[T any, R any](
+	parentFactory *Factory[T],
+	childFactory *Factory[R],
+	count func(parent *T) int,
+	linkFn func(parent *T, child *R),
+) *HasFactory[T, R] {
+	hf := Has(parentFactory, childFactory, 0, linkFn)
+	hf.countFn = count
+	return hf
+}
+
 // HasAttached creates a parent model with many-to-many relationships through a pivot table.
 // Creates one parent, creates 'count' related models, and creates pivot records for each.
 // Example: HasAttached(userFactory, roleFactory, pivotFactory, 3, linkFn)
@@ -486,10 +1177,13 @@ func HasAttached[T any, R any, P any](
 
 // HasFactory manages has-many relationships.
 type HasFactory[T any, R any] struct {
-	parent *Factory[T]
-	child  *Factory[R]
-	count  int
-	linkFn func(*T, *R)
+	parent    *Factory[T]
+	child     *Factory[R]
+	count     int
+	linkFn    func(*T, *R)
+	inverseFn func(*T, *R) // Lets the parent accumulate its children
+	countFn   func(*T) int // Per-parent child count overriding count (see Fanout and HasFn)
+	autoIDs   bool         // Make assigns in-memory IDs before linking (see WithAutoIDs)
 }
 
 // HasAttachedFactory manages many-to-many relationships with pivot tables.
@@ -499,17 +1193,54 @@ type HasAttachedFactory[T any, R any, P any] struct {
 	pivotFactory *Factory[P]
 	count        int
 	linkFn       func(*P, *T, *R)
+	unique       *pairGuard[T, R] // Skips pivots for pairs already attached
+	autoIDs      bool             // Make assigns in-memory IDs before linking (see WithAutoIDs)
+}
+
+// Inverse sets a function called with the parent and each finished child, so the
+// parent can hold its children (e.g., append to u.Posts) and the returned object
+// graph is navigable in both directions without a database round trip.
+// Example: Has(userFactory, postFactory, 3, linkFn).Inverse(func(u *User, p *Post) { u.Posts = append(u.Posts, *p) })
+func (hf *HasFactory[T, R]) Inverse(fn func(parent *T, child *R)) *HasFactory[T, R] {
+	hf.inverseFn = fn
+	return hf
+}
+
+// WithAutoIDs makes Make (and Raw) give the parent and each child a persisted-style ID
+// before linking, so purely in-memory graphs have non-empty foreign keys. IDs come
+// from each factory's auto-ID counter, which WithFallbackToMake shares, and are only
+// set on a zero "ID" field of string or integer type (see WithFallbackToMake).
+// Create is unaffected; persistence assigns IDs there.
+// Example: user, posts := Has(userFactory, postFactory, 3, linkFn).WithAutoIDs().Make()
+func (hf *HasFactory[T, R]) WithAutoIDs() *HasFactory[T, R] {
+	hf.autoIDs = true
+	return hf
 }
 
 // Make creates parent with children (in-memory only).
 func (hf *HasFactory[T, R]) Make() (T, []R) {
-	parent := hf.parent.Make()
-	children := make([]R, hf.count)
-	for i := 0; i < hf.count; i++ {
-		child := hf.child.Make()
+	return hf.build(hf.parent.Make, hf.child.Make)
+}
+
+// build links count children to a parent using the given builders (Make or Raw).
+func (hf *HasFactory[T, R]) build(makeParent func(...Trait[T]) T, makeChild func(...Trait[R]) R) (T, []R) {
+	parent := makeParent()
+	if hf.autoIDs {
+		hf.parent.assignAutoID(&parent)
+	}
+	count := hf.countFor(&parent)
+	children := make([]R, count)
+	for i := 0; i < count; i++ {
+		child := makeChild()
+		if hf.autoIDs {
+			hf.child.assignAutoID(&child)
+		}
 		if hf.linkFn != nil {
 			hf.linkFn(&parent, &child)
 		}
+		if hf.inverseFn != nil {
+			hf.inverseFn(&parent, &child)
+		}
 		children[i] = child
 	}
 	return parent, children
@@ -525,8 +1256,9 @@ func (hf *HasFactory[T, R]) Create(ctx context.Context) (*T, []*R, error) {
 	}
 
 	// Create children linked to parent
-	children := make([]*R, 0, hf.count)
-	for i := 0; i < hf.count; i++ {
+	count := hf.countFor(parent)
+	children := make([]*R, 0, count)
+	for i := 0; i < count; i++ {
 		var child *R
 		var err error
 
@@ -543,6 +1275,9 @@ func (hf *HasFactory[T, R]) Create(ctx context.Context) (*T, []*R, error) {
 		if err != nil {
 			return parent, children, err
 		}
+		if hf.inverseFn != nil {
+			hf.inverseFn(parent, child)
+		}
 		children = append(children, child)
 	}
 
@@ -560,24 +1295,58 @@ func (hf *HasFactory[T, R]) MustCreate(ctx context.Context) (*T, []*R) {
 
 // HasAttachedFactory Methods
 
+// UniquePairs skips the pivot record for any (parent, related) pair whose key was
+// already claimed in reg under namespace, preventing unique-constraint failures.
+// Example: UniquePairs(reg, "user_roles", func(u *User, r *Role) string { return u.ID + ":" + r.ID })
+func (haf *HasAttachedFactory[T, R, P]) UniquePairs(reg *UniqueRegistry, namespace string, key func(parent *T, related *R) string) *HasAttachedFactory[T, R, P] {
+	haf.unique = &pairGuard[T, R]{reg: reg, namespace: namespace, key: key}
+	return haf
+}
+
+// WithAutoIDs makes Make (and Raw) give the parent, each related model, and each
+// pivot record a persisted-style ID before linking, like HasFactory.WithAutoIDs.
+// Example: user, roles, pivots := HasAttached(userFactory, roleFactory, pivotFactory, 2, linkFn).WithAutoIDs().Make()
+func (haf *HasAttachedFactory[T, R, P]) WithAutoIDs() *HasAttachedFactory[T, R, P] {
+	haf.autoIDs = true
+	return haf
+}
+
 // Make creates parent with related models and pivot records (in-memory only).
 func (haf *HasAttachedFactory[T, R, P]) Make() (T, []R, []P) {
-	parent := haf.parent.Make()
+	return haf.build(haf.parent.Make, haf.related.Make, haf.pivotFactory.Make)
+}
+
+// build attaches count related items to a parent using the given builders (Make or Raw).
+func (haf *HasAttachedFactory[T, R, P]) build(makeParent func(...Trait[T]) T, makeRelated func(...Trait[R]) R, makePivot func(...Trait[P]) P) (T, []R, []P) {
+	parent := makeParent()
+	if haf.autoIDs {
+		haf.parent.assignAutoID(&parent)
+	}
 	related := make([]R, haf.count)
-	pivots := make([]P, haf.count)
+	pivots := make([]P, 0, haf.count)
 
 	for i := 0; i < haf.count; i++ {
-		rel := haf.related.Make()
-		pivot := haf.pivotFactory.Make()
-		haf.linkFn(&pivot, &parent, &rel)
+		rel := makeRelated()
+		if haf.autoIDs {
+			haf.related.assignAutoID(&rel)
+		}
 		related[i] = rel
-		pivots[i] = pivot
+		if !haf.unique.claim(&parent, &rel) {
+			continue
+		}
+		pivot := makePivot()
+		if haf.autoIDs {
+			haf.pivotFactory.assignAutoID(&pivot)
+		}
+		haf.linkFn(&pivot, &parent, &rel)
+		pivots = append(pivots, pivot)
 	}
 
 	return parent, related, pivots
 }
 
-// Create creates and persists parent, related models, and pivot records.
+// Create creates and persists parent, related models, and pivot records. Pivots saved
+// with a *HookError (see HookErrorsReturnRecord) are kept and their pairs stay claimed.
 func (haf *HasAttachedFactory[T, R, P]) Create(ctx context.Context) (*T, []*R, []*P, error) {
 	// Create parent first
 	parent, err := haf.parent.Create(ctx)
@@ -588,26 +1357,33 @@ func (haf *HasAttachedFactory[T, R, P]) Create(ctx context.Context) (*T, []*R, [
 	// Create related models and pivot records
 	relatedModels := make([]*R, 0, haf.count)
 	pivotRecords := make([]*P, 0, haf.count)
+	var hookErrs []error
 
 	for i := 0; i < haf.count; i++ {
 		// Create related model
 		related, err := haf.related.Create(ctx)
 		if err != nil {
-			return parent, relatedModels, pivotRecords, err
+			return parent, relatedModels, pivotRecords, errors.Join(append(hookErrs, err)...)
 		}
 		relatedModels = append(relatedModels, related)
+		if !haf.unique.claim(parent, related) {
+			continue
+		}
 
 		// Create pivot record with link function
 		pivot, err := haf.pivotFactory.Create(ctx, func(p *P) {
 			haf.linkFn(p, parent, related)
 		})
-		if err != nil {
-			return parent, relatedModels, pivotRecords, err
+		if pivot == nil {
+			// Nothing was saved, so the pair can be attached again
+			haf.unique.release(parent, related)
+			return parent, relatedModels, pivotRecords, errors.Join(append(hookErrs, err)...)
 		}
 		pivotRecords = append(pivotRecords, pivot)
+		hookErrs = append(hookErrs, err)
 	}
 
-	return parent, relatedModels, pivotRecords, nil
+	return parent, relatedModels, pivotRecords, errors.Join(hookErrs...)
 }
 
 // MustCreate creates and persists parent, related models, and pivot records. Panics on error.
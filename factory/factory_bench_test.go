@@ -176,6 +176,20 @@ func BenchmarkRawJSON(b *testing.B) {
 	}
 }
 
+func BenchmarkRawJSONPooled(b *testing.B) {
+	f := New(func(seq int64) BenchUser {
+		return BenchUser{
+			Name:  fmt.Sprintf("User %d", seq),
+			Email: fmt.Sprintf("user%d@example.com", seq),
+		}
+	}).WithPool()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = f.RawJSON()
+	}
+}
+
 func BenchmarkCreate(b *testing.B) {
 	f := New(func(seq int64) BenchUser {
 		return BenchUser{
@@ -370,3 +384,16 @@ func BenchmarkClonePerGoroutine(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkCompile(b *testing.B) {
+	next := New(func(seq int64) BenchUser {
+		return BenchUser{Role: "user"}
+	}).WithDefaults(func(u *BenchUser) {
+		u.Name = "User"
+	}).Compile()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = next()
+	}
+}
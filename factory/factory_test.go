@@ -3,7 +3,10 @@ package factory
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -1738,3 +1741,693 @@ func TestFactory_HasAttachedMustCreate(t *testing.T) {
 		t.Fatalf("expected 2 pivots, got %d", len(pivots))
 	}
 }
+
+// Persist override tests
+
+func TestFactory_UsingPersist(t *testing.T) {
+	var shared, spy []string
+	f := New(func(seq int64) User {
+		return User{Name: fmt.Sprintf("User %d", seq)}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		shared = append(shared, u.Name)
+		return u, nil
+	})
+
+	ctx := context.Background()
+	spied := f.UsingPersist(func(ctx context.Context, u *User) (*User, error) {
+		spy = append(spy, u.Name)
+		return u, nil
+	})
+
+	spied.MustCreate(ctx)
+	f.MustCreate(ctx)
+
+	if len(spy) != 1 || spy[0] != "User 1" {
+		t.Fatalf("expected spy to receive 'User 1', got %v", spy)
+	}
+	// Original factory keeps its persist function and shares the sequence
+	if len(shared) != 1 || shared[0] != "User 2" {
+		t.Fatalf("expected original persist to receive 'User 2', got %v", shared)
+	}
+}
+
+func TestFactory_CreateWith(t *testing.T) {
+	calls := 0
+	f := New(func(seq int64) User {
+		return User{Name: fmt.Sprintf("User %d", seq)}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		calls++
+		return u, nil
+	})
+
+	ctx := context.Background()
+	u, err := f.CreateWith(ctx, func(ctx context.Context, u *User) (*User, error) {
+		u.ID = "tx-1"
+		return u, nil
+	}, func(u *User) { u.Email = "override@example.com" })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.ID != "tx-1" {
+		t.Fatalf("expected ID from override persist, got %q", u.ID)
+	}
+	if u.Email != "override@example.com" {
+		t.Fatalf("expected per-call trait to apply, got %q", u.Email)
+	}
+	if calls != 0 {
+		t.Fatalf("expected shared persist not to be called, got %d calls", calls)
+	}
+	if next := f.Make(); next.Name != "User 2" {
+		t.Fatalf("expected sequence to advance on original, got %q", next.Name)
+	}
+}
+
+func TestFactory_AfterCreateDiff(t *testing.T) {
+	var order []string
+	f := New(func(seq int64) User {
+		return User{Name: fmt.Sprintf("User %d", seq)}
+	}).BeforeCreate(func(ctx context.Context, u *User) error {
+		u.Email = "before@example.com"
+		return nil
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		// Simulate database defaults modifying the value in place
+		u.ID = "db-generated"
+		u.Email = "normalized@example.com"
+		return u, nil
+	}).AfterCreate(func(ctx context.Context, u *User) error {
+		order = append(order, "after")
+		return nil
+	}).AfterCreateDiff(func(ctx context.Context, made, saved *User) error {
+		order = append(order, "diff")
+		if made.ID != "" {
+			t.Fatalf("expected made ID to be empty, got %q", made.ID)
+		}
+		if made.Email != "before@example.com" {
+			t.Fatalf("expected made email from BeforeCreate, got %q", made.Email)
+		}
+		if saved.ID != "db-generated" || saved.Email != "normalized@example.com" {
+			t.Fatalf("unexpected saved value: %+v", *saved)
+		}
+		return nil
+	})
+
+	f.MustCreate(context.Background())
+
+	if len(order) != 2 || order[0] != "after" || order[1] != "diff" {
+		t.Fatalf("expected AfterCreate before AfterCreateDiff, got %v", order)
+	}
+}
+
+func TestFactory_AfterCreateDiffError(t *testing.T) {
+	f := New(func(seq int64) User {
+		return User{}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		return u, nil
+	}).AfterCreateDiff(func(ctx context.Context, made, saved *User) error {
+		return fmt.Errorf("diff failed")
+	})
+
+	if _, err := f.Create(context.Background()); err == nil {
+		t.Fatal("expected error from AfterCreateDiff hook")
+	}
+}
+
+// Inverse relationship tests
+
+type Author struct {
+	ID    string
+	Posts []Post
+}
+
+func TestFactory_HasInverse(t *testing.T) {
+	authorFactory := New(func(seq int64) Author {
+		return Author{ID: fmt.Sprintf("author-%d", seq)}
+	}).WithPersist(func(ctx context.Context, a *Author) (*Author, error) {
+		return a, nil
+	})
+
+	postFactory := New(func(seq int64) Post {
+		return Post{Title: fmt.Sprintf("Post %d", seq)}
+	}).WithPersist(func(ctx context.Context, p *Post) (*Post, error) {
+		p.ID = fmt.Sprintf("post-%s", p.Title)
+		return p, nil
+	})
+
+	has := Has(authorFactory, postFactory, 3, func(a *Author, p *Post) {
+		p.AuthorID = a.ID
+	}).Inverse(func(a *Author, p *Post) {
+		a.Posts = append(a.Posts, *p)
+	})
+
+	// In-memory
+	author, posts := has.Make()
+	if len(author.Posts) != 3 {
+		t.Fatalf("expected author to hold 3 posts, got %d", len(author.Posts))
+	}
+	for i, p := range author.Posts {
+		if p.AuthorID != author.ID || p.Title != posts[i].Title {
+			t.Fatalf("post %d: expected linked post, got %+v", i, p)
+		}
+	}
+
+	// Persisted: parent holds the saved children (with IDs)
+	saved, savedPosts, err := has.Create(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Posts) != 3 {
+		t.Fatalf("expected saved author to hold 3 posts, got %d", len(saved.Posts))
+	}
+	for i, p := range saved.Posts {
+		if p.ID == "" || p.ID != savedPosts[i].ID {
+			t.Fatalf("post %d: expected persisted post, got %+v", i, p)
+		}
+	}
+}
+
+// Error-returning variants
+
+func TestFactory_TryState(t *testing.T) {
+	f := New(func(seq int64) User {
+		return User{Name: "Test"}
+	}).DefineState("admin", func(u *User) {
+		u.Email = "admin@example.com"
+	})
+
+	admin, err := f.TryState("admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u := admin.Make(); u.Email != "admin@example.com" {
+		t.Fatalf("expected admin state to apply, got %q", u.Email)
+	}
+
+	_, err = f.TryState("nonexistent")
+	if !errors.Is(err, ErrUnknownState) {
+		t.Fatalf("expected ErrUnknownState, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "nonexistent") {
+		t.Fatalf("expected error to name the state, got %v", err)
+	}
+
+	trait, err := f.TryApply("admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u := f.Make(trait); u.Email != "admin@example.com" {
+		t.Fatalf("expected TryApply trait to apply the state, got %q", u.Email)
+	}
+	if _, err := f.TryApply("admin", "nonexistent"); !errors.Is(err, ErrUnknownState) {
+		t.Fatalf("expected ErrUnknownState from TryApply, got %v", err)
+	}
+
+	counted, err := f.Count(2).TryState("admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if us := counted.Make(); len(us) != 2 || us[1].Email != "admin@example.com" {
+		t.Fatalf("expected 2 admins, got %+v", us)
+	}
+	if _, err := f.Count(2).TryState("nonexistent"); !errors.Is(err, ErrUnknownState) {
+		t.Fatalf("expected ErrUnknownState from CountedFactory.TryState, got %v", err)
+	}
+}
+
+func TestFactory_TryCreate(t *testing.T) {
+	f := New(func(seq int64) User {
+		return User{Name: "Test"}
+	})
+
+	ctx := context.Background()
+	if _, err := f.TryCreate(ctx); !errors.Is(err, ErrNoPersist) {
+		t.Fatalf("expected ErrNoPersist, got %v", err)
+	}
+	if _, err := f.TryCreateMany(ctx, 2); !errors.Is(err, ErrNoPersist) {
+		t.Fatalf("expected ErrNoPersist, got %v", err)
+	}
+
+	f.WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		u.ID = "saved"
+		return u, nil
+	})
+	u, err := f.TryCreate(ctx)
+	if err != nil || u.ID != "saved" {
+		t.Fatalf("expected saved user, got (%v, %v)", u, err)
+	}
+	users, err := f.TryCreateMany(ctx, 2)
+	if err != nil || len(users) != 2 {
+		t.Fatalf("expected 2 saved users, got (%d, %v)", len(users), err)
+	}
+}
+
+func TestFactory_AfterCreateBatch(t *testing.T) {
+	var batches [][]*User
+	perItem := 0
+	f := New(func(seq int64) User {
+		return User{Name: fmt.Sprintf("User %d", seq)}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		return u, nil
+	}).AfterCreate(func(ctx context.Context, u *User) error {
+		perItem++
+		return nil
+	}).AfterCreateBatch(func(ctx context.Context, items []*User) error {
+		if perItem != len(items) {
+			t.Fatalf("expected per-item hooks to run first, got %d of %d", perItem, len(items))
+		}
+		batches = append(batches, items)
+		return nil
+	})
+
+	ctx := context.Background()
+	f.MustCreate(ctx)
+	if len(batches) != 0 {
+		t.Fatal("expected batch hooks not to run for single Create")
+	}
+
+	perItem = 0
+	f.Count(3).MustCreate(ctx)
+	if len(batches) != 1 || len(batches[0]) != 3 {
+		t.Fatalf("expected one batch of 3, got %v", batches)
+	}
+
+	perItem = 0
+	f.DefineState("admin", func(u *User) {})
+	f.Count(4).Distribute(map[string]int{"admin": 1, "": 3}).MustCreate(ctx)
+	if len(batches) != 2 || len(batches[1]) != 4 {
+		t.Fatalf("expected distributed batch of 4 in one call, got %d batches", len(batches))
+	}
+}
+
+func TestFactory_AfterCreateBatchError(t *testing.T) {
+	boom := errors.New("bulk insert failed")
+	f := New(func(seq int64) User {
+		return User{}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		return u, nil
+	}).AfterCreateBatch(func(ctx context.Context, items []*User) error {
+		return boom
+	})
+
+	items, err := f.CreateMany(context.Background(), 2)
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected batch hook error, got %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected created items to be returned, got %d", len(items))
+	}
+}
+
+// Per-call state tests
+
+func TestFactory_Apply(t *testing.T) {
+	f := New(func(seq int64) User {
+		return User{Name: fmt.Sprintf("User %d", seq)}
+	}).DefineState("admin", func(u *User) {
+		u.Name = "Admin"
+	}).DefineState("verified", func(u *User) {
+		u.Email = u.Name + "@verified.com"
+	})
+
+	user := f.Make(f.Apply("admin", "verified"))
+	if user.Name != "Admin" || user.Email != "Admin@verified.com" {
+		t.Fatalf("expected states applied in order, got %+v", user)
+	}
+
+	plain := f.Make()
+	if plain.Name != "User 2" || plain.Email != "" {
+		t.Fatalf("expected factory to be unchanged, got %+v", plain)
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil || !strings.Contains(fmt.Sprint(r), "defined: admin, verified") {
+			t.Fatalf("expected unknown state panic, got %v", r)
+		}
+	}()
+	f.Apply("banned")
+}
+
+func TestFactory_WithDefaultsSeq(t *testing.T) {
+	f := New(func(seq int64) User {
+		return User{}
+	}).WithDefaults(func(u *User) {
+		u.Name = "Default"
+	}).WithDefaultsSeq(func(seq int64, u *User) {
+		u.Email = fmt.Sprintf("%s%d@example.com", strings.ToLower(u.Name), seq)
+	}).WithTraits(func(u *User) {
+		u.Name = "Trait"
+	})
+
+	users := f.MakeMany(2)
+	if users[0].Email != "default1@example.com" || users[1].Email != "default2@example.com" {
+		t.Fatalf("expected sequence-aware defaults after plain defaults, got %+v", users)
+	}
+	if users[0].Name != "Trait" {
+		t.Fatalf("expected traits to run after defaults, got %s", users[0].Name)
+	}
+
+	raw := f.Raw()
+	if raw.Email != "default3@example.com" {
+		t.Fatalf("expected Raw to apply sequence-aware defaults, got %s", raw.Email)
+	}
+}
+
+// Hook error policy tests
+
+func TestFactory_HookErrorPolicyDefault(t *testing.T) {
+	boom := errors.New("notify failed")
+	f := New(func(seq int64) User {
+		return User{}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		u.ID = "saved"
+		return u, nil
+	}).AfterCreate(func(ctx context.Context, u *User) error {
+		return boom
+	})
+
+	user, err := f.Create(context.Background())
+	if user != nil || !errors.Is(err, boom) {
+		t.Fatalf("expected nil record and hook error, got %v, %v", user, err)
+	}
+}
+
+func TestFactory_HookErrorsReturnRecord(t *testing.T) {
+	first := errors.New("notify failed")
+	second := errors.New("audit failed")
+	ran := 0
+	f := New(func(seq int64) User {
+		return User{Name: fmt.Sprintf("User %d", seq)}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		u.ID = "saved"
+		return u, nil
+	}).AfterCreate(func(ctx context.Context, u *User) error {
+		ran++
+		return first
+	}).AfterCreateDiff(func(ctx context.Context, made, saved *User) error {
+		ran++
+		return second
+	}).WithHookErrorPolicy(HookErrorsReturnRecord)
+
+	user, err := f.Create(context.Background())
+	if user == nil || user.ID != "saved" {
+		t.Fatalf("expected persisted record, got %v", user)
+	}
+	var hookErr *HookError
+	if !errors.As(err, &hookErr) || !errors.Is(err, first) || !errors.Is(err, second) {
+		t.Fatalf("expected HookError wrapping both failures, got %v", err)
+	}
+	if ran != 2 {
+		t.Fatalf("expected every hook to run, got %d", ran)
+	}
+
+	batches := 0
+	users, err := f.AfterCreateBatch(func(ctx context.Context, items []*User) error {
+		batches++
+		return nil
+	}).CreateMany(context.Background(), 3)
+	if len(users) != 3 || !errors.As(err, &hookErr) {
+		t.Fatalf("expected all 3 records with hook errors, got %d, %v", len(users), err)
+	}
+	if batches != 1 {
+		t.Fatalf("expected batch hooks to still run, got %d", batches)
+	}
+}
+
+func TestFactory_HookErrorsReturnRecordPersistFailure(t *testing.T) {
+	boom := errors.New("insert failed")
+	f := New(func(seq int64) User {
+		return User{}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		return nil, boom
+	}).WithHookErrorPolicy(HookErrorsReturnRecord)
+
+	users, err := f.CreateMany(context.Background(), 3)
+	var hookErr *HookError
+	if len(users) != 0 || !errors.Is(err, boom) || errors.As(err, &hookErr) {
+		t.Fatalf("expected persist error to stop the batch, got %d, %v", len(users), err)
+	}
+}
+
+// CountedFactory relationship tests
+
+func TestFactory_RecycleCountAndForCount(t *testing.T) {
+	postFactory := New(func(seq int64) Post {
+		return Post{ID: fmt.Sprintf("post-%d", seq)}
+	}).DefineState("published", func(p *Post) {
+		p.Title = "Published"
+	}).WithPersist(func(ctx context.Context, p *Post) (*Post, error) {
+		return p, nil
+	})
+	userFactory := New(func(seq int64) User {
+		return User{ID: fmt.Sprintf("user-%d", seq)}
+	})
+	link := func(p *Post, u *User) { p.AuthorID = u.ID }
+
+	author := &User{ID: "author-1"}
+	posts := RecycleCount(postFactory.Count(3), author, link).State("published").MustCreate(context.Background())
+	if len(posts) != 3 {
+		t.Fatalf("expected 3 posts, got %d", len(posts))
+	}
+	for _, p := range posts {
+		if p.AuthorID != "author-1" || p.Title != "Published" {
+			t.Fatalf("expected recycled author and state, got %+v", p)
+		}
+	}
+
+	made := ForCount(postFactory.Count(2), userFactory, link).Make()
+	if made[0].AuthorID == made[1].AuthorID {
+		t.Fatalf("expected a new author per post, got %+v", made)
+	}
+
+	distributed := RecycleCount(postFactory.Count(3).Distribute(map[string]int{"published": 1, "": 2}), author, link).Make()
+	published := 0
+	for _, p := range distributed {
+		if p.Title == "Published" {
+			published++
+		}
+	}
+	if published != 1 || distributed[0].AuthorID != "author-1" {
+		t.Fatalf("expected distribution to be kept, got %+v", distributed)
+	}
+}
+
+func TestFactory_HasCount(t *testing.T) {
+	userFactory := New(func(seq int64) User {
+		return User{ID: fmt.Sprintf("user-%d", seq)}
+	})
+	postFactory := New(func(seq int64) Post {
+		return Post{}
+	}).DefineState("published", func(p *Post) {
+		p.Title = "Published"
+	})
+	roleFactory := New(func(seq int64) Role {
+		return Role{ID: fmt.Sprintf("role-%d", seq)}
+	})
+	pivotFactory := New(func(seq int64) UserRole {
+		return UserRole{}
+	})
+
+	user, posts := HasCount(userFactory, postFactory.Count(3).State("published"), func(u *User, p *Post) {
+		p.AuthorID = u.ID
+	}).Make()
+	if len(posts) != 3 || posts[2].AuthorID != user.ID || posts[2].Title != "Published" {
+		t.Fatalf("expected 3 published posts for %s, got %+v", user.ID, posts)
+	}
+
+	_, roles, pivots := HasAttachedCount(userFactory, roleFactory.Count(2), pivotFactory, func(p *UserRole, u *User, r *Role) {
+		p.UserID, p.RoleID = u.ID, r.ID
+	}).Make()
+	if len(roles) != 2 || len(pivots) != 2 {
+		t.Fatalf("expected 2 roles and pivots, got %d, %d", len(roles), len(pivots))
+	}
+}
+
+// Indexed batch tests
+
+func TestFactory_MakeManyIndexed(t *testing.T) {
+	f := New(func(seq int64) UserRole { return UserRole{RoleID: "member"} })
+	f.Make() // global sequence is no longer 1-based for the batch
+
+	roles := f.MakeManyIndexed(3, func(i int, r *UserRole) {
+		if i == 0 {
+			r.RoleID = "owner"
+		}
+		r.UserID = fmt.Sprintf("user-%d", i)
+	}, func(r *UserRole) { r.RoleID = "overridden"; r.Active = true })
+
+	if roles[0].RoleID != "owner" || roles[1].RoleID != "overridden" || roles[2].UserID != "user-2" || !roles[2].Active {
+		t.Fatalf("expected index-based roles after per-call traits, got %+v", roles)
+	}
+}
+
+func TestFactory_CreateManyIndexed(t *testing.T) {
+	batches := 0
+	f := New(func(seq int64) User { return User{} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) { return u, nil }).
+		AfterCreateBatch(func(ctx context.Context, users []*User) error {
+			batches++
+			return nil
+		})
+
+	users := f.MustCreateManyIndexed(context.Background(), 3, func(i int, u *User) {
+		u.ID = fmt.Sprint(i)
+	})
+	if len(users) != 3 || users[0].ID != "0" || users[2].ID != "2" {
+		t.Fatalf("expected batch indexes as IDs, got %+v", users)
+	}
+	if batches != 1 {
+		t.Fatalf("expected batch hooks to run once, got %d", batches)
+	}
+}
+
+// Auto-ID relationship tests
+
+func TestHasFactory_WithAutoIDs(t *testing.T) {
+	users := New(func(seq int64) User { return User{Name: "User"} })
+	posts := New(func(seq int64) Post { return Post{Title: "Post"} })
+	link := func(u *User, p *Post) { p.AuthorID = u.ID }
+
+	user, items := Has(users, posts, 2, link).WithAutoIDs().Make()
+	if user.ID != "1" || items[0].ID != "1" || items[1].ID != "2" {
+		t.Fatalf("expected auto IDs, got user %q and posts %q, %q", user.ID, items[0].ID, items[1].ID)
+	}
+	if items[0].AuthorID != "1" || items[1].AuthorID != "1" {
+		t.Fatal("expected children linked to the parent's auto ID")
+	}
+
+	// IDs keep counting across calls; without WithAutoIDs nothing is assigned
+	user, _ = Has(users, posts, 1, link).WithAutoIDs().Make()
+	if user.ID != "2" {
+		t.Fatalf("expected next auto ID 2, got %q", user.ID)
+	}
+	if user, _ = Has(users, posts, 1, link).Make(); user.ID != "" {
+		t.Fatalf("expected no ID without WithAutoIDs, got %q", user.ID)
+	}
+
+	// Shared with the WithFallbackToMake counter
+	fallback := New(func(seq int64) User { return User{} }).WithFallbackToMake()
+	created := fallback.MustCreate(context.Background())
+	user, _ = Has(fallback, posts, 1, link).WithAutoIDs().Make()
+	if created.ID != "1" || user.ID != "2" {
+		t.Fatalf("expected Make to continue the fallback IDs, got %q and %q", created.ID, user.ID)
+	}
+}
+
+func TestHasAttachedFactory_WithAutoIDs(t *testing.T) {
+	users := New(func(seq int64) User { return User{} })
+	roles := New(func(seq int64) Role { return Role{Name: fmt.Sprintf("role-%d", seq)} })
+	pivots := New(func(seq int64) UserRole { return UserRole{Active: true} })
+
+	user, related, links := HasAttached(users, roles, pivots, 2, func(p *UserRole, u *User, r *Role) {
+		p.UserID, p.RoleID = u.ID, r.ID
+	}).WithAutoIDs().Make()
+
+	if user.ID != "1" || related[1].ID != "2" {
+		t.Fatalf("expected auto IDs, got %q and %q", user.ID, related[1].ID)
+	}
+	if links[0].UserID != "1" || links[0].RoleID != "1" || links[1].RoleID != "2" {
+		t.Fatalf("expected linked pivots, got %+v", links)
+	}
+}
+
+func TestFactory_ConcurrentBuilders(t *testing.T) {
+	f := New(func(seq int64) User { return User{Name: "User"} }).
+		DefineState("admin", func(u *User) { u.Name = "Admin" }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) { return u, nil })
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				f.Make()
+				f.Raw()
+				f.State("admin").MustCreate(ctx)
+				f.Count(2).MustCreate(ctx)
+				if _, err := f.RawJSON(); err != nil {
+					t.Error(err)
+				}
+				f.ExportConfig()
+				f.Explain()
+				f.PersistSteps()
+				f.PIIFields()
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for j := 0; j < 20; j++ {
+			f.When(true, func(u *User) {}).
+				WithTraits(func(u *User) {}).
+				DefineState(fmt.Sprintf("s%d", j), func(u *User) {}).
+				AfterCreate(func(ctx context.Context, u *User) error { return nil }).
+				AddPersist(func(ctx context.Context, u *User) (*User, error) { return u, nil })
+		}
+	}()
+	wg.Wait()
+
+	// 8 goroutines x 20 rounds x (Make + Raw + RawJSON + 2 batch items); State copies count on their own
+	if got := f.CurrentSequence(); got != 8*20*5 {
+		t.Fatalf("expected every build to draw a unique sequence, got %d", got)
+	}
+}
+
+func TestFactory_CreateUnlessExistsConcurrentBuilders(t *testing.T) {
+	f := New(func(seq int64) User { return User{} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) { return u, nil })
+	ctx := context.Background()
+	notFound := func(ctx context.Context, u *User) (*User, error) { return nil, nil }
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			if _, err := f.CreateUnlessExists(ctx, notFound); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			f.BeforeCreate(func(ctx context.Context, u *User) error { return nil })
+		}
+	}()
+	wg.Wait()
+}
+
+func TestHasFn(t *testing.T) {
+	users := New(func(seq int64) User { return User{ID: fmt.Sprintf("u%d", seq)} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) { return u, nil })
+	posts := New(func(seq int64) Post { return Post{} }).
+		WithPersist(func(ctx context.Context, p *Post) (*Post, error) { return p, nil })
+	// u1 gets one post, u2 two, ...; "u0" would get none
+	count := func(u *User) int {
+		var n int
+		fmt.Sscanf(u.ID, "u%d", &n) //nolint:errcheck // test IDs always match
+		return n
+	}
+	hf := HasFn(users, posts, count, func(u *User, p *Post) { p.AuthorID = u.ID })
+
+	for want := 1; want <= 3; want++ {
+		parent, children := hf.MustCreate(context.Background())
+		if len(children) != want {
+			t.Fatalf("expected %d children for %s, got %d", want, parent.ID, len(children))
+		}
+		for _, c := range children {
+			if c.AuthorID != parent.ID {
+				t.Fatalf("expected child linked to %s, got %q", parent.ID, c.AuthorID)
+			}
+		}
+	}
+
+	_, made := HasFn(users, posts, func(*User) int { return -1 }, nil).Make()
+	if len(made) != 0 {
+		t.Fatalf("expected no children for a negative count, got %d", len(made))
+	}
+}
@@ -0,0 +1,99 @@
+// Package factorygorm persists factory models with GORM, replacing the persist closure
+// most projects write for every model. It depends only on the methods it calls, so
+// importing it does not add GORM to modules that do not already use it; *gorm.DB
+// satisfies DB and TxDB as is.
+//
+//	userFactory.WithPersist(factorygorm.Persist[User](db))
+//	postFactory.WithPersistMany(factorygorm.PersistMany[Post](db))
+//
+// Created models are returned hydrated: GORM writes auto-increment IDs, default
+// values, and CreatedAt/UpdatedAt timestamps back into the struct it inserts.
+package factorygorm
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/b3ndoi/factory-go/factory"
+)
+
+// DB is the part of *gorm.DB that Persist and PersistMany use. D is the handle type
+// itself (*gorm.DB), since GORM methods return a new handle.
+type DB[D any] interface {
+	WithContext(ctx context.Context) D
+	Create(value any) D
+	AddError(err error) error // AddError(nil) reports the handle's Error
+}
+
+// TxDB is DB plus GORM's Transaction method, used by WithinTx.
+type TxDB[D any] interface {
+	DB[D]
+	Transaction(fc func(tx D) error, opts ...*sql.TxOptions) error
+}
+
+type txKey struct{}
+
+// WithTx returns a context that routes Persist and PersistMany through tx, e.g., the
+// handle passed to a db.Transaction callback or a test helper's rolled-back transaction.
+func WithTx[D any](ctx context.Context, tx D) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFrom returns the transaction handle stored by WithTx.
+func TxFrom[D any](ctx context.Context) (D, bool) {
+	tx, ok := ctx.Value(txKey{}).(D)
+	return tx, ok
+}
+
+// Persist returns a persist function that inserts each item with db.Create, using the
+// transaction in the Create context when there is one (see WithTx).
+// Example: userFactory.WithPersist(factorygorm.Persist[User](db))
+func Persist[T any, D DB[D]](db D) factory.PersistFn[T] {
+	return func(ctx context.Context, t *T) (*T, error) {
+		if err := create(ctx, db, t); err != nil {
+			return nil, err
+		}
+		return t, nil
+	}
+}
+
+// PersistMany returns a batch persister for CreateManyBatch that inserts all items
+// with a single db.Create call (GORM splits it per its CreateBatchSize setting).
+// Example: postFactory.WithPersistMany(factorygorm.PersistMany[Post](db))
+func PersistMany[T any, D DB[D]](db D) factory.PersistManyFn[T] {
+	return func(ctx context.Context, items []*T) ([]*T, error) {
+		if len(items) == 0 {
+			return items, nil
+		}
+		if err := create(ctx, db, &items); err != nil {
+			return nil, err
+		}
+		return items, nil
+	}
+}
+
+// WithinTx runs fn inside db.Transaction with the transaction stored in ctx, so every
+// Persist factory fn creates through, including Has and HasAttached children, commits
+// or rolls back together. When ctx already carries a transaction, fn joins it.
+// Example:
+//
+//	err := factorygorm.WithinTx(ctx, db, func(ctx context.Context) error {
+//		_, _, err := factory.Has(userFactory, postFactory, 3, linkFn).Create(ctx)
+//		return err
+//	})
+func WithinTx[D TxDB[D]](ctx context.Context, db D, fn func(ctx context.Context) error) error {
+	if _, ok := TxFrom[D](ctx); ok {
+		return fn(ctx)
+	}
+	return db.Transaction(func(tx D) error {
+		return fn(WithTx(ctx, tx))
+	})
+}
+
+// create inserts value through the transaction in ctx, falling back to db.
+func create[D DB[D]](ctx context.Context, db D, value any) error {
+	if tx, ok := TxFrom[D](ctx); ok {
+		db = tx
+	}
+	return db.WithContext(ctx).Create(value).AddError(nil)
+}
@@ -0,0 +1,104 @@
+package factorygorm
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/b3ndoi/factory-go/factory"
+)
+
+type User struct {
+	ID   int
+	Name string
+}
+
+// fakeDB mimics the *gorm.DB methods the adapter uses: Create hydrates IDs, and
+// errors are carried on the returned handle.
+type fakeDB struct {
+	name    string
+	nextID  *int
+	err     error
+	creates *[]string
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{name: "db", nextID: new(int), creates: new([]string)}
+}
+
+func (db *fakeDB) WithContext(context.Context) *fakeDB { return db }
+
+func (db *fakeDB) Create(value any) *fakeDB {
+	if db.err != nil {
+		return db
+	}
+	*db.creates = append(*db.creates, db.name)
+	switch v := value.(type) {
+	case *User:
+		*db.nextID++
+		v.ID = *db.nextID
+	case *[]*User:
+		for _, u := range *v {
+			*db.nextID++
+			u.ID = *db.nextID
+		}
+	}
+	return db
+}
+
+func (db *fakeDB) AddError(err error) error {
+	if db.err == nil {
+		db.err = err
+	}
+	return db.err
+}
+
+func (db *fakeDB) Transaction(fc func(tx *fakeDB) error, _ ...*sql.TxOptions) error {
+	tx := *db
+	tx.name = "tx"
+	return fc(&tx)
+}
+
+func TestPersist(t *testing.T) {
+	db := newFakeDB()
+	users := factory.New(func(seq int64) User { return User{Name: "Ada"} }).
+		WithPersist(Persist[User](db)).
+		WithPersistMany(PersistMany[User](db))
+	ctx := context.Background()
+
+	u := users.MustCreate(ctx)
+	if u.ID != 1 {
+		t.Fatalf("expected the hydrated ID 1, got %d", u.ID)
+	}
+	batch := users.MustCreateManyBatch(ctx, 2)
+	if batch[0].ID != 2 || batch[1].ID != 3 || len(*db.creates) != 2 {
+		t.Fatalf("expected one bulk create hydrating IDs 2 and 3, got %+v after %v", batch, *db.creates)
+	}
+
+	db.err = errors.New("duplicate key")
+	if _, err := users.Create(ctx); err == nil || err.Error() != "duplicate key" {
+		t.Fatalf("expected the handle's error, got %v", err)
+	}
+}
+
+func TestWithinTx(t *testing.T) {
+	db := newFakeDB()
+	users := factory.New(func(seq int64) User { return User{} }).WithPersist(Persist[User](db))
+	ctx := context.Background()
+
+	err := WithinTx(ctx, db, func(ctx context.Context) error {
+		users.MustCreate(ctx)
+		return WithinTx(ctx, db, func(ctx context.Context) error {
+			_, err := users.Create(ctx)
+			return err
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	users.MustCreate(ctx)
+	if got := *db.creates; len(got) != 3 || got[0] != "tx" || got[1] != "tx" || got[2] != "db" {
+		t.Fatalf("expected two creates in the transaction and one outside, got %v", got)
+	}
+}
@@ -0,0 +1,198 @@
+// Package factoryserve serves factory-generated JSON payloads over HTTP, for frontend
+// teams and contract-testing environments that need realistic data without a database.
+//
+//	reg := factoryserve.NewRegistry()
+//	factoryserve.Register(reg, "users", userFactory)
+//	http.Handle("/fixtures/", http.StripPrefix("/fixtures", factoryserve.Handler(reg)))
+//
+// GET /users?count=10&state=admin returns a JSON array of ten users built with Raw.
+package factoryserve
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/b3ndoi/factory-go/factory"
+)
+
+// DefaultMaxCount is the largest count a request may ask for unless WithMaxCount changes it.
+const DefaultMaxCount = 1000
+
+// errBadRequest marks generation errors caused by the request (e.g., an unknown state).
+var errBadRequest = errors.New("bad request")
+
+// entry is a registered factory with its type erased.
+type entry struct {
+	states func() []string
+	raw    func(count int, states []string) (any, error)
+}
+
+// Registry maps URL names to factories. Safe for concurrent use.
+type Registry struct {
+	mu       sync.RWMutex
+	entries  map[string]entry
+	maxCount int
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{entries: make(map[string]entry), maxCount: DefaultMaxCount}
+}
+
+// WithMaxCount sets the largest count a request may ask for.
+func (r *Registry) WithMaxCount(n int) *Registry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.maxCount = n
+	return r
+}
+
+// Register serves f under name (e.g., "users" for GET /users).
+// Panics if name is empty, contains a slash, or is already registered.
+func Register[T any](r *Registry, name string, f *factory.Factory[T]) *Registry {
+	if name == "" || strings.Contains(name, "/") {
+		panic(fmt.Sprintf("factoryserve: invalid name %q", name))
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.entries[name]; ok {
+		panic(fmt.Sprintf("factoryserve: %q is already registered", name))
+	}
+	r.entries[name] = entry{
+		states: f.States,
+		raw: func(count int, states []string) (any, error) {
+			ff := f
+			for _, s := range states {
+				next, err := ff.TryState(s)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %w", errBadRequest, err)
+				}
+				ff = next
+			}
+			return ff.RawMany(count), nil
+		},
+	}
+	return r
+}
+
+// Names returns the registered names, sorted.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.entries))
+	for name := range r.entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *Registry) lookup(name string) (entry, int, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.entries[name]
+	return e, r.maxCount, ok
+}
+
+// Handler returns an http.Handler serving the registry:
+//
+//   - GET /{name}?count=N&state=S returns a JSON array of N items (default 1) built
+//     with Raw. state may repeat or be comma-separated; states apply in order.
+//   - GET / returns each registered name with its defined states.
+//
+// Errors are JSON objects with an "error" field: 404 for unknown names, 400 for a bad
+// count or unknown state, and 405 for methods other than GET and HEAD.
+func Handler(r *Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		name := strings.Trim(req.URL.Path, "/")
+		if name == "" {
+			writeJSON(w, http.StatusOK, r.index())
+			return
+		}
+		e, maxCount, ok := r.lookup(name)
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("no factory registered as %q", name))
+			return
+		}
+
+		q := req.URL.Query()
+		count := 1
+		if s := q.Get("count"); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil || n < 0 || n > maxCount {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("count must be an integer between 0 and %d", maxCount))
+				return
+			}
+			count = n
+		}
+		var states []string
+		for _, v := range q["state"] {
+			for _, s := range strings.Split(v, ",") {
+				if s = strings.TrimSpace(s); s != "" {
+					states = append(states, s)
+				}
+			}
+		}
+
+		items, err := e.raw(count, states)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, errBadRequest) {
+				status = http.StatusBadRequest
+			}
+			writeError(w, status, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, items)
+	})
+}
+
+// index lists each registered name with its defined states.
+func (r *Registry) index() map[string][]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make(map[string][]string, len(r.entries))
+	for name, e := range r.entries {
+		out[name] = e.states()
+	}
+	return out
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	write(w, status, data)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	data, err := json.Marshal(map[string]string{"error": msg})
+	if err != nil {
+		http.Error(w, msg, http.StatusInternalServerError)
+		return
+	}
+	write(w, status, data)
+}
+
+// write sends a JSON body. A failed write means the client went away after the
+// status was sent, so there is no one left to report it to.
+func write(w http.ResponseWriter, status int, data []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(data); err != nil {
+		return
+	}
+}
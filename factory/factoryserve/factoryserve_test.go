@@ -0,0 +1,118 @@
+package factoryserve
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/b3ndoi/factory-go/factory"
+)
+
+type User struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Role  string `json:"role"`
+	Email string `json:"email"`
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	users := factory.New(func(seq int64) User {
+		return User{ID: fmt.Sprint(seq), Name: "User", Role: "member"}
+	}).
+		DefineState("admin", func(u *User) { u.Role = "admin" }).
+		DefineState("verified", func(u *User) { u.Email = "verified@example.com" })
+
+	reg := NewRegistry().WithMaxCount(50)
+	Register(reg, "users", users)
+	srv := httptest.NewServer(Handler(reg))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func get(t *testing.T, url string, v any) int {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode
+}
+
+func TestHandler_ServesPayloads(t *testing.T) {
+	srv := newServer(t)
+
+	var users []User
+	if code := get(t, srv.URL+"/users?count=10&state=admin&state=verified", &users); code != http.StatusOK {
+		t.Fatalf("unexpected status %d", code)
+	}
+	if len(users) != 10 {
+		t.Fatalf("expected 10 users, got %d", len(users))
+	}
+	for _, u := range users {
+		if u.Role != "admin" || u.Email != "verified@example.com" {
+			t.Fatalf("expected states applied, got %+v", u)
+		}
+	}
+
+	// Default count is 1; comma-separated states work too
+	if code := get(t, srv.URL+"/users/?state=admin,verified", &users); code != http.StatusOK || len(users) != 1 || users[0].Role != "admin" {
+		t.Fatalf("unexpected response %d %+v", code, users)
+	}
+
+	var index map[string][]string
+	get(t, srv.URL+"/", &index)
+	if strings.Join(index["users"], ",") != "admin,verified" {
+		t.Fatalf("unexpected index %v", index)
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	srv := newServer(t)
+
+	cases := map[string]int{
+		"/posts":               http.StatusNotFound,
+		"/users?count=abc":     http.StatusBadRequest,
+		"/users?count=51":      http.StatusBadRequest,
+		"/users?state=missing": http.StatusBadRequest,
+	}
+	for path, want := range cases {
+		var body map[string]string
+		if code := get(t, srv.URL+path, &body); code != want || body["error"] == "" {
+			t.Fatalf("%s: expected %d with error, got %d %v", path, want, code, body)
+		}
+	}
+
+	resp, err := http.Post(srv.URL+"/users", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", resp.StatusCode)
+	}
+}
+
+func TestRegister_Panics(t *testing.T) {
+	reg := NewRegistry()
+	Register(reg, "users", factory.New(func(seq int64) User { return User{} }))
+	if strings.Join(reg.Names(), ",") != "users" {
+		t.Fatalf("unexpected names %v", reg.Names())
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for duplicate name")
+		}
+	}()
+	Register(reg, "users", factory.New(func(seq int64) User { return User{} }))
+}
@@ -0,0 +1,173 @@
+// Package factorysql persists factory models with database/sql (or sqlx, whose DB and
+// Tx satisfy factory.DBTX) by building INSERT statements from `db` struct tags, so
+// most models need no hand-written persist closure.
+//
+//	userFactory.WithPersist(factorysql.Insert[User](db, "users", factorysql.Postgres()))
+//
+// Column names follow sqlx: the `db` tag, or the lowercased field name when there is
+// none; `db:"-"` skips a field, and untagged embedded structs are flattened. Table and
+// column names are double-quoted (backticks with MySQL). Inserts run in the
+// transaction stored in the Create context (see factory.WithTx).
+package factorysql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/b3ndoi/factory-go/factory"
+)
+
+// Option configures Insert.
+type Option func(*config)
+
+type config struct {
+	dollar    bool   // $1 placeholders instead of ?
+	backticks bool   // Quote identifiers with backticks instead of double quotes
+	returning bool   // Use RETURNING instead of LastInsertId
+	idColumn  string // Column the database assigns
+}
+
+// Postgres uses $1, $2, ... placeholders and reads the ID back with RETURNING.
+func Postgres() Option {
+	return func(c *config) {
+		c.dollar = true
+		c.returning = true
+	}
+}
+
+// MySQL quotes table and column names with backticks.
+func MySQL() Option {
+	return func(c *config) { c.backticks = true }
+}
+
+// IDColumn sets the database-assigned column (default "id"). It is left out of the
+// INSERT while zero and filled in afterwards, via RETURNING or, for integer columns,
+// LastInsertId.
+func IDColumn(column string) Option {
+	return func(c *config) { c.idColumn = column }
+}
+
+// column is one mapped struct field.
+type column struct {
+	name  string
+	index []int // Field index path for reflect.Value.FieldByIndex
+}
+
+// Insert returns a persist function that inserts each item into table and returns it
+// with its database-assigned ID set. Panics if T is not a struct (programming error).
+// Example: userFactory.WithPersist(factorysql.Insert[User](db, "users"))
+func Insert[T any](db factory.DBTX, table string, opts ...Option) factory.PersistFn[T] {
+	cfg := config{idColumn: "id"}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("factorysql: Insert needs a struct type, got %s", typ))
+	}
+	cols := columns(typ, nil)
+
+	return factory.SQLPersist(db, func(ctx context.Context, q factory.DBTX, t *T) (*T, error) {
+		v := reflect.ValueOf(t).Elem()
+		var id reflect.Value
+		names := make([]string, 0, len(cols))
+		args := make([]any, 0, len(cols))
+		for _, col := range cols {
+			field := v.FieldByIndex(col.index)
+			if col.name == cfg.idColumn {
+				id = field
+				if field.IsZero() {
+					continue // Let the database assign it
+				}
+			}
+			names = append(names, col.name)
+			args = append(args, field.Interface())
+		}
+
+		query := insertQuery(table, names, cfg)
+		assign := id.IsValid() && id.IsZero()
+		if assign && cfg.returning {
+			query += " RETURNING " + cfg.quote(cfg.idColumn)
+			if err := q.QueryRowContext(ctx, query, args...).Scan(id.Addr().Interface()); err != nil {
+				return nil, err
+			}
+			return t, nil
+		}
+
+		res, err := q.ExecContext(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		if assign && (id.CanInt() || id.CanUint()) {
+			n, err := res.LastInsertId()
+			if err != nil {
+				return nil, err
+			}
+			if id.CanInt() {
+				id.SetInt(n)
+			} else {
+				id.SetUint(uint64(n))
+			}
+		}
+		return t, nil
+	})
+}
+
+// insertQuery builds the INSERT statement for the given columns.
+func insertQuery(table string, names []string, cfg config) string {
+	parts := strings.Split(table, ".")
+	for i, part := range parts {
+		parts[i] = cfg.quote(part)
+	}
+	table = strings.Join(parts, ".")
+	if len(names) == 0 {
+		return "INSERT INTO " + table + " DEFAULT VALUES"
+	}
+	quoted := make([]string, len(names))
+	marks := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = cfg.quote(name)
+		marks[i] = "?"
+		if cfg.dollar {
+			marks[i] = fmt.Sprintf("$%d", i+1)
+		}
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(quoted, ", "), strings.Join(marks, ", "))
+}
+
+// quote quotes a SQL identifier, doubling any quote characters inside it.
+func (c config) quote(name string) string {
+	q := `"`
+	if c.backticks {
+		q = "`"
+	}
+	return q + strings.ReplaceAll(name, q, q+q) + q
+}
+
+// columns maps typ's exported fields to column names, flattening untagged embedded structs.
+func columns(typ reflect.Type, parent []int) []column {
+	var out []column
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		index := append(append([]int{}, parent...), i)
+		tag, tagged := f.Tag.Lookup("db")
+		if tag == "-" {
+			continue
+		}
+		if f.Anonymous && !tagged && f.Type.Kind() == reflect.Struct {
+			out = append(out, columns(f.Type, index)...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		out = append(out, column{name: name, index: index})
+	}
+	return out
+}
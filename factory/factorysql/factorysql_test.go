@@ -0,0 +1,144 @@
+package factorysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/b3ndoi/factory-go/factory"
+)
+
+// fakeDriver records statements and answers every query with id 42.
+type fakeDriver struct {
+	queries []string
+	args    [][]driver.Value
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{c.d, query}, nil }
+func (c fakeConn) Close() error                              { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)                 { return nil, io.EOF }
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.queries = append(s.d.queries, s.query)
+	s.d.args = append(s.d.args, args)
+	return driver.RowsAffected(1), nil
+}
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.queries = append(s.d.queries, s.query)
+	s.d.args = append(s.d.args, args)
+	return &idRows{}, nil
+}
+
+type idRows struct{ done bool }
+
+func (r *idRows) Columns() []string { return []string{"id"} }
+func (r *idRows) Close() error      { return nil }
+func (r *idRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = int64(42)
+	return nil
+}
+
+type connector struct{ d *fakeDriver }
+
+func (c connector) Connect(context.Context) (driver.Conn, error) { return c.d.Open("") }
+func (c connector) Driver() driver.Driver                        { return c.d }
+
+func openFake(t *testing.T) (*sql.DB, *fakeDriver) {
+	d := &fakeDriver{}
+	db := sql.OpenDB(connector{d})
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	return db, d
+}
+
+type Timestamps struct {
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type User struct {
+	ID    int64  `db:"id"`
+	Name  string `db:"name"`
+	Email string
+	Notes string `db:"-"`
+	Timestamps
+}
+
+func TestInsert_Postgres(t *testing.T) {
+	db, d := openFake(t)
+	users := factory.New(func(seq int64) User { return User{Name: "Ada", Email: "ada@example.com"} }).
+		WithPersist(Insert[User](db, "users", Postgres()))
+
+	u := users.MustCreate(context.Background())
+	want := `INSERT INTO "users" ("name", "email", "created_at") VALUES ($1, $2, $3) RETURNING "id"`
+	if len(d.queries) != 1 || d.queries[0] != want {
+		t.Fatalf("expected %q, got %v", want, d.queries)
+	}
+	if u.ID != 42 || len(d.args[0]) != 3 || d.args[0][0] != "Ada" {
+		t.Fatalf("expected the returned id and mapped args, got %+v and %v", u, d.args[0])
+	}
+}
+
+func TestInsert_ExplicitID(t *testing.T) {
+	db, d := openFake(t)
+	users := factory.New(func(seq int64) User { return User{ID: 7, Name: "Ada"} }).
+		WithPersist(Insert[User](db, "users"))
+
+	u := users.MustCreate(context.Background())
+	want := `INSERT INTO "users" ("id", "name", "email", "created_at") VALUES (?, ?, ?, ?)`
+	if d.queries[0] != want || u.ID != 7 {
+		t.Fatalf("expected the explicit id to be inserted, got %q and %+v", d.queries[0], u)
+	}
+}
+
+type Order struct {
+	ID    int64  `db:"id"`
+	Order int    `db:"order"`
+	Kind  string `db:"Kind"`
+}
+
+func TestInsert_QuotesIdentifiers(t *testing.T) {
+	db, d := openFake(t)
+	orders := factory.New(func(seq int64) Order { return Order{ID: 1, Order: 2, Kind: "gift"} })
+
+	orders.WithPersist(Insert[Order](db, "shop.order")).MustCreate(context.Background())
+	want := `INSERT INTO "shop"."order" ("id", "order", "Kind") VALUES (?, ?, ?)`
+	if d.queries[0] != want {
+		t.Fatalf("expected %q, got %q", want, d.queries[0])
+	}
+
+	orders.WithPersist(Insert[Order](db, "order", MySQL())).MustCreate(context.Background())
+	want = "INSERT INTO `order` (`id`, `order`, `Kind`) VALUES (?, ?, ?)"
+	if d.queries[1] != want {
+		t.Fatalf("expected %q, got %q", want, d.queries[1])
+	}
+}
+
+func TestInsert_NotStructPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	Insert[int](nil, "numbers")
+}
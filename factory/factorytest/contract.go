@@ -0,0 +1,101 @@
+package factorytest
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/b3ndoi/factory-go/factory"
+	"github.com/google/go-cmp/cmp"
+)
+
+// UpdateEnv is the environment variable that makes Contract.Check rewrite the baseline
+// instead of comparing against it: FACTORY_UPDATE_CONTRACT=1 go test ./...
+const UpdateEnv = "FACTORY_UPDATE_CONTRACT"
+
+// Contract renders the Raw output of registered factories to canonical JSON, so
+// accidental changes to default data that tests implicitly depend on show up in review.
+// Factories must draw randomness from a seeded source (gen with a seeded *rand.Rand,
+// or Group.WithSeed) for the output to be stable.
+type Contract struct {
+	renders map[string]func() any
+}
+
+// NewContract creates an empty contract.
+func NewContract() *Contract {
+	return &Contract{renders: make(map[string]func() any)}
+}
+
+// Register adds count Raw items of f under name, plus count items of every defined
+// state under "name/state". Each render uses a Clone so sequences start at 1.
+// Panics if name is already registered.
+func Register[T any](c *Contract, name string, f *factory.Factory[T], count int) {
+	add := func(key string, build func() *factory.Factory[T]) {
+		if _, exists := c.renders[key]; exists {
+			panic("factorytest: contract entry " + key + " already registered")
+		}
+		c.renders[key] = func() any { return build().RawMany(count) }
+	}
+
+	add(name, f.Clone)
+	for _, state := range f.States() {
+		state := state
+		add(name+"/"+state, func() *factory.Factory[T] { return f.Clone().State(state) })
+	}
+}
+
+// Render returns the canonical JSON for every entry, sorted by name. Entries are also
+// rendered in name order, so factories sharing a seeded source draw the same values.
+func (c *Contract) Render() ([]byte, error) {
+	names := make([]string, 0, len(c.renders))
+	for name := range c.renders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make(map[string]any, len(c.renders))
+	for _, name := range names {
+		out[name] = c.renders[name]()
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Check compares Render against the baseline at path and fails t with a diff when they
+// differ. With UpdateEnv set, it writes the baseline (creating directories) instead.
+// Example: contract.Check(t, "testdata/factories.contract.json")
+func (c *Contract) Check(t testing.TB, path string) {
+	t.Helper()
+	got, err := c.Render()
+	if err != nil {
+		t.Fatalf("factorytest: rendering contract: %v", err)
+	}
+
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatalf("factorytest: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o600); err != nil {
+			t.Fatalf("factorytest: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path) //nolint:gosec // path is provided by the test
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("factorytest: no contract baseline at %s; run with %s=1 to create it", path, UpdateEnv)
+	}
+	if err != nil {
+		t.Fatalf("factorytest: %v", err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("factory output changed (-baseline +current); run with %s=1 to accept:\n%s", UpdateEnv, diff)
+	}
+}
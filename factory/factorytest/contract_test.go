@@ -0,0 +1,104 @@
+package factorytest
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/b3ndoi/factory-go/factory"
+)
+
+func newAuthorFactory(domain string) *factory.Factory[Author] {
+	return factory.New(func(seq int64) Author {
+		return Author{ID: fmt.Sprintf("author-%d", seq), Email: fmt.Sprintf("author%d@%s", seq, domain)}
+	}).DefineState("staff", func(a *Author) {
+		a.Email = "staff@" + domain
+	})
+}
+
+func TestContract_Render(t *testing.T) {
+	f := newAuthorFactory("example.com")
+	f.Make() // Earlier use must not shift the snapshot
+
+	c := NewContract()
+	Register(c, "authors", f, 2)
+
+	data, err := c.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	for _, want := range []string{`"authors":`, `"authors/staff":`, `"author1@example.com"`, `"staff@example.com"`} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %s in contract, got %s", want, out)
+		}
+	}
+
+	again, _ := c.Render()
+	if string(again) != out {
+		t.Fatal("expected rendering to be stable")
+	}
+}
+
+func TestContract_RenderSharedSeed(t *testing.T) {
+	render := func() string {
+		r := rand.New(rand.NewSource(1))
+		c := NewContract()
+		for _, name := range []string{"a", "b", "c", "d"} {
+			Register(c, name, factory.New(func(seq int64) Author {
+				return Author{ID: fmt.Sprint(r.Intn(1000))}
+			}), 2)
+		}
+		data, err := c.Render()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	want := render()
+	for i := 0; i < 20; i++ {
+		if got := render(); got != want {
+			t.Fatalf("expected the same render for the same seed, got %s and %s", want, got)
+		}
+	}
+}
+
+func TestContract_Check(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "contract.json")
+
+	c := NewContract()
+	Register(c, "authors", newAuthorFactory("example.com"), 1)
+
+	t.Setenv(UpdateEnv, "1")
+	c.Check(t, path)
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected baseline to be written, got %v", err)
+	}
+
+	t.Setenv(UpdateEnv, "")
+	c.Check(t, path)
+
+	changed := NewContract()
+	Register(changed, "authors", newAuthorFactory("example.org"), 1)
+	rec := &recorder{}
+	changed.Check(rec, path)
+	if len(rec.failures) != 1 || !strings.Contains(rec.failures[0], "example.org") {
+		t.Fatalf("expected a diff mentioning the change, got %v", rec.failures)
+	}
+}
+
+func TestContract_DuplicateNamePanics(t *testing.T) {
+	c := NewContract()
+	Register(c, "authors", newAuthorFactory("example.com"), 1)
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic for duplicate name")
+		}
+	}()
+	Register(c, "authors", newAuthorFactory("example.com"), 1)
+}
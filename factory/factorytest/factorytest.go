@@ -0,0 +1,64 @@
+// Package factorytest provides test assertions for entities built by factories.
+package factorytest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// DefaultIgnored lists fields that AssertEqualIgnoring and DiffIgnoring always skip,
+// because persistence usually generates them.
+var DefaultIgnored = []string{"ID", "CreatedAt", "UpdatedAt", "DeletedAt"}
+
+// AssertEqualIgnoring fails t with a readable diff if want and got differ, ignoring
+// DefaultIgnored fields and ignoreFields. A plain name ("Email") matches that field at
+// any depth; a dotted path ("Author.Email") matches only that path.
+// Example: factorytest.AssertEqualIgnoring(t, expected, *created, "Password")
+func AssertEqualIgnoring[T any](t testing.TB, want, got T, ignoreFields ...string) {
+	t.Helper()
+	if diff := DiffIgnoring(want, got, ignoreFields...); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// DiffIgnoring returns a go-cmp diff of want and got ignoring the same fields as
+// AssertEqualIgnoring, or "" if they are equal. Unexported fields are compared.
+func DiffIgnoring[T any](want, got T, ignoreFields ...string) string {
+	ignored := make(map[string]bool, len(DefaultIgnored)+len(ignoreFields))
+	for _, name := range DefaultIgnored {
+		ignored[name] = true
+	}
+	for _, name := range ignoreFields {
+		ignored[name] = true
+	}
+
+	return cmp.Diff(want, got,
+		cmp.Exporter(func(reflect.Type) bool { return true }),
+		cmp.FilterPath(func(p cmp.Path) bool {
+			sf, ok := p.Last().(cmp.StructField)
+			if !ok {
+				return false
+			}
+			return ignored[sf.Name()] || ignored[fieldPath(p)]
+		}, cmp.Ignore()),
+	)
+}
+
+// fieldPath returns the dotted struct field path (e.g., "Author.Email"),
+// skipping pointer, slice, and map steps.
+func fieldPath(p cmp.Path) string {
+	path := ""
+	for _, step := range p {
+		sf, ok := step.(cmp.StructField)
+		if !ok {
+			continue
+		}
+		if path != "" {
+			path += "."
+		}
+		path += sf.Name()
+	}
+	return path
+}
@@ -0,0 +1,78 @@
+package factorytest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/b3ndoi/factory-go/factory"
+)
+
+type Author struct {
+	ID    string
+	Email string
+}
+
+type Post struct {
+	ID        string
+	Title     string
+	Author    *Author
+	CreatedAt time.Time
+	draft     bool
+}
+
+// recorder captures failures instead of failing the real test.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertEqualIgnoring(t *testing.T) {
+	f := factory.New(func(seq int64) Post {
+		return Post{
+			ID:        fmt.Sprintf("post-%d", seq),
+			Title:     "Hello",
+			Author:    &Author{ID: fmt.Sprintf("author-%d", seq), Email: "a@example.com"},
+			CreatedAt: time.Unix(seq, 0),
+		}
+	})
+
+	want := f.Make()
+	got := f.Make()
+
+	// IDs and timestamps differ but are ignored by default, at any depth
+	AssertEqualIgnoring(t, want, got)
+
+	got.Author.Email = "b@example.com"
+	AssertEqualIgnoring(t, want, got, "Author.Email")
+	AssertEqualIgnoring(t, want, got, "Email")
+
+	rec := &recorder{TB: t}
+	AssertEqualIgnoring(rec, want, got)
+	if len(rec.failures) != 1 || !strings.Contains(rec.failures[0], "b@example.com") {
+		t.Fatalf("expected a diff mentioning the changed email, got %v", rec.failures)
+	}
+}
+
+func TestDiffIgnoring(t *testing.T) {
+	want := Post{Title: "a", draft: true}
+	got := Post{Title: "a"}
+
+	if diff := DiffIgnoring(want, got); diff == "" {
+		t.Fatal("expected unexported fields to be compared")
+	}
+	if diff := DiffIgnoring(want, got, "draft"); diff != "" {
+		t.Fatalf("expected no diff, got:\n%s", diff)
+	}
+	// Dotted paths only match that exact path
+	if diff := DiffIgnoring(Post{Title: "a"}, Post{Title: "b"}, "Author.Title"); diff == "" {
+		t.Fatal("expected dotted path not to match a top-level field")
+	}
+}
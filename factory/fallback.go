@@ -0,0 +1,72 @@
+package factory
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+)
+
+// WithFallbackToMake lets Create work without a persist function: items are built,
+// given an auto-incremented ID, and returned with hooks run as usual. This allows the
+// same suite to run in in-memory and DB-backed modes. WithPersist and WithShardRouter
+// take precedence when set.
+//
+// The ID is assigned only when T is a struct with a zero "ID" field of string or
+// integer type; string IDs are decimal ("1", "2", ...).
+// Example: userFactory.WithFallbackToMake().MustCreate(ctx) // ID "1", nothing saved
+func (f *Factory[T]) WithFallbackToMake() *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.fallbackIDs = new(int64)
+	return f
+}
+
+// canPersist reports whether Create has somewhere to send items.
+func (f *Factory[T]) canPersist() bool {
+	return f.persist != nil || f.router != nil || f.fallbackIDs != nil
+}
+
+// fallbackPersist is the in-memory persist function used by WithFallbackToMake.
+func (f *Factory[T]) fallbackPersist(_ context.Context, t *T) (*T, error) {
+	assignID(t, atomic.AddInt64(f.fallbackIDs, 1))
+	return t, nil
+}
+
+// assignAutoID gives t a persisted-style ID without persisting it, drawing from the
+// WithFallbackToMake counter when set so Create and Make never reuse an ID.
+func (f *Factory[T]) assignAutoID(t *T) {
+	counter := f.fallbackIDs
+	if counter == nil {
+		counter = f.autoSeq
+	}
+	assignID(t, atomic.AddInt64(counter, 1))
+}
+
+// cloneCounter returns a fresh counter for a Clone, or nil if c is nil.
+func cloneCounter(c *int64) *int64 {
+	if c == nil {
+		return nil
+	}
+	return new(int64)
+}
+
+// assignID sets t's zero ID field (string or integer) to id.
+func assignID[T any](t *T, id int64) {
+	v := reflect.ValueOf(t).Elem()
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	field := v.FieldByName("ID")
+	if !field.IsValid() || !field.CanSet() || !field.IsZero() {
+		return
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(strconv.FormatInt(id, 10))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.SetInt(id)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		field.SetUint(uint64(id))
+	}
+}
@@ -0,0 +1,63 @@
+package factory
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFactory_WithFallbackToMake(t *testing.T) {
+	hooks := 0
+	f := New(func(seq int64) User {
+		return User{Name: "Fallback"}
+	}).AfterCreate(func(ctx context.Context, u *User) error {
+		hooks++
+		return nil
+	}).WithFallbackToMake()
+
+	ctx := context.Background()
+	user := f.MustCreate(ctx)
+	if user.ID != "1" || user.Name != "Fallback" {
+		t.Fatalf("expected made user with auto ID, got %+v", user)
+	}
+
+	users := f.Count(2).MustCreate(ctx)
+	if users[0].ID != "2" || users[1].ID != "3" {
+		t.Fatalf("expected incrementing IDs, got %s, %s", users[0].ID, users[1].ID)
+	}
+	if hooks != 3 {
+		t.Fatalf("expected hooks to run, got %d", hooks)
+	}
+
+	// Explicit IDs are kept
+	kept := f.MustCreate(ctx, func(u *User) { u.ID = "custom" })
+	if kept.ID != "custom" {
+		t.Fatalf("expected explicit ID to be kept, got %s", kept.ID)
+	}
+
+	if err := f.Validate(); err != nil {
+		t.Fatalf("expected hooks to be valid with fallback, got %v", err)
+	}
+}
+
+func TestFactory_WithFallbackToMakePrefersPersist(t *testing.T) {
+	type Row struct {
+		ID   int
+		Name string
+	}
+
+	f := New(func(seq int64) Row {
+		return Row{}
+	}).WithFallbackToMake()
+
+	if row := f.MustCreate(context.Background()); row.ID != 1 {
+		t.Fatalf("expected integer auto ID, got %d", row.ID)
+	}
+
+	f.WithPersist(func(ctx context.Context, r *Row) (*Row, error) {
+		r.ID = 99
+		return r, nil
+	})
+	if row := f.MustCreate(context.Background()); row.ID != 99 {
+		t.Fatalf("expected WithPersist to take precedence, got %d", row.ID)
+	}
+}
@@ -0,0 +1,114 @@
+package factory
+
+import "context"
+
+// FamilyFactory creates one parent with children from several different child
+// factories (e.g., a User with 3 Posts, 2 Drafts, and 1 Profile), instead of
+// composing Has calls that each create a new parent.
+type FamilyFactory[P any] struct {
+	parent   *Factory[P]
+	children []familyChild[P]
+}
+
+// familyChild builds or creates one child group for a parent.
+type familyChild[P any] struct {
+	make   func(parent *P) any
+	create func(ctx context.Context, parent *P) (any, error)
+}
+
+// Family is a parent with its heterogeneous children. Read each group with the
+// ChildKey returned by Children.
+type Family[P any] struct {
+	Parent   *P
+	children []any // []*C per child group, in Children order
+}
+
+// ChildKey identifies one child group of a FamilyFactory and reads it from a Family with type safety.
+type ChildKey[P any, C any] struct {
+	index int
+}
+
+// HasFamily starts a family declaration for parent.
+// Example:
+//
+//	fam := HasFamily(userFactory)
+//	posts := Children(fam, postFactory, 3, func(u *User, p *Post) { p.AuthorID = u.ID })
+//	drafts := Children(fam, draftFactory, 2, func(u *User, d *Draft) { d.AuthorID = u.ID })
+//	f, err := fam.Create(ctx)
+//	user, userPosts := f.Parent, posts.Of(f)
+func HasFamily[P any](parent *Factory[P]) *FamilyFactory[P] {
+	return &FamilyFactory[P]{parent: parent}
+}
+
+// Children adds count children from child to the family, linked to the parent by
+// link (may be nil), and returns the key to read them with.
+func Children[P any, C any](ff *FamilyFactory[P], child *Factory[C], count int, link func(parent *P, child *C)) ChildKey[P, C] {
+	linked := func(parent *P) Trait[C] {
+		return func(c *C) {
+			if link != nil {
+				link(parent, c)
+			}
+		}
+	}
+	ff.children = append(ff.children, familyChild[P]{
+		make: func(parent *P) any {
+			items := child.MakeMany(count, linked(parent))
+			out := make([]*C, len(items))
+			for i := range items {
+				out[i] = &items[i]
+			}
+			return out
+		},
+		create: func(ctx context.Context, parent *P) (any, error) {
+			return child.CreateMany(ctx, count, linked(parent))
+		},
+	})
+	return ChildKey[P, C]{index: len(ff.children) - 1}
+}
+
+// Of returns the children of this group in fam, or nil when fam is nil or the group
+// was never created (a partial family returned with an error).
+func (k ChildKey[P, C]) Of(fam *Family[P]) []*C {
+	if fam == nil || k.index >= len(fam.children) {
+		return nil
+	}
+	items, _ := fam.children[k.index].([]*C)
+	return items
+}
+
+// Make builds the parent and every child group without persisting.
+func (ff *FamilyFactory[P]) Make() *Family[P] {
+	parent := ff.parent.Make()
+	fam := &Family[P]{Parent: &parent}
+	for _, c := range ff.children {
+		fam.children = append(fam.children, c.make(fam.Parent))
+	}
+	return fam
+}
+
+// Create creates the parent, then each child group in the order added.
+// On error, returns the family created so far.
+func (ff *FamilyFactory[P]) Create(ctx context.Context) (*Family[P], error) {
+	parent, err := ff.parent.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fam := &Family[P]{Parent: parent}
+	for _, c := range ff.children {
+		items, err := c.create(ctx, parent)
+		fam.children = append(fam.children, items)
+		if err != nil {
+			return fam, err
+		}
+	}
+	return fam, nil
+}
+
+// MustCreate is like Create but panics on error.
+func (ff *FamilyFactory[P]) MustCreate(ctx context.Context) *Family[P] {
+	fam, err := ff.Create(ctx)
+	if err != nil {
+		panic("factory: FamilyFactory.MustCreate failed: " + err.Error())
+	}
+	return fam
+}
@@ -0,0 +1,64 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestHasFamily(t *testing.T) {
+	parents := 0
+	users := New(func(seq int64) User { return User{Name: fmt.Sprintf("User %d", seq)} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) {
+			parents++
+			u.ID = fmt.Sprintf("u%d", parents)
+			return u, nil
+		})
+	posts := New(func(seq int64) Post { return Post{Title: "Post"} }).
+		WithPersist(func(ctx context.Context, p *Post) (*Post, error) { return p, nil })
+	roles := New(func(seq int64) UserRole { return UserRole{RoleID: "member"} }).
+		WithPersist(func(ctx context.Context, r *UserRole) (*UserRole, error) { return r, nil })
+
+	fam := HasFamily(users)
+	postKey := Children(fam, posts, 3, func(u *User, p *Post) { p.AuthorID = u.ID })
+	draftKey := Children(fam, posts, 2, func(u *User, p *Post) { p.AuthorID, p.Title = u.ID, "Draft" })
+	roleKey := Children(fam, roles, 1, func(u *User, r *UserRole) { r.UserID = u.ID })
+
+	f := fam.MustCreate(context.Background())
+	if parents != 1 || f.Parent.ID != "u1" {
+		t.Fatalf("expected one parent, got %d (%+v)", parents, f.Parent)
+	}
+	if got := postKey.Of(f); len(got) != 3 || got[0].AuthorID != "u1" || got[0].Title != "Post" {
+		t.Fatalf("unexpected posts %+v", got)
+	}
+	if got := draftKey.Of(f); len(got) != 2 || got[1].Title != "Draft" {
+		t.Fatalf("unexpected drafts %+v", got)
+	}
+	if got := roleKey.Of(f); len(got) != 1 || got[0].UserID != "u1" {
+		t.Fatalf("unexpected roles %+v", got)
+	}
+
+	made := fam.Make()
+	if got := postKey.Of(made); len(got) != 3 || got[2].AuthorID != made.Parent.ID || parents != 1 {
+		t.Fatalf("expected made family without persisting, got %+v", got)
+	}
+}
+
+func TestHasFamily_ChildError(t *testing.T) {
+	users := New(func(seq int64) User { return User{ID: "u1"} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) { return u, nil })
+	posts := New(func(seq int64) Post { return Post{} }).
+		WithPersist(func(ctx context.Context, p *Post) (*Post, error) { return nil, errors.New("boom") })
+
+	fam := HasFamily(users)
+	key := Children(fam, posts, 2, nil)
+	later := Children(fam, posts, 1, nil)
+	f, err := fam.Create(context.Background())
+	if err == nil || f == nil || f.Parent.ID != "u1" || len(key.Of(f)) != 0 {
+		t.Fatalf("expected partial family and error, got %+v, %v", f, err)
+	}
+	if later.Of(f) != nil || key.Of(nil) != nil {
+		t.Fatal("expected nil children for groups never created and for a nil family")
+	}
+}
@@ -0,0 +1,133 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Fanout maps a uniform random roll in [0, 1) to a child count, describing how many
+// children each parent gets. Use it to give seeded data the skew production has
+// (most users have a few posts, a handful have hundreds) for performance testing.
+type Fanout func(roll float64) int
+
+// FanoutBucket is one range of a Buckets fan-out, chosen with probability
+// Weight / total weight.
+type FanoutBucket struct {
+	Weight   float64
+	Min, Max int // Inclusive child count range
+}
+
+// Uniform returns a Fanout giving each parent between lo and hi children (inclusive),
+// all counts equally likely. Panics if lo < 0 or hi < lo.
+func Uniform(lo, hi int) Fanout {
+	checkFanoutRange("Uniform", lo, hi)
+	return func(roll float64) int {
+		return lo + int(roll*float64(hi-lo+1))
+	}
+}
+
+// PowerLaw returns a Fanout giving each parent between lo and hi children with
+// probability proportional to (k-lo+1)^-alpha, so small counts dominate and a long
+// tail of parents gets many children. alpha around 2 resembles typical social data.
+// Panics if lo < 0, hi < lo, or alpha <= 0.
+// Example: Has(userFactory, postFactory, 0, linkFn).Fanout(PowerLaw(0, 100, 2))
+func PowerLaw(lo, hi int, alpha float64) Fanout {
+	checkFanoutRange("PowerLaw", lo, hi)
+	if alpha <= 0 {
+		panic(fmt.Sprintf("factory: PowerLaw alpha must be positive, got %v", alpha))
+	}
+	cdf := make([]float64, hi-lo+1)
+	total := 0.0
+	for i := range cdf {
+		total += math.Pow(float64(i+1), -alpha)
+		cdf[i] = total
+	}
+	return func(roll float64) int {
+		return lo + sort.SearchFloat64s(cdf, roll*total)
+	}
+}
+
+// Buckets returns a Fanout that picks a bucket by weight, then a count uniformly
+// within it. Panics if there are no buckets, a weight is not positive, or a range is
+// invalid.
+// Example: Buckets(FanoutBucket{Weight: 90, Min: 0, Max: 2}, FanoutBucket{Weight: 10, Min: 50, Max: 200})
+func Buckets(buckets ...FanoutBucket) Fanout {
+	if len(buckets) == 0 {
+		panic("factory: Buckets needs at least one bucket")
+	}
+	total := 0.0
+	for _, b := range buckets {
+		if b.Weight <= 0 {
+			panic(fmt.Sprintf("factory: Buckets weight must be positive, got %v", b.Weight))
+		}
+		checkFanoutRange("Buckets", b.Min, b.Max)
+		total += b.Weight
+	}
+	return func(roll float64) int {
+		target := roll * total
+		for _, b := range buckets {
+			if target < b.Weight {
+				// Rescale the roll within the bucket so one draw picks both
+				return b.Min + int(target/b.Weight*float64(b.Max-b.Min+1))
+			}
+			target -= b.Weight
+		}
+		last := buckets[len(buckets)-1]
+		return last.Max
+	}
+}
+
+func checkFanoutRange(name string, lo, hi int) {
+	if lo < 0 || hi < lo {
+		panic(fmt.Sprintf("factory: %s range [%d, %d] is invalid", name, lo, hi))
+	}
+}
+
+// Fanout makes each parent get a child count drawn from d (using the parent factory's
+// group random source, so WithSeed makes the shape reproducible) instead of the fixed
+// count passed to Has.
+// Example: Has(userFactory, postFactory, 0, linkFn).Fanout(PowerLaw(0, 100, 2)).Create(ctx)
+func (hf *HasFactory[T, R]) Fanout(d Fanout) *HasFactory[T, R] {
+	g := hf.parent.group
+	hf.countFn = func(*T) int { return d(g.float64()) }
+	return hf
+}
+
+// countFor returns the number of children to build for parent (see Fanout and HasFn).
+func (hf *HasFactory[T, R]) countFor(parent *T) int {
+	if hf.countFn != nil {
+		return max(hf.countFn(parent), 0)
+	}
+	return hf.count
+}
+
+// FanoutStep returns a seeder step that gives every parent stored under parentKey
+// (as []*T, e.g., by CreateStep) a number of children drawn from d, linked with
+// linkFn, and stores all children as []*R under key. Counts use childFactory's group
+// random source.
+// Example: s.Step("posts", FanoutStep("posts", "users", postFactory, PowerLaw(0, 50, 2), linkFn))
+func FanoutStep[T any, R any](key, parentKey string, childFactory *Factory[R], d Fanout, linkFn func(parent *T, child *R)) Step {
+	return func(ctx context.Context, r *Results) error {
+		parents, ok := Lookup[[]*T](r, parentKey)
+		if !ok {
+			return fmt.Errorf("factory: no %s results stored under '%s'", typeName[T](), parentKey)
+		}
+		var children []*R
+		defer func() { r.Set(key, children) }()
+		for _, parent := range parents {
+			linked := ForModel(childFactory, parent, func(c *R, p *T) { linkFn(p, c) })
+			linked.sharedSeq = childFactory.counter() // Keep sequence values unique across parents
+			n := d(childFactory.group.float64())
+			for i := 0; i < n; i++ {
+				child, err := linked.Create(ctx)
+				if err != nil {
+					return err
+				}
+				children = append(children, child)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,111 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestFanout_Distributions(t *testing.T) {
+	u := Uniform(1, 3)
+	if u(0) != 1 || u(0.5) != 2 || u(0.999) != 3 {
+		t.Fatalf("unexpected uniform counts %d %d %d", u(0), u(0.5), u(0.999))
+	}
+
+	p := PowerLaw(0, 100, 2)
+	small, large := 0, 0
+	for i := 0; i < 1000; i++ {
+		switch n := p(float64(i) / 1000); {
+		case n < 0 || n > 100:
+			t.Fatalf("power law count %d out of range", n)
+		case n <= 2:
+			small++
+		case n >= 50:
+			large++
+		}
+	}
+	if small < 800 || large == 0 {
+		t.Fatalf("expected most parents small with a long tail, got %d small and %d large", small, large)
+	}
+
+	b := Buckets(FanoutBucket{Weight: 9, Min: 0, Max: 2}, FanoutBucket{Weight: 1, Min: 50, Max: 60})
+	if n := b(0.5); n < 0 || n > 2 {
+		t.Fatalf("expected the first bucket, got %d", n)
+	}
+	if n := b(0.95); n < 50 || n > 60 {
+		t.Fatalf("expected the second bucket, got %d", n)
+	}
+}
+
+func TestFanout_InvalidPanics(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"range":  func() { Uniform(3, 1) },
+		"alpha":  func() { PowerLaw(0, 10, 0) },
+		"empty":  func() { Buckets() },
+		"weight": func() { Buckets(FanoutBucket{Weight: 0, Max: 1}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected panic", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestHasFactory_Fanout(t *testing.T) {
+	g := NewGroup().WithSeed(1)
+	users := NewIn(g, func(seq int64) User { return User{ID: "u"} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) { return u, nil })
+	posts := New(func(seq int64) Post { return Post{} }).
+		WithPersist(func(ctx context.Context, p *Post) (*Post, error) { return p, nil })
+	link := func(u *User, p *Post) { p.AuthorID = u.ID }
+
+	seen := map[int]bool{}
+	for i := 0; i < 20; i++ {
+		_, children := Has(users, posts, 0, link).Fanout(Uniform(1, 4)).MustCreate(context.Background())
+		if len(children) < 1 || len(children) > 4 {
+			t.Fatalf("expected 1-4 children, got %d", len(children))
+		}
+		seen[len(children)] = true
+	}
+	if len(seen) < 2 {
+		t.Fatalf("expected varying child counts, got %v", seen)
+	}
+
+	_, made := Has(users, posts, 5, link).Fanout(func(float64) int { return 2 }).Make()
+	if len(made) != 2 || made[0].AuthorID != "u" {
+		t.Fatalf("expected Make to use the fan-out, got %+v", made)
+	}
+}
+
+func TestFanoutStep(t *testing.T) {
+	users := New(func(seq int64) User { return User{ID: fmt.Sprintf("u%d", seq)} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) { return u, nil })
+	posts := New(func(seq int64) Post { return Post{Title: fmt.Sprintf("post-%d", seq)} }).
+		WithPersist(func(ctx context.Context, p *Post) (*Post, error) { return p, nil })
+
+	r := NewScenario("skewed").
+		Step("users", CreateStep("users", users, 3)).
+		Step("posts", FanoutStep("posts", "users", posts, func(float64) int { return 2 }, func(u *User, p *Post) { p.AuthorID = u.ID })).
+		MustCreate(context.Background())
+
+	created := Get[[]*Post](r, "posts")
+	if len(created) != 6 || created[0].AuthorID != "u1" || created[5].AuthorID != "u3" {
+		t.Fatalf("expected two posts per user, got %d", len(created))
+	}
+	seen := map[string]bool{}
+	for _, p := range created {
+		if seen[p.Title] {
+			t.Fatalf("expected unique sequence values across parents, got %s twice", p.Title)
+		}
+		seen[p.Title] = true
+	}
+
+	_, err := NewScenario("missing").Step("posts", FanoutStep("posts", "users", posts, Uniform(0, 1), func(u *User, p *Post) {})).Create(context.Background())
+	if err == nil {
+		t.Fatal("expected an error for missing parents")
+	}
+}
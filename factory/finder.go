@@ -0,0 +1,91 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Errors returned by Find and Reload.
+var (
+	// ErrNoFinder means Find or Reload was called without WithFinder.
+	ErrNoFinder = errors.New("factory: no finder; use WithFinder")
+	// ErrNotFound means the finder returned no record for the ID.
+	ErrNotFound = errors.New("factory: record not found")
+)
+
+// Finder fetches a persisted T by ID.
+type Finder[T any] func(ctx context.Context, id string) (*T, error)
+
+// WithFinder sets how to fetch a persisted T by ID, so tests can re-read the
+// canonical database state of created records (see Reload) without importing
+// repository code. A finder may return (nil, nil) for a missing record.
+// Example: userFactory.WithFinder(func(ctx context.Context, id string) (*User, error) { return repo.Get(ctx, id) })
+func (f *Factory[T]) WithFinder(find Finder[T]) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.finder = find
+	return f
+}
+
+// Find fetches the T with the given ID through the finder. Returns ErrNoFinder
+// without WithFinder and ErrNotFound if the finder returns no record.
+func (f *Factory[T]) Find(ctx context.Context, id string) (*T, error) {
+	v := f.view()
+	f = &v
+	if f.finder == nil {
+		return nil, ErrNoFinder
+	}
+	found, err := f.finder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w: %s %q", ErrNotFound, typeName[T](), id)
+	}
+	return found, nil
+}
+
+// Reload re-fetches t by its "ID" field (string or integer, formatted in decimal)
+// and returns the stored record; t itself is not modified. Use it after code under
+// test changes a created record.
+// Example: fresh, err := userFactory.Reload(ctx, user)
+func (f *Factory[T]) Reload(ctx context.Context, t *T) (*T, error) {
+	id, ok := idString(t)
+	if !ok {
+		return nil, fmt.Errorf("factory: Reload: %s has no ID", typeName[T]())
+	}
+	return f.Find(ctx, id)
+}
+
+// MustReload is Reload that panics on error.
+func (f *Factory[T]) MustReload(ctx context.Context, t *T) *T {
+	out, err := f.Reload(ctx, t)
+	if err != nil {
+		panic("factory: MustReload failed: " + err.Error())
+	}
+	return out
+}
+
+// idString returns t's non-zero "ID" field (string or integer) as a string.
+func idString[T any](t *T) (string, bool) {
+	v := reflect.ValueOf(t).Elem()
+	if v.Kind() != reflect.Struct {
+		return "", false
+	}
+	field := v.FieldByName("ID")
+	if !field.IsValid() || field.IsZero() {
+		return "", false
+	}
+	switch field.Kind() {
+	case reflect.String:
+		return field.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(field.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(field.Uint(), 10), true
+	}
+	return "", false
+}
@@ -0,0 +1,61 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFactory_Reload(t *testing.T) {
+	db := map[string]User{}
+	f := New(func(seq int64) User { return User{Name: "User"} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) {
+			u.ID = fmt.Sprintf("u%d", len(db)+1)
+			db[u.ID] = *u
+			return u, nil
+		}).
+		WithFinder(func(ctx context.Context, id string) (*User, error) {
+			u, ok := db[id]
+			if !ok {
+				return nil, nil
+			}
+			return &u, nil
+		})
+	ctx := context.Background()
+
+	user := f.MustCreate(ctx)
+	db[user.ID] = User{ID: user.ID, Name: "Renamed"} // code under test updates the row
+
+	fresh, err := f.Reload(ctx, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fresh.Name != "Renamed" || user.Name != "User" {
+		t.Fatalf("expected the stored record without modifying the original, got %+v and %+v", fresh, user)
+	}
+
+	delete(db, user.ID)
+	if _, err := f.Reload(ctx, user); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := f.Reload(ctx, &User{}); err == nil {
+		t.Fatal("expected error for an item without ID")
+	}
+	if _, err := New(func(seq int64) User { return User{} }).Find(ctx, "u1"); !errors.Is(err, ErrNoFinder) {
+		t.Fatalf("expected ErrNoFinder, got %v", err)
+	}
+}
+
+func TestFactory_ReloadIntegerID(t *testing.T) {
+	type Row struct{ ID int64 }
+	var asked string
+	f := New(func(seq int64) Row { return Row{} }).
+		WithFinder(func(ctx context.Context, id string) (*Row, error) {
+			asked = id
+			return &Row{ID: 42}, nil
+		})
+	if got := f.MustReload(context.Background(), &Row{ID: 42}); got.ID != 42 || asked != "42" {
+		t.Fatalf("expected lookup by decimal ID, got %+v (asked %q)", got, asked)
+	}
+}
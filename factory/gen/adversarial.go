@@ -0,0 +1,144 @@
+package gen
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/b3ndoi/factory-go/factory"
+)
+
+// Adversarial string sets for security and robustness testing.
+var (
+	SQLInjection = []string{
+		"' OR '1'='1",
+		"'; DROP TABLE users; --",
+		"\" OR \"\"=\"",
+		"1; SELECT pg_sleep(5)",
+		"admin'--",
+		"' UNION SELECT NULL, NULL --",
+	}
+	XSS = []string{
+		"<script>alert(1)</script>",
+		"\"><img src=x onerror=alert(1)>",
+		"javascript:alert(1)",
+		"<svg onload=alert(1)>",
+		"{{constructor.constructor('alert(1)')()}}",
+		"</textarea><script>alert(1)</script>",
+	}
+	ControlChars = []string{
+		"null\x00byte",
+		"\x00",
+		"line\r\nbreak",
+		"tab\tseparated",
+		"bell\a and escape \x1b[31m",
+		"\ufeffbyte order mark",
+	}
+	FourByteEmoji = []string{
+		"😀",
+		"👨‍👩‍👧‍👦 family",
+		"🏳️‍🌈 flag",
+		"𝕳𝖊𝖑𝖑𝖔 math letters",
+		"𠜎 rare CJK",
+	}
+	ZeroWidth = []string{
+		"zero\u200bwidth",
+		"joiner\u200dtext",
+		"non\u200cjoiner",
+		"\u2060word joiner",
+		"admin\u200b",
+		"rtl \u202eoverride",
+	}
+)
+
+// AdversarialPacks maps pack names to their values; AdversarialStates defines one
+// state per pack, named "adversarial:<name>".
+var AdversarialPacks = map[string][]string{
+	"sql":       SQLInjection,
+	"xss":       XSS,
+	"control":   ControlChars,
+	"emoji":     FourByteEmoji,
+	"zerowidth": ZeroWidth,
+	"long":      {strings.Repeat("é", 10000), strings.Repeat("𝕏", 5000)},
+}
+
+// Adversarial returns a trait that sets string fields to random values from values.
+// Selectors are field names or dotted paths into nested structs ("Address.City");
+// "*" selects every exported string field. Panics if a selector matches no string field.
+// Example: f.Make(gen.Adversarial[User](rng, gen.XSS, "Name", "Bio"))
+func Adversarial[T any](r *rand.Rand, values []string, selectors ...string) factory.Trait[T] {
+	var zero T
+	paths := stringFields(reflect.TypeOf(zero), "")
+	selected := make([][]int, 0, len(paths))
+	for _, sel := range selectors {
+		found := false
+		for _, p := range paths {
+			if sel == "*" || sel == p.name {
+				selected = append(selected, p.index)
+				found = true
+			}
+		}
+		if !found {
+			panic("gen: Adversarial selector " + sel + " matches no string field")
+		}
+	}
+
+	return func(t *T) {
+		v := reflect.ValueOf(t).Elem()
+		for _, index := range selected {
+			v.FieldByIndex(index).SetString(pick(r, values))
+		}
+	}
+}
+
+// AdversarialStates defines a state per entry in AdversarialPacks on f, applying that
+// pack to the selected fields, plus "adversarial:any" mixing every pack.
+// Example: gen.AdversarialStates(userFactory, rng, "Name", "Email").State("adversarial:xss").Make()
+func AdversarialStates[T any](f *factory.Factory[T], r *rand.Rand, selectors ...string) *factory.Factory[T] {
+	var all []string
+	for _, name := range sortedKeys(AdversarialPacks) {
+		values := AdversarialPacks[name]
+		f = f.DefineState("adversarial:"+name, Adversarial[T](r, values, selectors...))
+		all = append(all, values...)
+	}
+	return f.DefineState("adversarial:any", Adversarial[T](r, all, selectors...))
+}
+
+type fieldPath struct {
+	name  string
+	index []int
+}
+
+// stringFields lists exported string fields of t, descending into nested structs.
+func stringFields(t reflect.Type, prefix string) []fieldPath {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	var out []fieldPath
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name := prefix + sf.Name
+		switch sf.Type.Kind() {
+		case reflect.String:
+			out = append(out, fieldPath{name: name, index: []int{i}})
+		case reflect.Struct:
+			for _, nested := range stringFields(sf.Type, name+".") {
+				out = append(out, fieldPath{name: nested.name, index: append([]int{i}, nested.index...)})
+			}
+		}
+	}
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
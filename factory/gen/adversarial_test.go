@@ -0,0 +1,62 @@
+package gen
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/b3ndoi/factory-go/factory"
+)
+
+type profile struct {
+	Name    string
+	Bio     string
+	Age     int
+	Address struct {
+		City string
+	}
+}
+
+func TestAdversarial(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	f := factory.New(func(seq int64) profile {
+		return profile{Name: "safe", Bio: "safe", Age: 30}
+	})
+
+	p := f.Make(Adversarial[profile](r, XSS, "Name", "Address.City"))
+	if !slices.Contains(XSS, p.Name) || !slices.Contains(XSS, p.Address.City) {
+		t.Fatalf("expected XSS values in selected fields, got %+v", p)
+	}
+	if p.Bio != "safe" || p.Age != 30 {
+		t.Fatalf("expected other fields untouched, got %+v", p)
+	}
+
+	all := f.Make(Adversarial[profile](r, SQLInjection, "*"))
+	if !slices.Contains(SQLInjection, all.Bio) || !slices.Contains(SQLInjection, all.Address.City) {
+		t.Fatalf("expected every string field to be set, got %+v", all)
+	}
+
+	defer func() {
+		if rec := recover(); rec == nil {
+			t.Fatal("expected panic for selector matching no string field")
+		}
+	}()
+	Adversarial[profile](r, XSS, "Age")
+}
+
+func TestAdversarialStates(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	f := AdversarialStates(factory.New(func(seq int64) profile {
+		return profile{}
+	}), r, "Bio")
+
+	for name, values := range AdversarialPacks {
+		p := f.State("adversarial:" + name).Make()
+		if !slices.Contains(values, p.Bio) {
+			t.Fatalf("expected %s value, got %q", name, p.Bio)
+		}
+	}
+	if p := f.State("adversarial:any").Make(); p.Bio == "" {
+		t.Fatal("expected a value from the mixed pack")
+	}
+}
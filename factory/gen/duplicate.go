@@ -0,0 +1,139 @@
+package gen
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// Perturbation changes part of a near-duplicate record.
+type Perturbation[T any] func(r *rand.Rand, t *T)
+
+// Perturb returns a Perturbation that rewrites a string field with one of fns,
+// chosen at random.
+// Example: gen.Perturb(func(c *Contact) *string { return &c.Name }, gen.Typo, gen.SwapNameOrder)
+func Perturb[T any](field func(*T) *string, fns ...func(r *rand.Rand, s string) string) Perturbation[T] {
+	return func(r *rand.Rand, t *T) {
+		p := field(t)
+		*p = pick(r, fns)(r, *p)
+	}
+}
+
+// Duplicates returns n copies of original for testing dedupe and matching pipelines.
+// similarity is in [0, 1]: each perturbation is applied with probability
+// 1-similarity, and below 1 copies are re-perturbed until they differ from original,
+// so they are near-duplicates rather than exact ones. Perturbations that cannot change
+// a value (e.g., SwapNameOrder on a single name) are given up on after a few tries,
+// leaving that copy exact. A similarity of 1 returns exact copies.
+// Example: gen.Duplicates(rng, contact, 5, 0.8, gen.Perturb(nameField, gen.Typo), gen.Perturb(emailField, gen.AlternateEmail))
+func Duplicates[T any](r *rand.Rand, original T, n int, similarity float64, ps ...Perturbation[T]) []T {
+	out := make([]T, n)
+	for i := range out {
+		out[i] = original
+		if similarity >= 1 || len(ps) == 0 {
+			continue
+		}
+		applied := false
+		for _, p := range ps {
+			if float64(int63n(r, 1<<53))/(1<<53) >= similarity {
+				p(r, &out[i])
+				applied = true
+			}
+		}
+		// Some perturbations are no-ops for some values (ChangeCase on "ACME")
+		for try := 0; try < maxPerturbTries && (!applied || reflect.DeepEqual(out[i], original)); try++ {
+			pick(r, ps)(r, &out[i])
+			applied = true
+		}
+	}
+	return out
+}
+
+// maxPerturbTries bounds how often Duplicates re-perturbs a copy that still equals
+// the original.
+const maxPerturbTries = 10
+
+// Typo introduces one keyboard-style error: a swapped, dropped, doubled, or
+// replaced character. The result always differs from s.
+func Typo(r *rand.Rand, s string) string {
+	runes := []rune(s)
+	if len(runes) < 2 {
+		return s + "x"
+	}
+	i := intn(r, len(runes)-1)
+	switch intn(r, 4) {
+	case 0: // Swap adjacent (drop instead when both are the same)
+		if runes[i] == runes[i+1] {
+			runes = append(runes[:i], runes[i+1:]...)
+			break
+		}
+		runes[i], runes[i+1] = runes[i+1], runes[i]
+	case 1: // Drop
+		runes = append(runes[:i], runes[i+1:]...)
+	case 2: // Double
+		runes = append(runes[:i+1], runes[i:]...)
+	default: // Replace with a different letter
+		c := rune('a' + intn(r, 25))
+		if c >= runes[i] && runes[i] >= 'a' && runes[i] <= 'z' {
+			c++
+		}
+		runes[i] = c
+	}
+	return string(runes)
+}
+
+// SwapNameOrder writes "First Last" as "Last, First" (names without a space are unchanged).
+func SwapNameOrder(_ *rand.Rand, s string) string {
+	i := strings.LastIndex(s, " ")
+	if i < 0 {
+		return s
+	}
+	return s[i+1:] + ", " + s[:i]
+}
+
+// ChangeCase upper-cases or lower-cases s, or capitalizes only its first letter.
+func ChangeCase(r *rand.Rand, s string) string {
+	switch intn(r, 3) {
+	case 0:
+		return strings.ToUpper(s)
+	case 1:
+		return strings.ToLower(s)
+	default:
+		if s == "" {
+			return s
+		}
+		first, size := utf8.DecodeRuneInString(s)
+		return strings.ToUpper(string(first)) + strings.ToLower(s[size:])
+	}
+}
+
+// AlternateEmail returns a variant of an address that usually reaches the same person:
+// a plus tag, different case, dots in the local part, or a domain alias.
+func AlternateEmail(r *rand.Rand, email string) string {
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return Typo(r, email)
+	}
+	if local == "" { // e.g., from InvalidEmails; a plus tag still makes a distinct variant
+		return "+" + pick(r, words) + "@" + domain
+	}
+	switch intn(r, 4) {
+	case 0:
+		return local + "+" + pick(r, words) + "@" + domain
+	case 1:
+		return strings.ToUpper(local[:1]) + local[1:] + "@" + strings.ToUpper(domain)
+	case 2:
+		if len(local) > 1 {
+			i := 1 + intn(r, len(local)-1)
+			return local[:i] + "." + local[i:] + "@" + domain
+		}
+		return local + "." + "@" + domain
+	default:
+		aliases := map[string]string{"gmail.com": "googlemail.com", "googlemail.com": "gmail.com"}
+		if alias, ok := aliases[strings.ToLower(domain)]; ok {
+			return local + "@" + alias
+		}
+		return local + "@mail." + domain
+	}
+}
@@ -0,0 +1,93 @@
+package gen
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+type contact struct {
+	Name  string
+	Email string
+	City  string
+}
+
+func TestDuplicates(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	original := contact{Name: "Ada Lovelace", Email: "ada@example.com", City: "London"}
+	name := Perturb(func(c *contact) *string { return &c.Name }, Typo, SwapNameOrder, ChangeCase)
+	email := Perturb(func(c *contact) *string { return &c.Email }, AlternateEmail)
+
+	exact := Duplicates(r, original, 3, 1, name, email)
+	for _, c := range exact {
+		if c != original {
+			t.Fatalf("expected exact copies at similarity 1, got %+v", c)
+		}
+	}
+
+	near := Duplicates(r, original, 50, 0.9, name, email)
+	for _, c := range near {
+		if c == original {
+			t.Fatalf("expected every copy to be perturbed, got %+v", c)
+		}
+		if c.City != "London" {
+			t.Fatalf("expected unperturbed fields to be kept, got %+v", c)
+		}
+	}
+
+	// Low similarity perturbs more fields on average
+	changedBoth := func(cs []contact) int {
+		n := 0
+		for _, c := range cs {
+			if c.Name != original.Name && c.Email != original.Email {
+				n++
+			}
+		}
+		return n
+	}
+	if hi, lo := changedBoth(Duplicates(r, original, 200, 0.9, name, email)), changedBoth(Duplicates(r, original, 200, 0.1, name, email)); lo <= hi {
+		t.Fatalf("expected lower similarity to change more fields, got %d vs %d", lo, hi)
+	}
+}
+
+func TestStringPerturbations(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+
+	if got := SwapNameOrder(r, "Ada King Lovelace"); got != "Lovelace, Ada King" {
+		t.Fatalf("unexpected swapped name %q", got)
+	}
+	if got := SwapNameOrder(r, "Cher"); got != "Cher" {
+		t.Fatalf("expected single name to be unchanged, got %q", got)
+	}
+
+	for i := 0; i < 50; i++ {
+		if got := Typo(r, "Lovelace"); got == "Lovelace" {
+			t.Fatalf("expected a typo, got %q", got)
+		}
+		if got := Typo(r, "aa"); got == "aa" {
+			t.Fatalf("expected a typo, got %q", got)
+		}
+		if got := ChangeCase(r, "ada LOVELACE"); !strings.EqualFold(got, "ada lovelace") {
+			t.Fatalf("expected only case to change, got %q", got)
+		}
+		got := AlternateEmail(r, "ada@gmail.com")
+		if got == "ada@gmail.com" || !strings.Contains(got, "@") {
+			t.Fatalf("expected an alternate address, got %q", got)
+		}
+		if got := AlternateEmail(r, "@example.com"); got == "@example.com" || !strings.HasSuffix(got, "@example.com") {
+			t.Fatalf("expected an alternate address for an empty local part, got %q", got)
+		}
+	}
+}
+
+func TestDuplicates_RetriesNoOpPerturbations(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	original := contact{Name: "ACME"}
+	name := Perturb(func(c *contact) *string { return &c.Name }, ChangeCase, SwapNameOrder)
+
+	for _, c := range Duplicates(r, original, 50, 0.5, name) {
+		if c == original {
+			t.Fatalf("expected every copy to differ from the original, got %+v", c)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// EmailStrategy generates an email address for an item's sequence number.
+// Call it from a make function: Email: strategy(rng, seq).
+type EmailStrategy func(r *rand.Rand, seq int64) string
+
+// SafeDomain returns user<seq>@domain. Use a domain you control or one reserved by
+// RFC 2606 (example.com, *.test) so seeding can never email real people.
+func SafeDomain(domain string) EmailStrategy {
+	return func(r *rand.Rand, seq int64) string {
+		return fmt.Sprintf("user%d@%s", seq, domain)
+	}
+}
+
+// CatchAll returns a random, human-looking local part at domain, for environments
+// with a catch-all mailbox that should receive every seeded address.
+func CatchAll(domain string) EmailStrategy {
+	return func(r *rand.Rand, seq int64) string {
+		return fmt.Sprintf("%s.%s.%d@%s", pick(r, words), pick(r, words), seq, domain)
+	}
+}
+
+// PlusAddressed returns mailbox with "+<seq>" added to the local part
+// (qa@company.com → qa+42@company.com), so every address is unique but all mail
+// reaches one real inbox. Panics if mailbox has no "@".
+func PlusAddressed(mailbox string) EmailStrategy {
+	local, domain, ok := strings.Cut(mailbox, "@")
+	if !ok {
+		panic("gen: PlusAddressed requires a mailbox like name@domain")
+	}
+	return func(r *rand.Rand, seq int64) string {
+		return fmt.Sprintf("%s+%d@%s", local, seq, domain)
+	}
+}
+
+// InvalidEmails are malformed addresses for validation tests.
+var InvalidEmails = []string{
+	"",
+	"plainaddress",
+	"@missing-local.example.com",
+	"missing-at.example.com",
+	"two@@example.com",
+	"user@",
+	"spaces in@example.com",
+	"user@exa mple.com",
+	".leading-dot@example.com",
+	"trailing-dot.@example.com",
+	"double..dot@example.com",
+	"user@example..com",
+	"user@-leading-hyphen.com",
+	"user@example.com.",
+	"<script>@example.com",
+	strings.Repeat("a", 65) + "@example.com", // Local part over 64 characters
+}
+
+// Invalid returns a random malformed address from InvalidEmails.
+func Invalid() EmailStrategy {
+	return func(r *rand.Rand, seq int64) string {
+		return pick(r, InvalidEmails)
+	}
+}
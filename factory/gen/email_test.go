@@ -0,0 +1,75 @@
+package gen
+
+import (
+	"math/rand"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/b3ndoi/factory-go/factory"
+)
+
+type Subscriber struct {
+	Email string
+}
+
+func TestEmailStrategies(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	if got := SafeDomain("example.com")(r, 7); got != "user7@example.com" {
+		t.Fatalf("unexpected safe-domain email %q", got)
+	}
+	if got := PlusAddressed("qa@company.test")(r, 42); got != "qa+42@company.test" {
+		t.Fatalf("unexpected plus-addressed email %q", got)
+	}
+
+	catchAll := CatchAll("catchall.test")(r, 3)
+	if !strings.HasSuffix(catchAll, ".3@catchall.test") {
+		t.Fatalf("unexpected catch-all email %q", catchAll)
+	}
+	for _, s := range []EmailStrategy{SafeDomain("example.com"), CatchAll("catchall.test"), PlusAddressed("qa@company.test")} {
+		if _, err := mail.ParseAddress(s(r, 1)); err != nil {
+			t.Fatalf("expected a valid address: %v", err)
+		}
+	}
+}
+
+func TestInvalidEmails(t *testing.T) {
+	// net/mail is lenient about domain syntax and length; everything else must be rejected
+	lenient := map[string]bool{
+		"user@example..com":                      true,
+		"user@-leading-hyphen.com":               true,
+		"user@example.com.":                      true,
+		"<script>@example.com":                   true,
+		strings.Repeat("a", 65) + "@example.com": true,
+	}
+	for _, e := range InvalidEmails {
+		if _, err := mail.ParseAddress(e); err == nil && !lenient[e] {
+			t.Fatalf("expected %q to be invalid", e)
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	invalid := Invalid()
+	f := factory.New(func(seq int64) Subscriber {
+		return Subscriber{Email: invalid(r, seq)}
+	})
+	for _, s := range f.MakeMany(10) {
+		found := false
+		for _, e := range InvalidEmails {
+			found = found || e == s.Email
+		}
+		if !found {
+			t.Fatalf("unexpected invalid email %q", s.Email)
+		}
+	}
+}
+
+func TestPlusAddressedPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic for mailbox without @")
+		}
+	}()
+	PlusAddressed("no-at-sign")
+}
@@ -0,0 +1,26 @@
+// Package gen provides composable data generators for factory make functions and traits.
+//
+// Every generator takes a *rand.Rand so seeded runs are reproducible. A nil *rand.Rand
+// uses the math/rand global source.
+package gen
+
+import "math/rand"
+
+func intn(r *rand.Rand, n int) int {
+	if r == nil {
+		return rand.Intn(n) //nolint:gosec // test data, not security-sensitive
+	}
+	return r.Intn(n)
+}
+
+func int63n(r *rand.Rand, n int64) int64 {
+	if r == nil {
+		return rand.Int63n(n) //nolint:gosec // test data, not security-sensitive
+	}
+	return r.Int63n(n)
+}
+
+// pick returns a random element of items.
+func pick[E any](r *rand.Rand, items []E) E {
+	return items[intn(r, len(items))]
+}
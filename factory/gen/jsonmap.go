@@ -0,0 +1,137 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/b3ndoi/factory-go/factory"
+)
+
+// Field generates one value of a JSON object.
+type Field func(r *rand.Rand) any
+
+// Template describes the shape of a JSON object. Each value is a Field, a nested
+// Template, or a literal copied as-is.
+// Example: gen.Template{"source": "import", "score": gen.Float(0, 1), "tags": gen.Tags(3)}
+type Template map[string]any
+
+// Object builds a map from tmpl, calling every Field and recursing into nested Templates.
+// Fields run in key order, so the same seed always builds the same object.
+func Object(r *rand.Rand, tmpl Template) map[string]any {
+	keys := make([]string, 0, len(tmpl))
+	for k := range tmpl {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make(map[string]any, len(tmpl))
+	for _, k := range keys {
+		switch v := tmpl[k].(type) {
+		case Field:
+			out[k] = v(r)
+		case func(*rand.Rand) any:
+			out[k] = v(r)
+		case Template:
+			out[k] = Object(r, v)
+		default:
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// RandomObject returns an object with up to maxKeys random keys per level, nested at most
+// depth levels deep. Values are strings, numbers, booleans, nulls, arrays, and objects,
+// all of which round-trip through encoding/json unchanged.
+func RandomObject(r *rand.Rand, depth, maxKeys int) map[string]any {
+	n := 0
+	if maxKeys > 0 {
+		n = 1 + intn(r, maxKeys)
+	}
+	out := make(map[string]any, n)
+	for i := 0; i < n; i++ {
+		out[key(r, out)] = randomValue(r, depth-1, maxKeys)
+	}
+	return out
+}
+
+// WithExtraKeys adds n random keys to m (never overwriting existing ones) and returns m.
+// Useful for checking that readers ignore fields they do not know about.
+func WithExtraKeys(r *rand.Rand, m map[string]any, n int) map[string]any {
+	if m == nil {
+		m = make(map[string]any, n)
+	}
+	for i := 0; i < n; i++ {
+		m[key(r, m)] = randomValue(r, 0, 0)
+	}
+	return m
+}
+
+// Metadata returns a trait that fills a JSON column from tmpl plus extra random keys.
+// Example: gen.Metadata(rng, func(p *Product) *map[string]any { return &p.Meta }, tmpl, 2)
+func Metadata[T any](r *rand.Rand, field func(*T) *map[string]any, tmpl Template, extra int) factory.Trait[T] {
+	return func(t *T) {
+		*field(t) = WithExtraKeys(r, Object(r, tmpl), extra)
+	}
+}
+
+// Int returns a Field producing integers in [lo, hi], as float64 like encoding/json does.
+func Int(lo, hi int) Field {
+	return func(r *rand.Rand) any { return float64(lo + intn(r, hi-lo+1)) }
+}
+
+// Float returns a Field producing floats in [lo, hi).
+func Float(lo, hi float64) Field {
+	return func(r *rand.Rand) any { return lo + float64(int63n(r, 1<<53))/(1<<53)*(hi-lo) }
+}
+
+// OneOf returns a Field choosing among values.
+func OneOf(values ...any) Field {
+	return func(r *rand.Rand) any { return pick(r, values) }
+}
+
+// Tags returns a Field producing up to n words as a []any.
+func Tags(n int) Field {
+	return func(r *rand.Rand) any {
+		out := make([]any, intn(r, n+1))
+		for i := range out {
+			out[i] = pick(r, words)
+		}
+		return out
+	}
+}
+
+// key returns a word-based key not already present in m.
+func key(r *rand.Rand, m map[string]any) string {
+	k := pick(r, words)
+	for i := 2; ; i++ {
+		if _, ok := m[k]; !ok {
+			return k
+		}
+		k = fmt.Sprintf("%s_%d", pick(r, words), i)
+	}
+}
+
+func randomValue(r *rand.Rand, depth, maxKeys int) any {
+	kinds := 5
+	if depth > 0 && maxKeys > 0 {
+		kinds = 7
+	}
+	switch intn(r, kinds) {
+	case 0:
+		return pick(r, words)
+	case 1:
+		return float64(intn(r, 1000))
+	case 2:
+		return intn(r, 2) == 1
+	case 3:
+		return nil
+	case 4:
+		return Tags(3)(r)
+	case 5:
+		return []any{RandomObject(r, depth, maxKeys)}
+	default:
+		return RandomObject(r, depth, maxKeys)
+	}
+}
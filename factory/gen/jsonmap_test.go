@@ -0,0 +1,120 @@
+package gen
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestObject(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	obj := Object(r, Template{
+		"source": "import",
+		"score":  Float(0, 1),
+		"count":  Int(1, 3),
+		"kind":   OneOf("a", "b"),
+		"tags":   Tags(2),
+		"nested": Template{"plan": OneOf("free", "pro")},
+	})
+
+	if obj["source"] != "import" {
+		t.Fatalf("expected literal to be copied, got %v", obj["source"])
+	}
+	if s := obj["score"].(float64); s < 0 || s >= 1 {
+		t.Fatalf("expected score in [0, 1), got %v", s)
+	}
+	if c := obj["count"].(float64); c < 1 || c > 3 {
+		t.Fatalf("expected count in [1, 3], got %v", c)
+	}
+	if k := obj["kind"]; k != "a" && k != "b" {
+		t.Fatalf("expected kind a or b, got %v", k)
+	}
+	if tags := obj["tags"].([]any); len(tags) > 2 {
+		t.Fatalf("expected at most 2 tags, got %d", len(tags))
+	}
+	if plan := obj["nested"].(map[string]any)["plan"]; plan != "free" && plan != "pro" {
+		t.Fatalf("expected nested plan, got %v", plan)
+	}
+}
+
+func TestObject_Reproducible(t *testing.T) {
+	tmpl := Template{
+		"a": Int(0, 1000),
+		"b": Float(0, 1),
+		"c": OneOf("x", "y", "z"),
+		"d": Tags(5),
+		"e": Template{"f": Int(0, 1000), "g": Int(0, 1000)},
+	}
+	want := Object(rand.New(rand.NewSource(7)), tmpl)
+	for i := 0; i < 20; i++ {
+		if got := Object(rand.New(rand.NewSource(7)), tmpl); !reflect.DeepEqual(got, want) {
+			t.Fatalf("expected the same object for the same seed, got %v and %v", want, got)
+		}
+	}
+}
+
+func TestRandomObject_DepthAndRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 50; i++ {
+		obj := RandomObject(r, 3, 4)
+		if d := depthOf(obj); d > 3 {
+			t.Fatalf("expected depth <= 3, got %d", d)
+		}
+
+		data, err := json.Marshal(obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var back map[string]any
+		if err := json.Unmarshal(data, &back); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(obj, back) {
+			t.Fatalf("expected JSON round trip, got %v vs %v", obj, back)
+		}
+	}
+}
+
+func TestWithExtraKeys(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	m := WithExtraKeys(r, map[string]any{"go": "keep"}, 5)
+	if len(m) != 6 {
+		t.Fatalf("expected 6 keys, got %d", len(m))
+	}
+	if m["go"] != "keep" {
+		t.Fatalf("expected existing key to be kept, got %v", m["go"])
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	type Product struct{ Meta map[string]any }
+
+	r := rand.New(rand.NewSource(4))
+	var p Product
+	Metadata(r, func(p *Product) *map[string]any { return &p.Meta }, Template{"v": 1}, 2)(&p)
+	if len(p.Meta) != 3 || p.Meta["v"] != 1 {
+		t.Fatalf("expected template plus 2 extra keys, got %v", p.Meta)
+	}
+}
+
+func depthOf(v any) int {
+	deepest := 0
+	switch v := v.(type) {
+	case map[string]any:
+		for _, c := range v {
+			if d := depthOf(c); d > deepest {
+				deepest = d
+			}
+		}
+		return deepest + 1
+	case []any:
+		for _, c := range v {
+			if d := depthOf(c); d > deepest {
+				deepest = d
+			}
+		}
+		return deepest
+	}
+	return 0
+}
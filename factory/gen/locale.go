@@ -0,0 +1,176 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/b3ndoi/factory-go/factory"
+)
+
+// Locale holds the vocabulary used to generate names, addresses, and text for one
+// language and region. Register custom locales by adding them to Locales.
+type Locale struct {
+	Code        string   // e.g., "de_DE"
+	FirstNames  []string // Given names
+	LastNames   []string // Family names
+	Cities      []string
+	Streets     []string
+	Words       []string // Vocabulary for Sentence
+	FamilyFirst bool     // Write the family name first, without a space (e.g., ja_JP)
+	HouseFirst  bool     // Put the house number before the street (e.g., en_US)
+}
+
+// Locales are the built-in locales by code.
+var Locales = map[string]*Locale{
+	"en_US": {
+		Code:       "en_US",
+		FirstNames: []string{"James", "Mary", "Robert", "Patricia", "Michael", "Linda", "Ashley", "Tyler"},
+		LastNames:  []string{"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis"},
+		Cities:     []string{"Springfield", "Portland", "Austin", "Denver", "Columbus", "Raleigh"},
+		Streets:    []string{"Main St", "Oak Ave", "Maple Dr", "Cedar Ln", "Elm St", "Park Blvd"},
+		Words:      []string{"order", "shipped", "great", "service", "quick", "delivery", "would", "recommend", "price", "quality"},
+		HouseFirst: true,
+	},
+	"de_DE": {
+		Code:       "de_DE",
+		FirstNames: []string{"Lukas", "Anna", "Jonas", "Lea", "Felix", "Marie", "Jürgen", "Sören"},
+		LastNames:  []string{"Müller", "Schmidt", "Schneider", "Fischer", "Weber", "Meyer", "Größer", "Wagner"},
+		Cities:     []string{"Berlin", "München", "Köln", "Düsseldorf", "Nürnberg", "Leipzig"},
+		Streets:    []string{"Hauptstraße", "Schulstraße", "Gartenweg", "Bahnhofstraße", "Lindenallee", "Am Markt"},
+		Words:      []string{"bestellung", "schnell", "geliefert", "sehr", "gut", "qualität", "preis", "empfehlung", "gerne", "wieder"},
+	},
+	"fr_FR": {
+		Code:       "fr_FR",
+		FirstNames: []string{"Camille", "Léa", "Hugo", "Chloé", "Théo", "Zoé", "François", "Hélène"},
+		LastNames:  []string{"Martin", "Bernard", "Dubois", "Lefèvre", "Moreau", "Laurent", "Girard", "Roux"},
+		Cities:     []string{"Paris", "Lyon", "Marseille", "Orléans", "Besançon", "Nîmes"},
+		Streets:    []string{"rue de la Paix", "avenue Victor Hugo", "boulevard Saint-Michel", "rue des Écoles", "place du Marché"},
+		Words:      []string{"commande", "livraison", "rapide", "très", "bien", "qualité", "prix", "élevé", "service", "parfait"},
+		HouseFirst: true,
+	},
+	"es_MX": {
+		Code:       "es_MX",
+		FirstNames: []string{"José", "María", "Sofía", "Santiago", "Ximena", "Mateo", "Regina", "Íñigo"},
+		LastNames:  []string{"Hernández", "García", "Martínez", "López", "González", "Pérez", "Rodríguez", "Núñez"},
+		Cities:     []string{"Ciudad de México", "Guadalajara", "Monterrey", "Puebla", "Mérida", "Querétaro"},
+		Streets:    []string{"Avenida Reforma", "Calle Juárez", "Calle Hidalgo", "Avenida Insurgentes", "Calle Morelos"},
+		Words:      []string{"pedido", "llegó", "rápido", "excelente", "servicio", "calidad", "precio", "muy", "bueno", "recomiendo"},
+	},
+	"ja_JP": {
+		Code:        "ja_JP",
+		FirstNames:  []string{"陽翔", "結衣", "蓮", "陽菜", "湊", "凛", "大翔", "さくら"},
+		LastNames:   []string{"佐藤", "鈴木", "高橋", "田中", "伊藤", "渡辺", "山本", "中村"},
+		Cities:      []string{"東京都新宿区", "大阪市北区", "京都市中京区", "札幌市中央区", "福岡市博多区"},
+		Streets:     []string{"西新宿", "梅田", "烏丸通", "大通西", "博多駅前"},
+		Words:       []string{"注文", "配送", "早い", "とても", "良い", "品質", "価格", "サービス", "また", "購入"},
+		FamilyFirst: true,
+	},
+}
+
+// LocaleFor returns the locale for code. Panics if the code is not in Locales.
+func LocaleFor(code string) *Locale {
+	l, ok := Locales[code]
+	if !ok {
+		panic("gen: unknown locale " + code)
+	}
+	return l
+}
+
+// Name returns a full name in the locale's order.
+func (l *Locale) Name(r *rand.Rand) string {
+	first, last := pick(r, l.FirstNames), pick(r, l.LastNames)
+	if l.FamilyFirst {
+		return last + first
+	}
+	return first + " " + last
+}
+
+// FirstName returns a given name.
+func (l *Locale) FirstName(r *rand.Rand) string {
+	return pick(r, l.FirstNames)
+}
+
+// LastName returns a family name.
+func (l *Locale) LastName(r *rand.Rand) string {
+	return pick(r, l.LastNames)
+}
+
+// City returns a city name.
+func (l *Locale) City(r *rand.Rand) string {
+	return pick(r, l.Cities)
+}
+
+// Address returns a street address and city in the locale's usual order.
+func (l *Locale) Address(r *rand.Rand) string {
+	street, number, city := pick(r, l.Streets), 1+intn(r, 200), l.City(r)
+	switch {
+	case l.FamilyFirst:
+		return fmt.Sprintf("%s%s%d", city, street, number)
+	case l.HouseFirst:
+		return fmt.Sprintf("%d %s, %s", number, street, city)
+	default:
+		return fmt.Sprintf("%s %d, %s", street, number, city)
+	}
+}
+
+// Sentence returns n words of the locale's vocabulary as a sentence.
+func (l *Locale) Sentence(r *rand.Rand, n int) string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = pick(r, l.Words)
+	}
+	if l.FamilyFirst {
+		return strings.Join(out, "") + "。"
+	}
+	return capitalize(strings.Join(out, " ")) + "."
+}
+
+// PickLocale returns a locale chosen by weight (weights need not sum to 1).
+// Panics if a code is unknown or no weight is positive.
+// Example: gen.PickLocale(rng, map[string]float64{"en_US": 6, "de_DE": 2, "ja_JP": 2})
+func PickLocale(r *rand.Rand, weights map[string]float64) *Locale {
+	codes, total := sortedWeights(weights)
+	roll := float64(int63n(r, 1<<53)) / (1 << 53) * total
+	for _, code := range codes {
+		if roll < weights[code] {
+			return LocaleFor(code)
+		}
+		roll -= weights[code]
+	}
+	return LocaleFor(codes[len(codes)-1])
+}
+
+// Localized returns a trait that assigns each record a locale by weight and calls apply,
+// so every localized field of the record (name, address, text) uses the same locale.
+// Example:
+//
+//	gen.Localized(rng, map[string]float64{"en_US": 0.7, "fr_FR": 0.3}, func(u *User, l *gen.Locale) {
+//		u.Locale, u.Name, u.City = l.Code, l.Name(rng), l.City(rng)
+//	})
+func Localized[T any](r *rand.Rand, weights map[string]float64, apply func(t *T, l *Locale)) factory.Trait[T] {
+	sortedWeights(weights) // Validate up front rather than on the first Make
+	return func(t *T) {
+		apply(t, PickLocale(r, weights))
+	}
+}
+
+// sortedWeights validates weights and returns the codes in sorted order (for
+// reproducible seeded picks) with the total weight.
+func sortedWeights(weights map[string]float64) ([]string, float64) {
+	codes := make([]string, 0, len(weights))
+	total := 0.0
+	for code, w := range weights {
+		LocaleFor(code)
+		if w > 0 {
+			codes = append(codes, code)
+			total += w
+		}
+	}
+	if total <= 0 {
+		panic("gen: locale weights must include a positive weight")
+	}
+	sort.Strings(codes)
+	return codes, total
+}
@@ -0,0 +1,84 @@
+package gen
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/b3ndoi/factory-go/factory"
+)
+
+func TestLocale_Generators(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	ja := LocaleFor("ja_JP")
+	if name := ja.Name(r); strings.Contains(name, " ") {
+		t.Fatalf("expected family-first name without space, got %q", name)
+	}
+	if s := ja.Sentence(r, 3); !strings.HasSuffix(s, "。") {
+		t.Fatalf("expected Japanese full stop, got %q", s)
+	}
+
+	us := LocaleFor("en_US")
+	if addr := us.Address(r); addr[0] < '0' || addr[0] > '9' {
+		t.Fatalf("expected house number first, got %q", addr)
+	}
+	de := LocaleFor("de_DE")
+	if addr := de.Address(r); addr[0] >= '0' && addr[0] <= '9' {
+		t.Fatalf("expected street first, got %q", addr)
+	}
+
+	defer func() {
+		if rec := recover(); rec == nil {
+			t.Fatal("expected panic for unknown locale")
+		}
+	}()
+	LocaleFor("xx_XX")
+}
+
+func TestPickLocale_Weighted(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	counts := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		counts[PickLocale(r, map[string]float64{"en_US": 8, "fr_FR": 2, "de_DE": 0}).Code]++
+	}
+	if counts["de_DE"] != 0 {
+		t.Fatalf("expected zero-weight locale to be skipped, got %v", counts)
+	}
+	if counts["en_US"] < 700 || counts["fr_FR"] < 100 {
+		t.Fatalf("expected roughly 80/20 split, got %v", counts)
+	}
+}
+
+func TestLocalized(t *testing.T) {
+	type Customer struct {
+		Locale, Name, City string
+	}
+
+	r := rand.New(rand.NewSource(3))
+	f := factory.New(func(seq int64) Customer {
+		return Customer{}
+	}).WithTraits(Localized(r, map[string]float64{"ja_JP": 1, "es_MX": 1}, func(c *Customer, l *Locale) {
+		c.Locale, c.Name, c.City = l.Code, l.Name(r), l.City(r)
+	}))
+
+	seen := map[string]bool{}
+	for _, c := range f.MakeMany(50) {
+		seen[c.Locale] = true
+		l := LocaleFor(c.Locale)
+		if !slices.Contains(l.Cities, c.City) {
+			t.Fatalf("expected city from %s, got %+v", c.Locale, c)
+		}
+	}
+	if !seen["ja_JP"] || !seen["es_MX"] {
+		t.Fatalf("expected both locales, got %v", seen)
+	}
+
+	defer func() {
+		if rec := recover(); rec == nil {
+			t.Fatal("expected panic for weights without a positive entry")
+		}
+	}()
+	Localized(r, map[string]float64{"en_US": 0}, func(c *Customer, l *Locale) {})
+}
@@ -0,0 +1,77 @@
+package gen
+
+import (
+	"math/rand"
+	"net"
+	"net/netip"
+)
+
+// UserAgents are realistic browser, mobile, bot, and CLI user-agent strings.
+var UserAgents = []string{
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Safari/605.1.15",
+	"Mozilla/5.0 (X11; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0",
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36 Edg/124.0.2478.51",
+	"Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1",
+	"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.6367.82 Mobile Safari/537.36",
+	"Mozilla/5.0 (iPad; CPU OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1",
+	"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+	"curl/8.6.0",
+	"Go-http-client/1.1",
+	"PostmanRuntime/7.37.3",
+}
+
+// UserAgent returns a random entry from UserAgents.
+func UserAgent(r *rand.Rand) string {
+	return pick(r, UserAgents)
+}
+
+// IPInCIDR returns a random address within cidr (e.g., "10.0.0.0/8" or "2001:db8::/32").
+// Panics if cidr is invalid.
+func IPInCIDR(r *rand.Rand, cidr string) netip.Addr {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		panic("gen: invalid CIDR " + cidr + ": " + err.Error())
+	}
+	prefix = prefix.Masked()
+
+	b := prefix.Addr().AsSlice()
+	bits := prefix.Bits()
+	for i := range b {
+		// Bits of this byte that belong to the network prefix
+		fixed := bits - i*8
+		switch {
+		case fixed >= 8:
+			continue
+		case fixed <= 0:
+			b[i] = byte(intn(r, 256))
+		default:
+			mask := byte(0xff >> fixed)
+			b[i] = b[i]&^mask | byte(intn(r, 256))&mask
+		}
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
+
+// IPv4 returns a random address from the documentation ranges (RFC 5737),
+// safe to store without pointing at real hosts.
+func IPv4(r *rand.Rand) netip.Addr {
+	return IPInCIDR(r, pick(r, []string{"192.0.2.0/24", "198.51.100.0/24", "203.0.113.0/24"}))
+}
+
+// IPv6 returns a random address from the documentation range 2001:db8::/32 (RFC 3849).
+func IPv6(r *rand.Rand) netip.Addr {
+	return IPInCIDR(r, "2001:db8::/32")
+}
+
+// MAC returns a random locally administered, unicast MAC address, which never
+// collides with a real vendor-assigned address.
+func MAC(r *rand.Rand) net.HardwareAddr {
+	mac := make(net.HardwareAddr, 6)
+	for i := range mac {
+		mac[i] = byte(intn(r, 256))
+	}
+	mac[0] = mac[0]&^0x01 | 0x02
+	return mac
+}
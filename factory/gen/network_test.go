@@ -0,0 +1,54 @@
+package gen
+
+import (
+	"math/rand"
+	"net/netip"
+	"testing"
+)
+
+func TestIPInCIDR(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, cidr := range []string{"10.0.0.0/8", "192.168.1.0/24", "172.16.0.0/12", "10.1.2.3/32", "2001:db8::/32", "fd00::/8"} {
+		prefix := netip.MustParsePrefix(cidr)
+		for i := 0; i < 50; i++ {
+			if ip := IPInCIDR(r, cidr); !prefix.Contains(ip) {
+				t.Fatalf("%s does not contain %s", cidr, ip)
+			}
+		}
+	}
+
+	seen := map[netip.Addr]bool{}
+	for i := 0; i < 50; i++ {
+		seen[IPInCIDR(r, "10.0.0.0/8")] = true
+	}
+	if len(seen) < 40 {
+		t.Fatalf("expected varied addresses, got %d unique", len(seen))
+	}
+
+	defer func() {
+		if rec := recover(); rec == nil {
+			t.Fatal("expected panic for invalid CIDR")
+		}
+	}()
+	IPInCIDR(r, "not-a-cidr")
+}
+
+func TestIPv4IPv6MAC(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 20; i++ {
+		if ip := IPv4(r); !ip.Is4() {
+			t.Fatalf("expected IPv4, got %s", ip)
+		}
+		if ip := IPv6(r); !netip.MustParsePrefix("2001:db8::/32").Contains(ip) {
+			t.Fatalf("expected documentation IPv6, got %s", ip)
+		}
+		mac := MAC(r)
+		if len(mac) != 6 || mac[0]&0x01 != 0 || mac[0]&0x02 == 0 {
+			t.Fatalf("expected locally administered unicast MAC, got %s", mac)
+		}
+	}
+
+	if UserAgent(r) == "" {
+		t.Fatal("expected a user agent")
+	}
+}
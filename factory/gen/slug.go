@@ -0,0 +1,32 @@
+package gen
+
+import (
+	"strconv"
+
+	"github.com/b3ndoi/factory-go/factory"
+)
+
+// UniqueSlug returns a trait that slugifies the field returned by from into the field
+// returned by to, appending -2, -3, ... on collision within namespace (common CMS
+// semantics). An empty slug becomes "untitled".
+// Add it with WithTraits so it runs after the source field is final.
+// Example: gen.UniqueSlug(reg, "posts.slug", func(p *Post) string { return p.Title }, func(p *Post) *string { return &p.Slug })
+func UniqueSlug[T any](reg *factory.UniqueRegistry, namespace string, from func(*T) string, to func(*T) *string) factory.Trait[T] {
+	return func(t *T) {
+		*to(t) = ClaimSlug(reg, namespace, from(t))
+	}
+}
+
+// ClaimSlug slugifies s and claims it in namespace, appending -2, -3, ... until the
+// slug is unclaimed. Returns the claimed slug.
+func ClaimSlug(reg *factory.UniqueRegistry, namespace, s string) string {
+	base := Slugify(s)
+	if base == "" {
+		base = "untitled"
+	}
+	slug := base
+	for n := 2; !reg.Claim(namespace, slug); n++ {
+		slug = base + "-" + strconv.Itoa(n)
+	}
+	return slug
+}
@@ -0,0 +1,53 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/b3ndoi/factory-go/factory"
+)
+
+type Article struct {
+	Title string
+	Slug  string
+}
+
+func TestUniqueSlug(t *testing.T) {
+	reg := factory.NewUniqueRegistry()
+	slug := UniqueSlug(reg, "articles.slug",
+		func(a *Article) string { return a.Title },
+		func(a *Article) *string { return &a.Slug },
+	)
+
+	f := factory.New(func(seq int64) Article {
+		return Article{Title: "Hello World!"}
+	}).WithTraits(slug)
+
+	articles := f.MakeMany(3)
+	want := []string{"hello-world", "hello-world-2", "hello-world-3"}
+	for i, a := range articles {
+		if a.Slug != want[i] {
+			t.Fatalf("article %d: expected %q, got %q", i, want[i], a.Slug)
+		}
+	}
+
+	// A second factory sharing the namespace continues the numbering
+	other := factory.New(func(seq int64) Article {
+		return Article{Title: "hello world"}
+	}).WithTraits(slug)
+	if a := other.Make(); a.Slug != "hello-world-4" {
+		t.Fatalf("expected hello-world-4, got %q", a.Slug)
+	}
+}
+
+func TestClaimSlug(t *testing.T) {
+	reg := factory.NewUniqueRegistry()
+	if got := ClaimSlug(reg, "ns", "!!!"); got != "untitled" {
+		t.Fatalf("expected untitled, got %q", got)
+	}
+	if got := ClaimSlug(reg, "ns", ""); got != "untitled-2" {
+		t.Fatalf("expected untitled-2, got %q", got)
+	}
+	if got := ClaimSlug(reg, "other", "!!!"); got != "untitled" {
+		t.Fatalf("expected namespaces to be independent, got %q", got)
+	}
+}
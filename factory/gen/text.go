@@ -0,0 +1,140 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"unicode"
+)
+
+var words = []string{
+	"go", "factory", "data", "seed", "test", "build", "model", "value", "stream", "cloud",
+	"quick", "simple", "modern", "guide", "deep", "dive", "practical", "patterns", "scaling", "release",
+	"notes", "design", "system", "service", "engine", "query", "cache", "index", "review", "update",
+}
+
+var emoji = []string{"😀", "🚀", "🎉", "👍🏽", "❤️", "🔥", "👨‍👩‍👧", "🇯🇵", "✅", "🤖"}
+
+var rtl = []string{"مرحبا بالعالم", "שלום עולם", "سلام دنیا", "خوش آمدید"}
+
+// Words returns n random lowercase words separated by spaces.
+func Words(r *rand.Rand, n int) string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = pick(r, words)
+	}
+	return strings.Join(out, " ")
+}
+
+// Sentence returns a capitalized sentence of n words ending with a period.
+func Sentence(r *rand.Rand, n int) string {
+	return capitalize(Words(r, n)) + "."
+}
+
+// Paragraph returns a paragraph of n sentences of 6-12 words each.
+func Paragraph(r *rand.Rand, n int) string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = Sentence(r, 6+intn(r, 7))
+	}
+	return strings.Join(out, " ")
+}
+
+// Title returns a title-cased headline of 3-7 words.
+func Title(r *rand.Rand) string {
+	ws := strings.Fields(Words(r, 3+intn(r, 5)))
+	for i, w := range ws {
+		ws[i] = capitalize(w)
+	}
+	return strings.Join(ws, " ")
+}
+
+// Slugify converts s to a lowercase, hyphen-separated URL slug.
+// Letters and digits are kept (including non-ASCII); everything else separates words.
+func Slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, c := range strings.ToLower(s) {
+		if unicode.IsLetter(c) || unicode.IsDigit(c) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(c)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return b.String()
+}
+
+// Markdown returns a markdown body with a heading, paragraphs, a list, and a code block.
+func Markdown(r *rand.Rand) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", Title(r))
+	fmt.Fprintf(&b, "%s\n\n", Paragraph(r, 2+intn(r, 2)))
+	fmt.Fprintf(&b, "## %s\n\n", Title(r))
+	for i := 0; i < 3; i++ {
+		fmt.Fprintf(&b, "- %s\n", Sentence(r, 4))
+	}
+	fmt.Fprintf(&b, "\n```go\nfmt.Println(%q)\n```\n\n", Words(r, 2))
+	fmt.Fprintf(&b, "%s\n", Paragraph(r, 1))
+	return b.String()
+}
+
+// HTML returns a small HTML snippet with headings, paragraphs, a link, and a list.
+func HTML(r *rand.Rand) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<h2>%s</h2>\n", Title(r))
+	fmt.Fprintf(&b, "<p>%s <a href=\"https://example.com/%s\">%s</a></p>\n", Sentence(r, 8), Slugify(Words(r, 2)), Words(r, 2))
+	b.WriteString("<ul>\n")
+	for i := 0; i < 3; i++ {
+		fmt.Fprintf(&b, "  <li>%s</li>\n", Sentence(r, 3))
+	}
+	b.WriteString("</ul>\n")
+	return b.String()
+}
+
+// Emoji returns a sentence of n words with emoji (including multi-codepoint sequences)
+// mixed in, for testing byte vs rune vs grapheme handling.
+func Emoji(r *rand.Rand, n int) string {
+	out := make([]string, n)
+	for i := range out {
+		if intn(r, 2) == 0 {
+			out[i] = pick(r, emoji)
+		} else {
+			out[i] = pick(r, words)
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// RTL returns right-to-left text (Arabic, Hebrew, Persian), optionally mixed with
+// left-to-right words when mixed is true.
+func RTL(r *rand.Rand, mixed bool) string {
+	s := pick(r, rtl)
+	if mixed {
+		s += " " + Words(r, 2) + " " + pick(r, rtl)
+	}
+	return s
+}
+
+// Long returns a string of exactly n bytes built from words, for testing column
+// limits and truncation.
+func Long(r *rand.Rand, n int) string {
+	var b strings.Builder
+	for b.Len() < n {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(pick(r, words))
+	}
+	return b.String()[:n]
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
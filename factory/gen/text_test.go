@@ -0,0 +1,80 @@
+package gen
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTextGenerators(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	if got := len(strings.Fields(Words(r, 5))); got != 5 {
+		t.Fatalf("expected 5 words, got %d", got)
+	}
+
+	s := Sentence(r, 4)
+	if !strings.HasSuffix(s, ".") || s[0] < 'A' || s[0] > 'Z' {
+		t.Fatalf("unexpected sentence %q", s)
+	}
+
+	if got := strings.Count(Paragraph(r, 3), "."); got != 3 {
+		t.Fatalf("expected 3 sentences, got %d", got)
+	}
+
+	title := Title(r)
+	for _, w := range strings.Fields(title) {
+		if w[0] < 'A' || w[0] > 'Z' {
+			t.Fatalf("expected title case, got %q", title)
+		}
+	}
+
+	md := Markdown(r)
+	for _, want := range []string{"# ", "## ", "- ", "```go"} {
+		if !strings.Contains(md, want) {
+			t.Fatalf("expected markdown to contain %q:\n%s", want, md)
+		}
+	}
+
+	html := HTML(r)
+	for _, want := range []string{"<h2>", "<p>", "<a href=", "<li>"} {
+		if !strings.Contains(html, want) {
+			t.Fatalf("expected HTML to contain %q:\n%s", want, html)
+		}
+	}
+
+	if e := Emoji(r, 20); utf8.RuneCountInString(e) == len(e) {
+		t.Fatalf("expected multi-byte runes in %q", e)
+	}
+
+	if s := RTL(r, true); !strings.ContainsAny(s, "abcdefghijklmnopqrstuvwxyz") {
+		t.Fatalf("expected mixed-direction text, got %q", s)
+	}
+
+	if got := len(Long(r, 10000)); got != 10000 {
+		t.Fatalf("expected 10000 bytes, got %d", got)
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Hello, World!":        "hello-world",
+		"  Go 1.21 Released  ": "go-1-21-released",
+		"Ünïcödé Title":        "ünïcödé-title",
+		"---":                  "",
+	}
+	for in, want := range tests {
+		if got := Slugify(in); got != want {
+			t.Fatalf("Slugify(%q): expected %q, got %q", in, want, got)
+		}
+	}
+}
+
+func TestTextDeterministic(t *testing.T) {
+	a := Markdown(rand.New(rand.NewSource(42)))
+	b := Markdown(rand.New(rand.NewSource(42)))
+	if a != b {
+		t.Fatal("expected same seed to produce the same text")
+	}
+}
@@ -0,0 +1,162 @@
+package gen
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/b3ndoi/factory-go/factory"
+)
+
+// Zones is a spread of IANA zones covering DST (both hemispheres), half- and
+// quarter-hour offsets, and the extremes of the offset range. They are loaded from the
+// system zone database; binaries that run without one (scratch or distroless images)
+// should import _ "time/tzdata" or build with -tags timetzdata. gen does not embed it,
+// to keep binaries that import gen small.
+var Zones = []string{
+	"UTC",
+	"America/New_York",
+	"America/Los_Angeles",
+	"America/St_Johns",
+	"America/Sao_Paulo",
+	"Europe/London",
+	"Europe/Berlin",
+	"Asia/Kolkata",
+	"Asia/Kathmandu",
+	"Asia/Tokyo",
+	"Australia/Adelaide",
+	"Pacific/Auckland",
+	"Pacific/Chatham",
+	"Pacific/Kiritimati",
+	"Pacific/Pago_Pago",
+}
+
+// DSTZones lists zones in Zones that observe daylight saving time.
+var DSTZones = []string{
+	"America/New_York",
+	"America/Los_Angeles",
+	"America/St_Johns",
+	"Europe/London",
+	"Europe/Berlin",
+	"Australia/Adelaide",
+	"Pacific/Auckland",
+	"Pacific/Chatham",
+}
+
+// Zone returns a random location from Zones.
+func Zone(r *rand.Rand) *time.Location {
+	return mustLoad(pick(r, Zones))
+}
+
+// FixedOffset returns a location with a random offset between -12:00 and +14:00
+// in 15-minute steps.
+func FixedOffset(r *rand.Rand) *time.Location {
+	const step = 15 * 60
+	minOffset, maxOffset := -12*3600, 14*3600
+	offset := minOffset + intn(r, (maxOffset-minOffset)/step+1)*step
+	return time.FixedZone("", offset)
+}
+
+// InZone returns the same instant as t, expressed in a random zone from Zones.
+func InZone(r *rand.Rand, t time.Time) time.Time {
+	return t.In(Zone(r))
+}
+
+// Between returns a random time in [from, to), in from's location.
+func Between(r *rand.Rand, from, to time.Time) time.Time {
+	span := to.Sub(from)
+	if span <= 0 {
+		return from
+	}
+	return from.Add(time.Duration(int63n(r, int64(span))))
+}
+
+// DSTTransitions returns the instants in year at which loc changes its UTC offset.
+func DSTTransitions(loc *time.Location, year int) []time.Time {
+	var out []time.Time
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+	end := start.AddDate(1, 0, 0)
+	prev := start
+	_, prevOffset := prev.Zone()
+	for t := start.Add(time.Hour); !t.After(end); t = t.Add(time.Hour) {
+		_, offset := t.Zone()
+		if offset != prevOffset {
+			out = append(out, findTransition(prev, t))
+			prevOffset = offset
+		}
+		prev = t
+	}
+	return out
+}
+
+// findTransition narrows [lo, hi] to the first instant with hi's offset.
+func findTransition(lo, hi time.Time) time.Time {
+	_, target := hi.Zone()
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2)
+		if _, offset := mid.Zone(); offset == target {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi.Truncate(time.Second)
+}
+
+// dstKey identifies a cached DSTTransitions result.
+type dstKey struct {
+	zone string
+	year int
+}
+
+// dstCache holds the zone and transitions per (zone, year) for AroundDST, which would
+// otherwise rescan the year on every call.
+var dstCache sync.Map // dstKey -> dstEntry
+
+type dstEntry struct {
+	loc         *time.Location
+	transitions []time.Time
+}
+
+// AroundDST returns a random time within ±window of a DST transition of a random
+// zone from DSTZones, in the given year.
+func AroundDST(r *rand.Rand, year int, window time.Duration) time.Time {
+	key := dstKey{zone: pick(r, DSTZones), year: year}
+	cached, ok := dstCache.Load(key)
+	if !ok {
+		loc := mustLoad(key.zone)
+		cached, _ = dstCache.LoadOrStore(key, dstEntry{loc: loc, transitions: DSTTransitions(loc, year)})
+	}
+	loc, transitions := cached.(dstEntry).loc, cached.(dstEntry).transitions
+	if len(transitions) == 0 {
+		return time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+	}
+	at := pick(r, transitions)
+	return Between(r, at.Add(-window), at.Add(window)).In(loc)
+}
+
+// AcrossDSTBoundary returns a trait that sets a time field to within an hour of a DST
+// transition in year, in a random DST-observing zone.
+// Example: factory.WithTraits(gen.AcrossDSTBoundary(rng, 2024, func(e *Event) *time.Time { return &e.StartsAt }))
+func AcrossDSTBoundary[T any](r *rand.Rand, year int, field func(*T) *time.Time) factory.Trait[T] {
+	return func(t *T) {
+		*field(t) = AroundDST(r, year, time.Hour)
+	}
+}
+
+// InVariedZones returns a trait that re-expresses a time field in a random zone,
+// keeping the instant. Exercises code that accidentally compares wall-clock values.
+func InVariedZones[T any](r *rand.Rand, field func(*T) *time.Time) factory.Trait[T] {
+	return func(t *T) {
+		p := field(t)
+		*p = InZone(r, *p)
+	}
+}
+
+func mustLoad(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic("gen: unknown time zone " + name + ": " + err.Error())
+	}
+	return loc
+}
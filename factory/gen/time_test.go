@@ -0,0 +1,100 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+	_ "time/tzdata" // The sandbox may lack a zone database
+
+	"github.com/b3ndoi/factory-go/factory"
+)
+
+type Event struct {
+	StartsAt time.Time
+}
+
+func TestZones(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		if Zone(r) == nil {
+			t.Fatal("expected a location")
+		}
+		_, offset := time.Now().In(FixedOffset(r)).Zone()
+		if offset < -12*3600 || offset > 14*3600 || offset%(15*60) != 0 {
+			t.Fatalf("unexpected fixed offset %d", offset)
+		}
+	}
+
+	now := time.Now()
+	if in := InZone(r, now); !in.Equal(now) {
+		t.Fatal("expected InZone to keep the instant")
+	}
+}
+
+func TestDSTTransitions(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+	got := DSTTransitions(ny, 2024)
+	want := []time.Time{
+		time.Date(2024, 3, 10, 7, 0, 0, 0, time.UTC),
+		time.Date(2024, 11, 3, 6, 0, 0, 0, time.UTC),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d transitions, got %v", len(want), got)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Fatalf("transition %d: expected %v, got %v", i, want[i], got[i].UTC())
+		}
+	}
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	if got := DSTTransitions(tokyo, 2024); len(got) != 0 {
+		t.Fatalf("expected no transitions for Tokyo, got %v", got)
+	}
+}
+
+func TestAcrossDSTBoundary(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	f := factory.New(func(seq int64) Event {
+		return Event{}
+	}).WithTraits(AcrossDSTBoundary(r, 2024, func(e *Event) *time.Time { return &e.StartsAt }))
+
+	for _, e := range f.MakeMany(20) {
+		near := false
+		for _, tr := range DSTTransitions(e.StartsAt.Location(), e.StartsAt.Year()) {
+			if d := e.StartsAt.Sub(tr); d >= -time.Hour && d <= time.Hour {
+				near = true
+			}
+		}
+		if !near {
+			t.Fatalf("expected %v to be within an hour of a DST transition", e.StartsAt)
+		}
+	}
+}
+
+func TestAroundDST_Reproducible(t *testing.T) {
+	a, b := rand.New(rand.NewSource(3)), rand.New(rand.NewSource(3))
+	for i := 0; i < 20; i++ {
+		if x, y := AroundDST(a, 2024, time.Hour), AroundDST(b, 2024, time.Hour); !x.Equal(y) || x.Location().String() != y.Location().String() {
+			t.Fatalf("expected the same time for the same seed, got %v and %v", x, y)
+		}
+	}
+}
+
+func TestInVariedZones(t *testing.T) {
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	f := factory.New(func(seq int64) Event {
+		return Event{StartsAt: base}
+	}).WithTraits(InVariedZones(rand.New(rand.NewSource(3)), func(e *Event) *time.Time { return &e.StartsAt }))
+
+	zones := map[string]bool{}
+	for _, e := range f.MakeMany(30) {
+		if !e.StartsAt.Equal(base) {
+			t.Fatal("expected the instant to be preserved")
+		}
+		zones[e.StartsAt.Location().String()] = true
+	}
+	if len(zones) < 3 {
+		t.Fatalf("expected varied zones, got %v", zones)
+	}
+}
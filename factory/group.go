@@ -0,0 +1,282 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Middleware wraps persistence for every factory in a Group, regardless of type.
+// item is the *T being saved; next performs the save and returns the persisted *T.
+type Middleware func(ctx context.Context, item any, next func(ctx context.Context) (any, error)) (any, error)
+
+// Group holds project-wide settings shared by the factories bound to it, so
+// conventions (clock, randomness, logging, persist middleware, scope traits) are
+// configured once rather than per factory.
+type Group struct {
+	mu         sync.RWMutex // Guards the settings below; rngMu guards rng and seed
+	now        func() time.Time
+	logger     *slog.Logger
+	middleware []Middleware
+	scopes     []func(item any)
+	states     map[string]groupState // Cross-cutting states (see DefineGroupState)
+	rngMu      sync.Mutex
+	rng        *rand.Rand
+	seed       int64 // Set by WithSeed (0 means time-seeded)
+}
+
+// groupState is a state shared by every member factory whose item type fits.
+type groupState struct {
+	fits  func(item any) bool
+	apply func(item any)
+}
+
+// NewGroup creates a group using time.Now and a time-seeded random source.
+func NewGroup() *Group {
+	return &Group{
+		now: time.Now,
+		rng: rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec // test data, not security-sensitive
+	}
+}
+
+// NewIn constructs a factory bound to g (Go methods cannot take type parameters,
+// so this is the equivalent of group.New).
+// Example: userFactory := NewIn(g, func(seq int64) User { return User{CreatedAt: g.Now()} })
+func NewIn[T any](g *Group, makeFn func(seq int64) T) *Factory[T] {
+	f := New(makeFn)
+	f.group = g
+	// Scope traits run first so defaults and traits can override them
+	f.defaults = append(f.defaults, func(_ int64, t *T) {
+		g.mu.RLock()
+		scopes := g.scopes
+		g.mu.RUnlock()
+		for _, scope := range scopes {
+			scope(t)
+		}
+	})
+	return f
+}
+
+// WithClock sets the clock returned by Now (e.g., a fixed time for reproducible seeds).
+func (g *Group) WithClock(now func() time.Time) *Group {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.now = now
+	return g
+}
+
+// WithSeed replaces the group's random source with one seeded by seed.
+func (g *Group) WithSeed(seed int64) *Group {
+	g.rngMu.Lock()
+	defer g.rngMu.Unlock()
+	g.rng = rand.New(rand.NewSource(seed)) //nolint:gosec // test data, not security-sensitive
+	g.seed = seed
+	return g
+}
+
+// Seed returns the seed set by WithSeed, or 0 for a time-seeded group.
+func (g *Group) Seed() int64 {
+	g.rngMu.Lock()
+	defer g.rngMu.Unlock()
+	return g.seed
+}
+
+// fork returns a private copy of g's settings with its own random source seeded by
+// seed and a clock fixed at now, leaving g untouched.
+func (g *Group) fork(seed int64, now time.Time) *Group {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return &Group{
+		now:        func() time.Time { return now },
+		logger:     g.logger,
+		middleware: g.middleware,
+		scopes:     g.scopes,
+		states:     g.states,
+		rng:        rand.New(rand.NewSource(seed)), //nolint:gosec // test data, not security-sensitive
+		seed:       seed,
+	}
+}
+
+// WithLogger sets a logger that records each item created by the group's factories.
+func (g *Group) WithLogger(l *slog.Logger) *Group {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.logger = l
+	return g
+}
+
+// Use appends persist middleware applied to every factory in the group.
+// The first middleware added is the outermost.
+func (g *Group) Use(mw ...Middleware) *Group {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.middleware = grow(g.middleware, mw...)
+	return g
+}
+
+// WithScope adds traits applied to every item built by the group's factories, before
+// their defaults. item is a pointer (*T); use a type switch or interface assertion.
+// Example: g.WithScope(func(item any) { if t, ok := item.(interface{ SetTenant(string) }); ok { t.SetTenant("acme") } })
+func (g *Group) WithScope(scopes ...func(item any)) *Group {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.scopes = grow(g.scopes, scopes...)
+	return g
+}
+
+// DefineGroupState registers a named state on g for every member factory whose item
+// pointer (*T) implements I, so cross-cutting states such as "soft deleted" or
+// "for tenant X" are defined once. Member factories resolve it with State, TryState,
+// and Apply, and list it in States; a state of the same name defined on the factory
+// itself takes precedence. Factories whose type does not implement I do not see it.
+// (Go methods cannot take type parameters, so this is a function.)
+// Example: DefineGroupState(g, "deleted", func(m interface{ SoftDelete() }) { m.SoftDelete() })
+func DefineGroupState[I any](g *Group, name string, fn func(I)) *Group {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	// Copy on write, so Factory.States can range over a snapshot
+	states := make(map[string]groupState, len(g.states)+1)
+	for k, v := range g.states {
+		states[k] = v
+	}
+	g.states = states
+	g.states[name] = groupState{
+		fits: func(item any) bool {
+			_, ok := item.(I)
+			return ok
+		},
+		apply: func(item any) { fn(item.(I)) },
+	}
+	return g
+}
+
+// Now returns the current time from the group's clock.
+func (g *Group) Now() time.Time {
+	g.mu.RLock()
+	now := g.now
+	g.mu.RUnlock()
+	return now()
+}
+
+// Intn returns a random int in [0, n) from the group's source. Safe for concurrent use.
+func (g *Group) Intn(n int) int {
+	g.rngMu.Lock()
+	defer g.rngMu.Unlock()
+	return g.rng.Intn(n)
+}
+
+// Float64 returns a random float in [0, 1) from the group's source. Safe for concurrent use.
+func (g *Group) Float64() float64 {
+	g.rngMu.Lock()
+	defer g.rngMu.Unlock()
+	return g.rng.Float64()
+}
+
+// Shuffle shuffles s in place using g's random source, so seeders that pick
+// "random users for random posts" stay reproducible under WithSeed.
+// A nil g uses the math/rand global source.
+func Shuffle[E any](g *Group, s []E) {
+	swap := func(i, j int) { s[i], s[j] = s[j], s[i] }
+	if g == nil {
+		rand.Shuffle(len(s), swap) //nolint:gosec // test data, not security-sensitive
+		return
+	}
+	g.rngMu.Lock()
+	defer g.rngMu.Unlock()
+	g.rng.Shuffle(len(s), swap)
+}
+
+// SampleN returns n distinct elements of s in random order using g's random source
+// (all of s, shuffled, when n >= len(s)). s is not modified. A nil g uses the
+// math/rand global source. Panics if n is negative.
+// Example: for _, post := range factory.SampleN(g, posts, 3) { ... }
+func SampleN[E any](g *Group, s []E, n int) []E {
+	if n < 0 {
+		panic(fmt.Sprintf("factory: SampleN count %d is negative", n))
+	}
+	out := append([]E{}, s...)
+	if n > len(out) {
+		n = len(out)
+	}
+	// Partial Fisher-Yates: only the first n positions need to be random
+	for i := 0; i < n; i++ {
+		j := i + g.intn(len(out)-i)
+		out[i], out[j] = out[j], out[i]
+	}
+	return out[:n]
+}
+
+// intn is Intn that falls back to the math/rand global source for a nil group.
+func (g *Group) intn(n int) int {
+	if g == nil {
+		return rand.Intn(n) //nolint:gosec // test data, not security-sensitive
+	}
+	return g.Intn(n)
+}
+
+// float64 is Float64 that falls back to the math/rand global source for a nil group.
+func (g *Group) float64() float64 {
+	if g == nil {
+		return rand.Float64() //nolint:gosec // test data, not security-sensitive
+	}
+	return g.Float64()
+}
+
+// groupState returns the group state name as a trait for T, if the factory's group
+// defines one that T fits.
+func (f *Factory[T]) groupState(name string) (Trait[T], bool) {
+	if f.group == nil {
+		return nil, false
+	}
+	f.group.mu.RLock()
+	gs, ok := f.group.states[name]
+	f.group.mu.RUnlock()
+	if !ok || !gs.fits(new(T)) {
+		return nil, false
+	}
+	return func(t *T) { gs.apply(t) }, true
+}
+
+// Group returns the group the factory is bound to, or nil.
+func (f *Factory[T]) Group() *Group {
+	return f.group
+}
+
+// wrapPersist applies the group's middleware and logging around p.
+func wrapPersist[T any](g *Group, p PersistFn[T]) PersistFn[T] {
+	g.mu.RLock()
+	middleware, logger := g.middleware, g.logger
+	g.mu.RUnlock()
+	for i := len(middleware) - 1; i >= 0; i-- {
+		mw, next := middleware[i], p
+		p = func(ctx context.Context, t *T) (*T, error) {
+			out, err := mw(ctx, t, func(ctx context.Context) (any, error) {
+				return next(ctx, t)
+			})
+			if out == nil {
+				return nil, err
+			}
+			res, ok := out.(*T)
+			if !ok {
+				return nil, fmt.Errorf("factory: middleware returned %T, want %T", out, t)
+			}
+			return res, err
+		}
+	}
+	if logger == nil {
+		return p
+	}
+	inner := p
+	return func(ctx context.Context, t *T) (*T, error) {
+		out, err := inner(ctx, t)
+		if err != nil {
+			logger.ErrorContext(ctx, "factory: create failed", "type", fmt.Sprintf("%T", *t), "error", err)
+			return out, err
+		}
+		logger.DebugContext(ctx, "factory: created", "type", fmt.Sprintf("%T", *t))
+		return out, nil
+	}
+}
@@ -0,0 +1,257 @@
+package factory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type Tenanted interface {
+	SetTenant(string)
+}
+
+type Invoice struct {
+	ID       string
+	Tenant   string
+	IssuedAt time.Time
+}
+
+func (i *Invoice) SetTenant(t string) { i.Tenant = t }
+
+type Account struct {
+	Tenant string
+}
+
+func (a *Account) SetTenant(t string) { a.Tenant = t }
+
+func TestGroup_SharedSettings(t *testing.T) {
+	fixed := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var calls []string
+	var logs bytes.Buffer
+
+	g := NewGroup().
+		WithClock(func() time.Time { return fixed }).
+		WithLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))).
+		WithScope(func(item any) {
+			if t, ok := item.(Tenanted); ok {
+				t.SetTenant("acme")
+			}
+		}).
+		Use(func(ctx context.Context, item any, next func(context.Context) (any, error)) (any, error) {
+			calls = append(calls, fmt.Sprintf("outer:%T", item))
+			return next(ctx)
+		}, func(ctx context.Context, item any, next func(context.Context) (any, error)) (any, error) {
+			calls = append(calls, "inner")
+			return next(ctx)
+		})
+
+	invoiceFactory := NewIn(g, func(seq int64) Invoice {
+		return Invoice{IssuedAt: g.Now()}
+	}).WithPersist(func(ctx context.Context, i *Invoice) (*Invoice, error) {
+		i.ID = "inv-1"
+		return i, nil
+	})
+	accountFactory := NewIn(g, func(seq int64) Account {
+		return Account{}
+	}).WithPersist(func(ctx context.Context, a *Account) (*Account, error) {
+		return a, nil
+	})
+
+	ctx := context.Background()
+	inv := invoiceFactory.MustCreate(ctx)
+	acct := accountFactory.MustCreate(ctx)
+
+	if inv.Tenant != "acme" || acct.Tenant != "acme" {
+		t.Fatalf("expected scope to apply to both factories, got %+v and %+v", inv, acct)
+	}
+	if !inv.IssuedAt.Equal(fixed) {
+		t.Fatalf("expected group clock, got %v", inv.IssuedAt)
+	}
+	if inv.ID != "inv-1" {
+		t.Fatalf("expected persisted invoice, got %+v", inv)
+	}
+	want := "outer:*factory.Invoice,inner,outer:*factory.Account,inner"
+	if got := strings.Join(calls, ","); got != want {
+		t.Fatalf("expected middleware order %q, got %q", want, got)
+	}
+	if strings.Count(logs.String(), "factory: created") != 2 {
+		t.Fatalf("expected 2 create log lines, got:\n%s", logs.String())
+	}
+	if accountFactory.Group() != g || New(func(int64) User { return User{} }).Group() != nil {
+		t.Fatal("unexpected Group()")
+	}
+}
+
+func TestGroup_ScopeOverriddenByDefaults(t *testing.T) {
+	g := NewGroup().WithScope(func(item any) {
+		if i, ok := item.(*Invoice); ok {
+			i.Tenant = "scope"
+		}
+	})
+
+	inv := NewIn(g, func(seq int64) Invoice {
+		return Invoice{}
+	}).WithDefaults(func(i *Invoice) {
+		i.Tenant = "default"
+	}).Make()
+
+	if inv.Tenant != "default" {
+		t.Fatalf("expected defaults to override scope, got %q", inv.Tenant)
+	}
+}
+
+func TestGroup_MiddlewareErrors(t *testing.T) {
+	boom := errors.New("boom")
+	g := NewGroup().Use(func(ctx context.Context, item any, next func(context.Context) (any, error)) (any, error) {
+		return nil, boom
+	})
+	f := NewIn(g, func(seq int64) User {
+		return User{}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		t.Fatal("expected middleware to short-circuit persist")
+		return u, nil
+	})
+	if _, err := f.Create(context.Background()); !errors.Is(err, boom) {
+		t.Fatalf("expected middleware error, got %v", err)
+	}
+
+	wrongType := NewGroup().Use(func(ctx context.Context, item any, next func(context.Context) (any, error)) (any, error) {
+		return "not a user", nil
+	})
+	f = NewIn(wrongType, func(seq int64) User {
+		return User{}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		return u, nil
+	})
+	if _, err := f.Create(context.Background()); err == nil {
+		t.Fatal("expected error for middleware returning the wrong type")
+	}
+}
+
+func TestGroup_SeededRand(t *testing.T) {
+	a := NewGroup().WithSeed(1)
+	b := NewGroup().WithSeed(1)
+	for i := 0; i < 5; i++ {
+		if a.Intn(1000) != b.Intn(1000) || a.Float64() != b.Float64() {
+			t.Fatal("expected same seed to produce same values")
+		}
+	}
+}
+
+func TestGroup_ShuffleAndSampleN(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+
+	run := func() ([]int, []int) {
+		g := NewGroup().WithSeed(42)
+		shuffled := append([]int{}, items...)
+		Shuffle(g, shuffled)
+		return shuffled, SampleN(g, items, 3)
+	}
+
+	shuffled1, sample1 := run()
+	shuffled2, sample2 := run()
+	if fmt.Sprint(shuffled1) != fmt.Sprint(shuffled2) || fmt.Sprint(sample1) != fmt.Sprint(sample2) {
+		t.Fatalf("expected same seed to give same order, got %v/%v and %v/%v", shuffled1, sample1, shuffled2, sample2)
+	}
+
+	if len(sample1) != 3 {
+		t.Fatalf("expected 3 sampled items, got %v", sample1)
+	}
+	seen := map[int]bool{}
+	for _, v := range sample1 {
+		if seen[v] {
+			t.Fatalf("expected distinct samples, got %v", sample1)
+		}
+		seen[v] = true
+	}
+	if fmt.Sprint(items) != "[1 2 3 4 5 6 7 8]" {
+		t.Fatalf("expected SampleN not to modify input, got %v", items)
+	}
+
+	if all := SampleN(nil, items, 20); len(all) != len(items) {
+		t.Fatalf("expected all items when n exceeds length, got %v", all)
+	}
+}
+
+func TestSampleN_NegativePanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "negative") {
+			t.Fatalf("expected a panic explaining the negative count, got %v", r)
+		}
+	}()
+	SampleN(nil, []int{1, 2, 3}, -1)
+}
+
+func TestGroup_DefineGroupState(t *testing.T) {
+	g := DefineGroupState(NewGroup(), "for-globex", func(m Tenanted) { m.SetTenant("globex") })
+
+	invoices := NewIn(g, func(seq int64) Invoice { return Invoice{} })
+	accounts := NewIn(g, func(seq int64) Account { return Account{} }).
+		DefineState("for-globex", func(a *Account) { a.Tenant = "own" })
+	users := NewIn(g, func(seq int64) User { return User{} })
+
+	if inv := invoices.State("for-globex").Make(); inv.Tenant != "globex" {
+		t.Fatalf("expected group state applied, got %q", inv.Tenant)
+	}
+	if inv := invoices.Make(invoices.Apply("for-globex")); inv.Tenant != "globex" {
+		t.Fatalf("expected Apply to resolve group state, got %q", inv.Tenant)
+	}
+	if strings.Join(invoices.States(), ",") != "for-globex" {
+		t.Fatalf("expected group state listed, got %v", invoices.States())
+	}
+
+	// The factory's own state of the same name wins
+	if acc := accounts.State("for-globex").Make(); acc.Tenant != "own" {
+		t.Fatalf("expected factory state to take precedence, got %q", acc.Tenant)
+	}
+
+	// Types that do not implement the interface do not see it
+	if _, err := users.TryState("for-globex"); !errors.Is(err, ErrUnknownState) {
+		t.Fatalf("expected ErrUnknownState for non-matching type, got %v", err)
+	}
+	if len(users.States()) != 0 {
+		t.Fatalf("expected no states for User, got %v", users.States())
+	}
+}
+
+func TestGroup_ConcurrentConfig(t *testing.T) {
+	g := NewGroup().WithSeed(1)
+	invoiceFactory := NewIn(g, func(seq int64) Invoice {
+		return Invoice{IssuedAt: g.Now()}
+	}).WithPersist(func(ctx context.Context, i *Invoice) (*Invoice, error) { return i, nil })
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				invoiceFactory.MustCreate(ctx)
+				invoiceFactory.States()
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for j := 0; j < 20; j++ {
+			g.WithClock(time.Now).
+				WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))).
+				WithScope(func(item any) {}).
+				Use(func(ctx context.Context, item any, next func(context.Context) (any, error)) (any, error) {
+					return next(ctx)
+				})
+			DefineGroupState(g, fmt.Sprintf("s%d", j), func(Tenanted) {})
+			invoiceFactory.Examples(1)
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,83 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+)
+
+// EnvironmentEnv is the variable WithEnvironmentGuard reads by default.
+const EnvironmentEnv = "FACTORY_ENV"
+
+// WithEnvironmentGuard makes Create (and every method built on it) fail with
+// ErrEnvironmentNotAllowed unless the current environment is one of allowed,
+// protecting teams that wire factories into shared binaries from seeding
+// production by accident. The environment is read from FACTORY_ENV at Create
+// time (see WithEnvironmentFunc); an unset environment is not allowed.
+// Make and Raw are never guarded. Use AllowAnyEnvironment to override per call.
+// Example: userFactory.WithEnvironmentGuard("local", "test", "staging")
+func (f *Factory[T]) WithEnvironmentGuard(allowed ...string) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	if len(allowed) == 0 {
+		panic("factory: WithEnvironmentGuard requires at least one environment")
+	}
+	f.envAllowed = append([]string{}, allowed...)
+	return f
+}
+
+// WithEnvironmentFunc sets how the guard learns the current environment (e.g.,
+// from the app's config instead of FACTORY_ENV).
+func (f *Factory[T]) WithEnvironmentFunc(fn func() string) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.envFn = fn
+	return f
+}
+
+type allowAnyEnvKey struct{}
+
+// AllowAnyEnvironment returns a context that bypasses WithEnvironmentGuard, for
+// deliberate one-off seeding (e.g., a reviewed demo-data job). reason must not be
+// empty; each create the guard would have rejected logs a warning with it, the
+// factory name, and the environment (to the group's logger, or slog.Default).
+func AllowAnyEnvironment(ctx context.Context, reason string) context.Context {
+	if reason == "" {
+		panic("factory: AllowAnyEnvironment requires a reason")
+	}
+	return context.WithValue(ctx, allowAnyEnvKey{}, reason)
+}
+
+// checkEnvironment returns ErrEnvironmentNotAllowed when the guard rejects this call.
+func (f *Factory[T]) checkEnvironment(ctx context.Context) error {
+	v := f.view()
+	f = &v
+	if f.envAllowed == nil {
+		return nil
+	}
+	env := os.Getenv(EnvironmentEnv)
+	if f.envFn != nil {
+		env = f.envFn()
+	}
+	if slices.Contains(f.envAllowed, env) {
+		return nil
+	}
+	if reason, ok := ctx.Value(allowAnyEnvKey{}).(string); ok {
+		logger := slog.Default()
+		if f.group != nil {
+			f.group.mu.RLock()
+			if f.group.logger != nil {
+				logger = f.group.logger
+			}
+			f.group.mu.RUnlock()
+		}
+		logger.WarnContext(ctx, "factory: environment guard bypassed", "factory", f.Name(), "environment", env, "reason", reason)
+		return nil
+	}
+	if env == "" {
+		return fmt.Errorf("%w: environment is not set (allowed: %v)", ErrEnvironmentNotAllowed, f.envAllowed)
+	}
+	return fmt.Errorf("%w: %q (allowed: %v)", ErrEnvironmentNotAllowed, env, f.envAllowed)
+}
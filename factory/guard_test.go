@@ -0,0 +1,86 @@
+package factory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestFactory_WithEnvironmentGuard(t *testing.T) {
+	saved := 0
+	f := New(func(seq int64) User { return User{} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) {
+			saved++
+			return u, nil
+		}).
+		WithEnvironmentGuard("local", "test")
+	ctx := context.Background()
+
+	t.Setenv(EnvironmentEnv, "production")
+	_, err := f.Create(ctx)
+	if !errors.Is(err, ErrEnvironmentNotAllowed) || !strings.Contains(err.Error(), `"production"`) {
+		t.Fatalf("expected ErrEnvironmentNotAllowed, got %v", err)
+	}
+	if _, err := f.CreateMany(ctx, 3); !errors.Is(err, ErrEnvironmentNotAllowed) {
+		t.Fatalf("expected CreateMany to be guarded, got %v", err)
+	}
+	if saved != 0 {
+		t.Fatalf("expected nothing saved, got %d", saved)
+	}
+	f.Make() // never guarded
+
+	if _, err := f.Create(AllowAnyEnvironment(ctx, "reviewed demo seed")); err != nil {
+		t.Fatalf("expected override to allow creation, got %v", err)
+	}
+
+	t.Setenv(EnvironmentEnv, "test")
+	if _, err := f.Create(ctx); err != nil {
+		t.Fatalf("expected test environment to be allowed, got %v", err)
+	}
+
+	t.Setenv(EnvironmentEnv, "")
+	if _, err := f.Create(ctx); !errors.Is(err, ErrEnvironmentNotAllowed) {
+		t.Fatalf("expected unset environment to be rejected, got %v", err)
+	}
+
+	env := "local"
+	g := f.Clone().WithEnvironmentFunc(func() string { return env })
+	if _, err := g.Create(ctx); err != nil {
+		t.Fatalf("expected callback environment to be allowed, got %v", err)
+	}
+	env = "prod"
+	if _, err := g.Create(ctx); !errors.Is(err, ErrEnvironmentNotAllowed) {
+		t.Fatalf("expected callback environment to be rejected, got %v", err)
+	}
+	if saved != 3 {
+		t.Fatalf("expected 3 saves, got %d", saved)
+	}
+}
+
+func TestFactory_AllowAnyEnvironmentLogsReason(t *testing.T) {
+	var logs bytes.Buffer
+	g := NewGroup().WithLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+	f := NewIn(g, func(seq int64) User { return User{} }).
+		WithName("users").
+		WithPersist(func(ctx context.Context, u *User) (*User, error) { return u, nil }).
+		WithEnvironmentGuard("test")
+	ctx := AllowAnyEnvironment(context.Background(), "reviewed demo seed")
+
+	t.Setenv(EnvironmentEnv, "test")
+	if _, err := f.Create(ctx); err != nil || logs.Len() != 0 {
+		t.Fatalf("expected no warning when the environment is allowed, got %v, %q", err, logs.String())
+	}
+
+	t.Setenv(EnvironmentEnv, "production")
+	if _, err := f.Create(ctx); err != nil {
+		t.Fatalf("expected override to allow creation, got %v", err)
+	}
+	for _, want := range []string{"environment guard bypassed", "factory=users", "environment=production", `reason="reviewed demo seed"`} {
+		if !strings.Contains(logs.String(), want) {
+			t.Fatalf("expected log to contain %q, got %q", want, logs.String())
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HistoryEntry is one prior version of a record in a generated audit trail.
+type HistoryEntry[T any] struct {
+	Version int       // 1 is the oldest; the current record is version len(trail)+1
+	At      time.Time // When this version was written
+	Record  T         // The record as it was at this version
+	Changed []string  // Fields that differ in the next version (what the next edit changed)
+}
+
+// History generates a plausible trail of n prior versions of record, oldest first.
+// Working back from record, each earlier version replaces one or two fields with
+// values from a freshly built item. ID, foreign key (…ID), and CreatedAt fields are
+// never changed; if no other field differs, Changed is empty.
+// Versions are one day apart, the newest a day before the group clock's Now (or
+// time.Now when ungrouped). T must be a struct.
+// Example: trail := userFactory.History(*user, 3)
+func (f *Factory[T]) History(record T, n int) []HistoryEntry[T] {
+	v := f.view()
+	f = &v
+	now := time.Now()
+	if f.group != nil {
+		now = f.group.Now()
+	}
+
+	trail := make([]HistoryEntry[T], n)
+	next := record
+	dry := f.dryRun()
+	for version := n; version >= 1; version-- {
+		prev := next
+		changed := perturb(f.group, &prev, dry.Make())
+		trail[version-1] = HistoryEntry[T]{
+			Version: version,
+			At:      now.Add(-time.Duration(n-version+1) * 24 * time.Hour),
+			Record:  prev,
+			Changed: changed,
+		}
+		next = prev
+	}
+	return trail
+}
+
+// WithHistory returns a copy of f that, after each record is created, creates a
+// generated audit trail of versions entries through history, oldest first.
+// link fills one history row from an entry (e.g., copy Record fields, At, and Changed).
+// Example:
+//
+//	WithHistory(postFactory, revisionFactory, 3, func(e HistoryEntry[Post], current *Post, r *Revision) {
+//		r.PostID, r.Version, r.Title, r.At = current.ID, e.Version, e.Record.Title, e.At
+//	})
+func WithHistory[T any, H any](f *Factory[T], history *Factory[H], versions int, link func(entry HistoryEntry[T], current *T, row *H)) *Factory[T] {
+	copy := f.shallow()
+	copy.relationHook(func(ctx context.Context, t *T) error {
+		for _, entry := range f.History(*t, versions) {
+			entry := entry
+			if _, err := history.Create(ctx, func(h *H) { link(entry, t, h) }); err != nil {
+				return fmt.Errorf("factory: history version %d: %w", entry.Version, err)
+			}
+		}
+		return nil
+	})
+	return copy
+}
+
+// perturb copies one or two editable fields that differ from fresh into prev and
+// returns their names.
+func perturb[T any](g *Group, prev *T, fresh T) []string {
+	pv := reflect.ValueOf(prev).Elem()
+	if pv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("factory: History requires a struct type, got %T", *prev))
+	}
+	fv := reflect.ValueOf(fresh)
+
+	var candidates []int
+	for i := 0; i < pv.NumField(); i++ {
+		sf := pv.Type().Field(i)
+		if !sf.IsExported() || strings.HasSuffix(sf.Name, "ID") || sf.Name == "CreatedAt" {
+			continue
+		}
+		if !reflect.DeepEqual(pv.Field(i).Interface(), fv.Field(i).Interface()) {
+			candidates = append(candidates, i)
+		}
+	}
+
+	picks := SampleN(g, candidates, 1+g.intn(2))
+	sort.Ints(picks) // Report in struct order
+	changed := make([]string, len(picks))
+	for j, i := range picks {
+		pv.Field(i).Set(fv.Field(i))
+		changed[j] = pv.Type().Field(i).Name
+	}
+	return changed
+}
@@ -0,0 +1,106 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type Document struct {
+	ID        string
+	OwnerID   string
+	Title     string
+	Body      string
+	Status    string
+	CreatedAt time.Time
+}
+
+type Revision struct {
+	DocumentID string
+	Version    int
+	Title      string
+	Changed    string
+	At         time.Time
+}
+
+func newDocumentFactory(g *Group) *Factory[Document] {
+	return NewIn(g, func(seq int64) Document {
+		return Document{
+			ID:      fmt.Sprintf("doc-%d", seq),
+			OwnerID: fmt.Sprintf("user-%d", seq),
+			Title:   fmt.Sprintf("Title %d", seq),
+			Body:    fmt.Sprintf("Body %d", seq),
+			Status:  []string{"draft", "review", "published"}[seq%3],
+		}
+	})
+}
+
+func TestFactory_History(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := NewGroup().WithSeed(7).WithClock(func() time.Time { return now })
+	f := newDocumentFactory(g)
+
+	current := f.Make()
+	trail := f.History(current, 3)
+	if len(trail) != 3 {
+		t.Fatalf("expected 3 versions, got %d", len(trail))
+	}
+
+	next := current
+	for i := len(trail) - 1; i >= 0; i-- {
+		e := trail[i]
+		if e.Version != i+1 {
+			t.Fatalf("expected version %d, got %d", i+1, e.Version)
+		}
+		if want := now.Add(-time.Duration(3-i) * 24 * time.Hour); !e.At.Equal(want) {
+			t.Fatalf("expected version %d at %v, got %v", e.Version, want, e.At)
+		}
+		if len(e.Changed) == 0 || len(e.Changed) > 2 {
+			t.Fatalf("expected 1-2 changed fields, got %v", e.Changed)
+		}
+		if e.Record.ID != current.ID || e.Record.OwnerID != current.OwnerID {
+			t.Fatalf("expected ID fields to be kept, got %+v", e.Record)
+		}
+		for _, field := range e.Changed {
+			if field == "ID" || field == "OwnerID" || field == "CreatedAt" {
+				t.Fatalf("expected %s never to change", field)
+			}
+		}
+		if e.Record == next {
+			t.Fatalf("expected version %d to differ from the next version", e.Version)
+		}
+		next = e.Record
+	}
+
+	// History does not advance the sequence
+	if doc := f.Make(); doc.ID != "doc-2" {
+		t.Fatalf("expected sequence to be untouched, got %s", doc.ID)
+	}
+}
+
+func TestWithHistory(t *testing.T) {
+	g := NewGroup().WithSeed(3)
+	var revisions []Revision
+	revisionFactory := New(func(seq int64) Revision {
+		return Revision{}
+	}).WithPersist(func(ctx context.Context, r *Revision) (*Revision, error) {
+		revisions = append(revisions, *r)
+		return r, nil
+	})
+	docs := newDocumentFactory(g).WithPersist(func(ctx context.Context, d *Document) (*Document, error) {
+		return d, nil
+	})
+
+	doc := WithHistory(docs, revisionFactory, 2, func(e HistoryEntry[Document], current *Document, r *Revision) {
+		r.DocumentID, r.Version, r.Title, r.At = current.ID, e.Version, e.Record.Title, e.At
+		r.Changed = fmt.Sprint(e.Changed)
+	}).MustCreate(context.Background())
+
+	if len(revisions) != 2 || revisions[0].Version != 1 || revisions[1].Version != 2 {
+		t.Fatalf("expected 2 revisions oldest first, got %+v", revisions)
+	}
+	if revisions[0].DocumentID != doc.ID || !revisions[0].At.Before(revisions[1].At) {
+		t.Fatalf("expected linked, time-ordered revisions, got %+v", revisions)
+	}
+}
@@ -0,0 +1,61 @@
+package factory
+
+// DisableTap removes the Tap function, so Make does not call it (e.g., a debugging
+// Tap left on a shared factory).
+func (f *Factory[T]) DisableTap() *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.tapFn = nil
+	return f
+}
+
+// DisableSequences removes the Sequence traits, so Make skips the cycling step.
+func (f *Factory[T]) DisableSequences() *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.sequences = nil
+	return f
+}
+
+// Compile returns a maker that builds items like Make() with no per-call traits, from a
+// snapshot of the factory. It skips per-call traits and WarnOverrides bookkeeping.
+// Example: next := userFactory.Clone().DisableTap().Compile(); for i := 0; i < n; i++ { send(next()) }
+func (f *Factory[T]) Compile() func() T {
+	v := f.view() // Later builder calls do not affect the snapshot
+	f = &v
+	makeFn := f.makeFn
+	defaults := append([]func(int64, *T){}, f.defaults...)
+	traits := append([]Trait[T]{}, f.traits...)
+	sequences := append([]Trait[T]{}, f.sequences...)
+	tap := f.tapFn
+	post := f.runPrefix != "" && len(f.prefixed) > 0 || f.idKey != nil
+	var stamp string
+	if f.provenance != nil {
+		stamp = f.Provenance().String()
+	}
+
+	return func() T {
+		seq := f.nextSeq()
+		t := makeFn(seq)
+		for _, d := range defaults {
+			d(seq, &t)
+		}
+		for _, tr := range traits {
+			tr(&t)
+		}
+		if len(sequences) > 0 {
+			sequences[int((seq-1)%int64(len(sequences)))](&t)
+		}
+		if post {
+			f.applyRunPrefix(&t)
+			f.applyDeterministicID(&t)
+		}
+		if stamp != "" {
+			*f.provenance(&t) = stamp
+		}
+		if tap != nil {
+			tap(t)
+		}
+		return t
+	}
+}
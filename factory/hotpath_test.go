@@ -0,0 +1,44 @@
+package factory
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFactory_Compile(t *testing.T) {
+	tapped := 0
+	f := New(func(seq int64) User { return User{ID: fmt.Sprint(seq)} }).
+		WithDefaults(func(u *User) { u.Name = "User" }).
+		WithTraits(func(u *User) { u.Email = "user@example.com" }).
+		Sequence(func(u *User) { u.Name += " A" }, func(u *User) { u.Name += " B" }).
+		Tap(func(User) { tapped++ })
+
+	next := f.Compile()
+	first, second := next(), next()
+	if first != f.Clone().Make() || second.ID != "2" || second.Name != "User B" {
+		t.Fatalf("expected Compile to match Make, got %+v and %+v", first, second)
+	}
+	if f.Make().ID != "3" || tapped != 4 {
+		t.Fatalf("expected a shared sequence and Tap calls, tapped=%d", tapped)
+	}
+
+	// Later changes do not affect a compiled maker
+	f.WithTraits(func(u *User) { u.Email = "changed" })
+	if next().Email != "user@example.com" {
+		t.Fatal("expected Compile to snapshot the factory")
+	}
+
+	// Toggles strip features from the hot path
+	lean := f.Clone().DisableTap().DisableSequences().Compile()
+	if u := lean(); u.Name != "User" || tapped != 5 {
+		t.Fatalf("expected no sequence or tap, got %+v (tapped=%d)", u, tapped)
+	}
+}
+
+func TestFactory_CompileRunPrefix(t *testing.T) {
+	f := New(func(seq int64) User { return User{Email: "a@example.com"} }).
+		WithRunPrefix("run-1-", func(u *User) *string { return &u.Email })
+	if u := f.Compile()(); u.Email != "run-1-a@example.com" {
+		t.Fatalf("expected run prefix, got %q", u.Email)
+	}
+}
@@ -0,0 +1,79 @@
+package factory
+
+import (
+	"context"
+	"sync"
+)
+
+// WithIdentity gives items a logical identity (e.g., email). Within one identity
+// scope (WithIdentityMap, or a Scenario run), creating an item whose identity was
+// already created returns the existing record instead of persisting a duplicate,
+// so a graph that references the same parent from several Has/For helpers creates
+// it exactly once. Hooks run only for the first creation. Outside a scope, every
+// Create persists as usual.
+// Example: userFactory.WithIdentity(func(u *User) string { return u.Email })
+func (f *Factory[T]) WithIdentity(key func(*T) string) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.identity = key
+	return f
+}
+
+type identityKey struct{}
+
+// identityMap remembers records created per identity. Safe for concurrent use.
+type identityMap struct {
+	mu      sync.Mutex
+	entries map[string]*identityEntry
+}
+
+// identityEntry is one in-flight or completed creation.
+type identityEntry struct {
+	done chan struct{}
+	val  any
+	err  error
+}
+
+// WithIdentityMap returns a context that deduplicates creates of factories with
+// WithIdentity. Use one per graph or seeding run. If ctx already has a map, it is
+// returned unchanged so nested helpers share the outer scope.
+func WithIdentityMap(ctx context.Context) context.Context {
+	if identitiesFrom(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, identityKey{}, &identityMap{entries: make(map[string]*identityEntry)})
+}
+
+func identitiesFrom(ctx context.Context) *identityMap {
+	m, _ := ctx.Value(identityKey{}).(*identityMap)
+	return m
+}
+
+// resolveIdentity returns the record created for key, calling create for the
+// first request. Concurrent requests wait for the first; failures are not
+// remembered, so a later request retries.
+func resolveIdentity[T any](m *identityMap, key string, create func() (*T, error)) (*T, error) {
+	m.mu.Lock()
+	if e, ok := m.entries[key]; ok {
+		m.mu.Unlock()
+		<-e.done
+		if e.err != nil {
+			return resolveIdentity(m, key, create)
+		}
+		return e.val.(*T), nil
+	}
+	e := &identityEntry{done: make(chan struct{})}
+	m.entries[key] = e
+	m.mu.Unlock()
+
+	var val *T
+	val, e.err = create()
+	e.val = val
+	if e.err != nil {
+		m.mu.Lock()
+		delete(m.entries, key)
+		m.mu.Unlock()
+	}
+	close(e.done)
+	return val, e.err
+}
@@ -0,0 +1,111 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestFactory_WithIdentity(t *testing.T) {
+	var mu sync.Mutex
+	saved, hooks := 0, 0
+	users := New(func(seq int64) User { return User{Email: "owner@example.com"} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			saved++
+			u.ID = fmt.Sprint(saved)
+			return u, nil
+		}).
+		AfterCreate(func(ctx context.Context, u *User) error {
+			mu.Lock()
+			defer mu.Unlock()
+			hooks++
+			return nil
+		}).
+		WithIdentity(func(u *User) string { return u.Email })
+	posts := New(func(seq int64) Post { return Post{} }).
+		WithPersist(func(ctx context.Context, p *Post) (*Post, error) { return p, nil })
+
+	ctx := WithIdentityMap(context.Background())
+	a, _ := Has(users, posts, 2, func(u *User, p *Post) { p.AuthorID = u.ID }).MustCreate(ctx)
+	b, drafts := Has(users, posts, 1, func(u *User, p *Post) { p.AuthorID = u.ID }).MustCreate(ctx)
+	if saved != 1 || hooks != 1 {
+		t.Fatalf("expected the owner to be created once, got %d saves and %d hooks", saved, hooks)
+	}
+	if b != a || drafts[0].AuthorID != a.ID {
+		t.Fatalf("expected the second Has to reuse the owner, got %+v vs %+v", b, a)
+	}
+
+	other := users.MustCreate(ctx, func(u *User) { u.Email = "other@example.com" })
+	if other.ID == a.ID || saved != 2 {
+		t.Fatalf("expected a different identity to be created, got %+v", other)
+	}
+
+	// Concurrent creates of one identity wait for the first
+	ctx = WithIdentityMap(context.Background())
+	var wg sync.WaitGroup
+	results := make([]*User, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = users.MustCreate(ctx, func(u *User) { u.Email = "shared@example.com" })
+		}(i)
+	}
+	wg.Wait()
+	for _, u := range results {
+		if u != results[0] {
+			t.Fatal("expected every concurrent create to return the same record")
+		}
+	}
+
+	// Without a scope, every Create persists
+	before := saved
+	users.MustCreate(context.Background())
+	users.MustCreate(context.Background())
+	if saved != before+2 {
+		t.Fatalf("expected 2 saves outside a scope, got %d", saved-before)
+	}
+}
+
+func TestFactory_WithIdentity_RetriesFailures(t *testing.T) {
+	fail := true
+	users := New(func(seq int64) User { return User{Email: "a@example.com"} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) {
+			if fail {
+				return nil, errors.New("boom")
+			}
+			return u, nil
+		}).
+		WithIdentity(func(u *User) string { return u.Email })
+
+	ctx := WithIdentityMap(context.Background())
+	if _, err := users.Create(ctx); err == nil {
+		t.Fatal("expected first create to fail")
+	}
+	fail = false
+	if _, err := users.Create(ctx); err != nil {
+		t.Fatalf("expected retry after failure, got %v", err)
+	}
+}
+
+func TestScenario_IdentityScope(t *testing.T) {
+	saved := 0
+	users := New(func(seq int64) User { return User{Email: "admin@example.com"} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) {
+			saved++
+			return u, nil
+		}).
+		WithIdentity(func(u *User) string { return u.Email })
+
+	s := NewScenario("dedupe").
+		Step("first", CreateStep("first", users, 1)).
+		Step("second", CreateStep("second", users, 2))
+	r := s.MustCreate(context.Background())
+	if saved != 1 || First[User](r, "first") != First[User](r, "second") {
+		t.Fatalf("expected one admin shared across steps, got %d saves", saved)
+	}
+}
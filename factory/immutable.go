@@ -0,0 +1,24 @@
+package factory
+
+// Immutable makes every later builder call (When, WithTraits, DefineState,
+// AfterCreate, ...) return a modified copy instead of changing f, so each chain
+// produces an independent factory and a shared base stays pristine, as in Laravel.
+// Copies are immutable too and share f's sequence counter, so items from different
+// chains never reuse a sequence number. Call it last when defining a shared factory.
+// Example:
+//
+//	var Users = factory.New(newUser).WithPersist(repo.Save).Immutable()
+//	admin := Users.When(isAdmin, makeAdmin) // Users is unchanged
+func (f *Factory[T]) Immutable() *Factory[T] {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.immutable = true
+	return f
+}
+
+// IsImmutable reports whether builder calls return copies (see Immutable).
+func (f *Factory[T]) IsImmutable() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.immutable
+}
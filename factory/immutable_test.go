@@ -0,0 +1,53 @@
+package factory
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFactory_Immutable(t *testing.T) {
+	var saved int
+	base := New(func(seq int64) User { return User{Name: "User"} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) { return u, nil }).
+		Immutable()
+
+	admin := base.When(true, func(u *User) { u.Name = "Admin" })
+	hooked := base.AfterCreate(func(ctx context.Context, u *User) error { saved++; return nil })
+	defined := base.DefineState("banned", func(u *User) { u.Name = "Banned" })
+
+	if admin == base || hooked == base || defined == base {
+		t.Fatal("expected builders to return copies")
+	}
+	if got := base.Make().Name; got != "User" {
+		t.Fatalf("expected the base to stay pristine, got %q", got)
+	}
+	if got := admin.Make().Name; got != "Admin" {
+		t.Fatalf("expected the copy to apply its trait, got %q", got)
+	}
+	base.MustCreate(context.Background())
+	hooked.MustCreate(context.Background())
+	if saved != 1 {
+		t.Fatalf("expected only the hooked copy to run the hook, got %d", saved)
+	}
+	if _, err := base.TryState("banned"); err == nil {
+		t.Fatal("expected the base not to see the copy's state")
+	}
+	if !admin.IsImmutable() || admin.WithTraits(func(u *User) {}) == admin {
+		t.Fatal("expected copies to be immutable too")
+	}
+
+	// Chains share the sequence counter
+	if a, b := admin.Make(), hooked.Make(); a == b {
+		t.Fatalf("expected distinct builds, got %+v twice", a)
+	}
+	if got := base.CurrentSequence(); got != 6 {
+		t.Fatalf("expected every chain to draw from the base counter, got %d", got)
+	}
+}
+
+func TestFactory_MutableByDefault(t *testing.T) {
+	f := New(func(seq int64) User { return User{} })
+	if f.IsImmutable() || f.WithTraits(func(u *User) {}) != f {
+		t.Fatal("expected builders to modify the factory in place by default")
+	}
+}
@@ -0,0 +1,109 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// PersistCounter counts persist calls made by every factory that Creates with a context
+// from WithPersistCounter, including the nested creates of Has, HasAttached, and scenarios.
+// It helps spot seeding paths that degenerate into thousands of single-row inserts.
+// Safe for concurrent use.
+type PersistCounter struct {
+	mu        sync.Mutex
+	counts    map[string]int
+	total     int
+	threshold int
+	onExceed  func(c *PersistCounter)
+	warned    bool
+}
+
+// NewPersistCounter creates an empty counter without a warning threshold.
+func NewPersistCounter() *PersistCounter {
+	return &PersistCounter{counts: make(map[string]int)}
+}
+
+// WarnAt calls fn once when the total number of persist calls exceeds n.
+// A nil fn logs a warning with slog.Default.
+// Example: counter := factory.NewPersistCounter().WarnAt(500, nil)
+func (c *PersistCounter) WarnAt(n int, fn func(c *PersistCounter)) *PersistCounter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.threshold = n
+	c.onExceed = fn
+	return c
+}
+
+// Total returns the number of persist calls recorded.
+func (c *PersistCounter) Total() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.total
+}
+
+// Counts returns persist calls per type name (e.g., "main.User").
+func (c *PersistCounter) Counts() map[string]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make(map[string]int, len(c.counts))
+	for k, v := range c.counts {
+		out[k] = v
+	}
+	return out
+}
+
+// String returns a summary such as "12 persist calls (main.Post=10, main.User=2)".
+func (c *PersistCounter) String() string {
+	counts := c.Counts()
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s=%d", name, counts[name])
+	}
+	return fmt.Sprintf("%d persist calls (%s)", c.Total(), strings.Join(parts, ", "))
+}
+
+// record counts one persist call and fires the warning when the threshold is crossed.
+func (c *PersistCounter) record(typeName string) {
+	c.mu.Lock()
+	c.counts[typeName]++
+	c.total++
+	fire := c.threshold > 0 && c.total > c.threshold && !c.warned
+	if fire {
+		c.warned = true
+	}
+	fn := c.onExceed
+	c.mu.Unlock()
+
+	if !fire {
+		return
+	}
+	if fn == nil {
+		slog.Default().Warn("factory: persist call threshold exceeded; consider batch inserts", "calls", c.String())
+		return
+	}
+	fn(c)
+}
+
+type persistCounterKey struct{}
+
+// WithPersistCounter returns a context that makes Create calls record into c.
+// Example: ctx := factory.WithPersistCounter(ctx, counter); Has(userFactory, postFactory, 100, link).Create(ctx)
+func WithPersistCounter(ctx context.Context, c *PersistCounter) context.Context {
+	return context.WithValue(ctx, persistCounterKey{}, c)
+}
+
+// PersistCounterFrom returns the counter attached to ctx, or nil.
+func PersistCounterFrom(ctx context.Context) *PersistCounter {
+	c, _ := ctx.Value(persistCounterKey{}).(*PersistCounter)
+	return c
+}
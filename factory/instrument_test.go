@@ -0,0 +1,77 @@
+package factory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestPersistCounter_CountsGraph(t *testing.T) {
+	userFactory := New(func(seq int64) User {
+		return User{ID: fmt.Sprintf("user-%d", seq)}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		return u, nil
+	})
+	postFactory := New(func(seq int64) Post {
+		return Post{}
+	}).WithPersist(func(ctx context.Context, p *Post) (*Post, error) {
+		return p, nil
+	})
+
+	counter := NewPersistCounter()
+	ctx := WithPersistCounter(context.Background(), counter)
+
+	Has(userFactory, postFactory, 5, func(u *User, p *Post) {
+		p.AuthorID = u.ID
+	}).MustCreate(ctx)
+
+	if counter.Total() != 6 {
+		t.Fatalf("expected 6 persist calls, got %d", counter.Total())
+	}
+	counts := counter.Counts()
+	if counts["factory.User"] != 1 || counts["factory.Post"] != 5 {
+		t.Fatalf("expected per-type counts, got %v", counts)
+	}
+	if got := counter.String(); got != "6 persist calls (factory.Post=5, factory.User=1)" {
+		t.Fatalf("unexpected summary %q", got)
+	}
+
+	// Without the context nothing is recorded
+	userFactory.MustCreate(context.Background())
+	if counter.Total() != 6 {
+		t.Fatalf("expected counter to be unchanged, got %d", counter.Total())
+	}
+}
+
+func TestPersistCounter_WarnAt(t *testing.T) {
+	f := New(func(seq int64) User {
+		return User{}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		return u, nil
+	})
+
+	warnings := 0
+	counter := NewPersistCounter().WarnAt(3, func(c *PersistCounter) {
+		warnings++
+		if c.Total() != 4 {
+			t.Fatalf("expected warning on call 4, got %d", c.Total())
+		}
+	})
+	f.MustCreateMany(WithPersistCounter(context.Background(), counter), 10)
+	if warnings != 1 {
+		t.Fatalf("expected one warning, got %d", warnings)
+	}
+
+	var buf bytes.Buffer
+	old := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	defer slog.SetDefault(old)
+
+	f.MustCreateMany(WithPersistCounter(context.Background(), NewPersistCounter().WarnAt(1, nil)), 2)
+	if !strings.Contains(buf.String(), "threshold exceeded") {
+		t.Fatalf("expected default slog warning, got %q", buf.String())
+	}
+}
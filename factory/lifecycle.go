@@ -0,0 +1,122 @@
+package factory
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Lifecycle is a linear state machine for a model (e.g., order: pending → paid →
+// shipped). Records can be generated at any point in it, with every transition up to
+// that point applied in order along with its timestamp.
+type Lifecycle[T any] struct {
+	steps []lifecycleStep[T]
+	now   func() time.Time
+	gap   time.Duration
+	rngMu sync.Mutex // Random's traits run from concurrent Make calls
+	rng   *rand.Rand
+}
+
+type lifecycleStep[T any] struct {
+	name  string
+	apply func(t *T, at time.Time)
+}
+
+// NewLifecycle creates an empty lifecycle. Transitions are one hour apart, the
+// latest at time.Now, unless changed with WithClock and WithGap.
+func NewLifecycle[T any]() *Lifecycle[T] {
+	return &Lifecycle[T]{now: time.Now, gap: time.Hour}
+}
+
+// Then appends a state. fn sets the status and transition timestamp fields.
+// The first state is the initial one (its timestamp is usually CreatedAt).
+// Example: NewLifecycle[Order]().Then("pending", func(o *Order, at time.Time) { o.Status, o.CreatedAt = "pending", at })
+func (l *Lifecycle[T]) Then(name string, fn func(t *T, at time.Time)) *Lifecycle[T] {
+	for _, s := range l.steps {
+		if s.name == name {
+			panic(fmt.Sprintf("factory: lifecycle state %q already defined", name))
+		}
+	}
+	l.steps = append(l.steps, lifecycleStep[T]{name: name, apply: fn})
+	return l
+}
+
+// WithClock sets the time of the latest transition (e.g., Group.Now).
+func (l *Lifecycle[T]) WithClock(now func() time.Time) *Lifecycle[T] {
+	l.now = now
+	return l
+}
+
+// WithGap sets the time between consecutive transitions.
+func (l *Lifecycle[T]) WithGap(d time.Duration) *Lifecycle[T] {
+	l.gap = d
+	return l
+}
+
+// WithRand sets the random source used by Random (nil uses math/rand).
+func (l *Lifecycle[T]) WithRand(r *rand.Rand) *Lifecycle[T] {
+	l.rng = r
+	return l
+}
+
+// States returns the state names in lifecycle order.
+func (l *Lifecycle[T]) States() []string {
+	names := make([]string, len(l.steps))
+	for i, s := range l.steps {
+		names[i] = s.name
+	}
+	return names
+}
+
+// At returns a trait that moves a record through every state up to and including name.
+// Panics if name is not a state.
+// Example: orderFactory.Make(lifecycle.At("paid")) // pending, then paid
+func (l *Lifecycle[T]) At(name string) Trait[T] {
+	for i, s := range l.steps {
+		if s.name == name {
+			return l.upTo(i)
+		}
+	}
+	panic(fmt.Sprintf("factory: unknown lifecycle state %q (states: %v)", name, l.States()))
+}
+
+// Random returns a trait that moves each record to a uniformly random state.
+// Panics if the lifecycle has no states.
+func (l *Lifecycle[T]) Random() Trait[T] {
+	if len(l.steps) == 0 {
+		panic("factory: Lifecycle.Random requires at least one state; add them with Then")
+	}
+	return func(t *T) {
+		l.upTo(l.intn(len(l.steps)))(t)
+	}
+}
+
+// intn draws from the WithRand source under its lock, or from math/rand.
+func (l *Lifecycle[T]) intn(n int) int {
+	if l.rng == nil {
+		return rand.Intn(n) //nolint:gosec // test data, not security-sensitive
+	}
+	l.rngMu.Lock()
+	defer l.rngMu.Unlock()
+	return l.rng.Intn(n)
+}
+
+// Define registers every lifecycle state as a named state on f, so
+// f.State("shipped") applies pending → paid → shipped.
+func (l *Lifecycle[T]) Define(f *Factory[T]) *Factory[T] {
+	for _, s := range l.steps {
+		f = f.DefineState(s.name, l.At(s.name))
+	}
+	return f
+}
+
+// upTo returns a trait applying steps 0..last, the last at l.now().
+func (l *Lifecycle[T]) upTo(last int) Trait[T] {
+	return func(t *T) {
+		end := l.now()
+		for i := 0; i <= last; i++ {
+			l.steps[i].apply(t, end.Add(-time.Duration(last-i)*l.gap))
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package factory
+
+import (
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type Shipment struct {
+	Status    string
+	CreatedAt time.Time
+	PaidAt    *time.Time
+	ShippedAt *time.Time
+}
+
+func newShipmentLifecycle(now time.Time) *Lifecycle[Shipment] {
+	return NewLifecycle[Shipment]().
+		WithClock(func() time.Time { return now }).
+		WithGap(2*time.Hour).
+		Then("pending", func(s *Shipment, at time.Time) { s.Status, s.CreatedAt = "pending", at }).
+		Then("paid", func(s *Shipment, at time.Time) { s.Status, s.PaidAt = "paid", &at }).
+		Then("shipped", func(s *Shipment, at time.Time) { s.Status, s.ShippedAt = "shipped", &at })
+}
+
+func TestLifecycle_At(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	lc := newShipmentLifecycle(now)
+	f := New(func(seq int64) Shipment { return Shipment{} })
+
+	paid := f.Make(lc.At("paid"))
+	if paid.Status != "paid" || paid.ShippedAt != nil {
+		t.Fatalf("expected paid shipment, got %+v", paid)
+	}
+	if !paid.PaidAt.Equal(now) || !paid.CreatedAt.Equal(now.Add(-2*time.Hour)) {
+		t.Fatalf("expected timestamps spaced by the gap, got %+v", paid)
+	}
+
+	shipped := lc.Define(f).State("shipped").Make()
+	if shipped.Status != "shipped" || shipped.PaidAt == nil || !shipped.CreatedAt.Equal(now.Add(-4*time.Hour)) {
+		t.Fatalf("expected full lifecycle via State, got %+v", shipped)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic for unknown state")
+		}
+	}()
+	lc.At("delivered")
+}
+
+func TestLifecycle_Random(t *testing.T) {
+	lc := newShipmentLifecycle(time.Now()).WithRand(rand.New(rand.NewSource(1)))
+	f := New(func(seq int64) Shipment { return Shipment{} }).WithTraits(lc.Random())
+
+	seen := map[string]bool{}
+	for _, s := range f.MakeMany(30) {
+		seen[s.Status] = true
+		if s.Status == "shipped" && s.PaidAt == nil {
+			t.Fatalf("expected earlier transitions to be applied, got %+v", s)
+		}
+	}
+	if len(seen) != 3 {
+		t.Fatalf("expected all states to appear, got %v", seen)
+	}
+	if got := lc.States(); len(got) != 3 || got[0] != "pending" {
+		t.Fatalf("expected ordered states, got %v", got)
+	}
+}
+
+func TestLifecycle_RandomConcurrent(t *testing.T) {
+	lc := newShipmentLifecycle(time.Now()).WithRand(rand.New(rand.NewSource(1)))
+	f := New(func(seq int64) Shipment { return Shipment{} }).WithTraits(lc.Random())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f.MakeMany(20)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestLifecycle_RandomWithoutStatesPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "at least one state") {
+			t.Fatalf("expected a panic explaining the missing states, got %v", r)
+		}
+	}()
+	NewLifecycle[Shipment]().Random()
+}
+
+func TestLifecycle_DuplicateStatePanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic for duplicate state")
+		}
+	}()
+	newShipmentLifecycle(time.Now()).Then("paid", func(s *Shipment, at time.Time) {})
+}
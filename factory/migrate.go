@@ -0,0 +1,186 @@
+package factory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Fixture is the on-disk envelope for a versioned dataset (golden or replay files).
+// Items are kept as raw JSON so migrations can reshape them without the current Go type.
+type Fixture struct {
+	Version int               `json:"version"`
+	Items   []json.RawMessage `json:"items"`
+}
+
+// Migration upgrades one fixture item in place from version N to N+1.
+// Numbers are decoded as json.Number so curated values survive unchanged.
+type Migration func(item map[string]any) error
+
+// Migrations is an ordered set of fixture upgrades.
+// Example:
+//
+//	m := factory.NewMigrations().
+//		Register(1, func(item map[string]any) error { item["Role"] = "user"; return nil })
+//	upgraded, err := m.Upgrade(data) // version 1 -> 2
+type Migrations struct {
+	steps map[int]Migration
+}
+
+// NewMigrations creates an empty migration set. Fixtures without migrations are version 1.
+func NewMigrations() *Migrations {
+	return &Migrations{steps: make(map[int]Migration)}
+}
+
+// Register adds the migration from version `from` to from+1.
+// Panics if a migration for that version is already registered or from is less than 1.
+func (m *Migrations) Register(from int, fn Migration) *Migrations {
+	if from < 1 {
+		panic(fmt.Sprintf("factory: migration version must be >= 1, got %d", from))
+	}
+	if _, exists := m.steps[from]; exists {
+		panic(fmt.Sprintf("factory: migration from version %d already registered", from))
+	}
+	m.steps[from] = fn
+	return m
+}
+
+// Latest returns the version fixtures are upgraded to (highest registered version + 1).
+func (m *Migrations) Latest() int {
+	latest := 1
+	for from := range m.steps {
+		if from+1 > latest {
+			latest = from + 1
+		}
+	}
+	return latest
+}
+
+// Upgrade migrates a fixture file to Latest and returns the re-encoded fixture.
+// Fixtures already at Latest are returned re-encoded but otherwise unchanged.
+// Returns an error if the fixture is newer than Latest or a step is missing.
+func (m *Migrations) Upgrade(data []byte) ([]byte, error) {
+	var fx Fixture
+	if err := json.Unmarshal(data, &fx); err != nil {
+		return nil, fmt.Errorf("factory: invalid fixture: %w", err)
+	}
+	if err := m.upgrade(&fx); err != nil {
+		return nil, err
+	}
+	return encodeFixture(fx)
+}
+
+// Pending returns the versions whose migrations Upgrade would run for data.
+func (m *Migrations) Pending(data []byte) ([]int, error) {
+	var fx Fixture
+	if err := json.Unmarshal(data, &fx); err != nil {
+		return nil, fmt.Errorf("factory: invalid fixture: %w", err)
+	}
+	var pending []int
+	for from := range m.steps {
+		if from >= fx.Version {
+			pending = append(pending, from)
+		}
+	}
+	sort.Ints(pending)
+	return pending, nil
+}
+
+func (m *Migrations) upgrade(fx *Fixture) error {
+	latest := m.Latest()
+	if fx.Version < 1 {
+		return fmt.Errorf("factory: invalid fixture version %d", fx.Version)
+	}
+	if fx.Version > latest {
+		return fmt.Errorf("factory: fixture version %d is newer than latest migration %d", fx.Version, latest)
+	}
+
+	for fx.Version < latest {
+		step, ok := m.steps[fx.Version]
+		if !ok {
+			return fmt.Errorf("factory: no migration from fixture version %d", fx.Version)
+		}
+		for i, raw := range fx.Items {
+			item, err := decodeItem(raw)
+			if err != nil {
+				return fmt.Errorf("factory: fixture item %d: %w", i, err)
+			}
+			if err := step(item); err != nil {
+				return fmt.Errorf("factory: migrating item %d from version %d: %w", i, fx.Version, err)
+			}
+			if fx.Items[i], err = json.Marshal(item); err != nil {
+				return fmt.Errorf("factory: fixture item %d: %w", i, err)
+			}
+		}
+		fx.Version++
+	}
+	return nil
+}
+
+// RawFixture builds count Raw items into a fixture envelope tagged with version.
+// Pass Migrations.Latest() so recorded files match the current migration set.
+func (f *Factory[T]) RawFixture(version, count int, ts ...Trait[T]) ([]byte, error) {
+	fx := Fixture{Version: version, Items: make([]json.RawMessage, count)}
+	for i := range fx.Items {
+		data, err := json.Marshal(f.Raw(ts...))
+		if err != nil {
+			return nil, err
+		}
+		fx.Items[i] = data
+	}
+	return encodeFixture(fx)
+}
+
+// LoadFixture upgrades a fixture to m.Latest() and decodes its items into T.
+// m may be nil for fixtures that have never been migrated.
+func LoadFixture[T any](data []byte, m *Migrations) ([]T, error) {
+	if m == nil {
+		m = NewMigrations()
+	}
+	var fx Fixture
+	if err := json.Unmarshal(data, &fx); err != nil {
+		return nil, fmt.Errorf("factory: invalid fixture: %w", err)
+	}
+	if err := m.upgrade(&fx); err != nil {
+		return nil, err
+	}
+
+	items := make([]T, len(fx.Items))
+	for i, raw := range fx.Items {
+		if err := json.Unmarshal(raw, &items[i]); err != nil {
+			return nil, fmt.Errorf("factory: fixture item %d: %w", i, err)
+		}
+	}
+	return items, nil
+}
+
+func decodeItem(raw json.RawMessage) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var item map[string]any
+	if err := dec.Decode(&item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// encodeFixture writes one item per line so fixture diffs stay readable.
+func encodeFixture(fx Fixture) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "{\n  \"version\": %d,\n  \"items\": [", fx.Version)
+	for i, item := range fx.Items {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString("\n    ")
+		if err := json.Compact(&buf, item); err != nil {
+			return nil, err
+		}
+	}
+	if len(fx.Items) > 0 {
+		buf.WriteString("\n  ")
+	}
+	buf.WriteString("]\n}\n")
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,131 @@
+package factory
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFactory_RawFixtureRoundTrip(t *testing.T) {
+	f := New(func(seq int64) User {
+		return User{ID: fmt.Sprintf("user-%d", seq), Name: fmt.Sprintf("User %d", seq)}
+	})
+
+	data, err := f.RawFixture(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "{\n  \"version\": 1,\n  \"items\": [\n" +
+		"    {\"ID\":\"user-1\",\"Name\":\"User 1\",\"Email\":\"\"},\n" +
+		"    {\"ID\":\"user-2\",\"Name\":\"User 2\",\"Email\":\"\"}\n  ]\n}\n"
+	if string(data) != want {
+		t.Fatalf("expected %q, got %q", want, data)
+	}
+
+	users, err := LoadFixture[User](data, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 || users[1].Name != "User 2" {
+		t.Fatalf("expected 2 users, got %+v", users)
+	}
+}
+
+func TestMigrations_Upgrade(t *testing.T) {
+	// Version 1 had FullName; version 2 renamed it to Name; version 3 added Email
+	m := NewMigrations().
+		Register(1, func(item map[string]any) error {
+			item["Name"] = item["FullName"]
+			delete(item, "FullName")
+			return nil
+		}).
+		Register(2, func(item map[string]any) error {
+			item["Email"] = strings.ToLower(item["Name"].(string)) + "@example.com"
+			return nil
+		})
+
+	if m.Latest() != 3 {
+		t.Fatalf("expected latest 3, got %d", m.Latest())
+	}
+
+	v1 := []byte(`{"version": 1, "items": [{"ID": "curated-7", "FullName": "Ada"}]}`)
+
+	pending, err := m.Pending(v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 || pending[0] != 1 || pending[1] != 2 {
+		t.Fatalf("expected pending [1 2], got %v", pending)
+	}
+
+	users, err := LoadFixture[User](v1, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if users[0] != (User{ID: "curated-7", Name: "Ada", Email: "ada@example.com"}) {
+		t.Fatalf("expected migrated user, got %+v", users[0])
+	}
+
+	upgraded, err := m.Upgrade(v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fx Fixture
+	if err := json.Unmarshal(upgraded, &fx); err != nil {
+		t.Fatal(err)
+	}
+	if fx.Version != 3 {
+		t.Fatalf("expected version 3, got %d", fx.Version)
+	}
+
+	// Upgrading again is a no-op
+	again, err := m.Upgrade(upgraded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != string(upgraded) {
+		t.Fatalf("expected idempotent upgrade, got %s", again)
+	}
+}
+
+func TestMigrations_PreservesNumbers(t *testing.T) {
+	m := NewMigrations().Register(1, func(item map[string]any) error { return nil })
+
+	upgraded, err := m.Upgrade([]byte(`{"version": 1, "items": [{"big": 9007199254740993}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(upgraded), "9007199254740993") {
+		t.Fatalf("expected large number to be preserved, got %s", upgraded)
+	}
+}
+
+func TestMigrations_Errors(t *testing.T) {
+	m := NewMigrations().
+		Register(2, func(item map[string]any) error { return nil })
+
+	if _, err := m.Upgrade([]byte(`{"version": 1, "items": []}`)); err == nil {
+		t.Fatal("expected error for missing migration")
+	}
+	if _, err := m.Upgrade([]byte(`{"version": 9, "items": []}`)); err == nil {
+		t.Fatal("expected error for fixture newer than latest")
+	}
+	if _, err := m.Upgrade([]byte(`not json`)); err == nil {
+		t.Fatal("expected error for invalid fixture")
+	}
+
+	failing := NewMigrations().Register(1, func(item map[string]any) error {
+		return fmt.Errorf("boom")
+	})
+	if _, err := failing.Upgrade([]byte(`{"version": 1, "items": [{}]}`)); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("expected migration error, got %v", err)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic for duplicate migration")
+		}
+	}()
+	m.Register(2, func(item map[string]any) error { return nil })
+}
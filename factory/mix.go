@@ -0,0 +1,297 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Mixer interleaves creation across several factories of the same type (e.g., event
+// kinds written to one table or topic) according to weights.
+type Mixer[T any] struct {
+	entries []mixEntry[T]
+	count   int
+}
+
+type mixEntry[T any] struct {
+	f      *Factory[T]
+	weight int
+}
+
+// Weighted is a factory and its weight for MixOf.
+type Weighted[T any] struct {
+	Factory *Factory[T]
+	Weight  int
+}
+
+// Mix returns a mixer over weighted factories, for seeding realistic mixed workloads.
+// Each factory gets its share of the batch (largest remainder, so counts add up
+// exactly), and items are interleaved evenly rather than in blocks. Factories with
+// equal weights are ordered by Name (see WithName); use MixOf to fix the order
+// instead. Panics on a negative weight, when every weight is zero, or when equal
+// positive weights share a name, since their order would then vary between runs.
+// Example: Mix(map[*Factory[Event]]int{pageViews: 70, clicks: 25, purchases: 5}).Count(1000).Create(ctx)
+func Mix[T any](weights map[*Factory[T]]int) *Mixer[T] {
+	entries := make([]Weighted[T], 0, len(weights))
+	for f, w := range weights {
+		entries = append(entries, Weighted[T]{Factory: f, Weight: w})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Weight != b.Weight {
+			return a.Weight > b.Weight
+		}
+		return a.Factory.Name() < b.Factory.Name()
+	})
+	for i := 1; i < len(entries); i++ {
+		checkMixTie("Mix", entries[i-1].Weight, entries[i].Weight, entries[i-1].Factory.Name(), entries[i].Factory.Name())
+	}
+	return MixOf(entries...)
+}
+
+// checkMixTie panics when two adjacent sorted entries tie on both weight and name.
+func checkMixTie(fn string, wa, wb int, na, nb string) {
+	if wa > 0 && wa == wb && na == nb {
+		panic(fmt.Sprintf("factory: %s factories with equal weight %d share the name %q; use WithName or MixOf", fn, wa, na))
+	}
+}
+
+// MixOf is Mix with the factories in a slice; equal weights keep the slice's order.
+// Example: MixOf(Weighted[Event]{pageViews, 70}, Weighted[Event]{clicks, 30})
+func MixOf[T any](entries ...Weighted[T]) *Mixer[T] {
+	m := &Mixer[T]{}
+	total := 0
+	for _, e := range entries {
+		if e.Weight < 0 {
+			panic(fmt.Sprintf("factory: Mix weight %d is negative", e.Weight))
+		}
+		total += e.Weight
+		m.entries = append(m.entries, mixEntry[T]{f: e.Factory, weight: e.Weight})
+	}
+	if total == 0 {
+		panic("factory: Mix requires at least one positive weight")
+	}
+	sort.SliceStable(m.entries, func(i, j int) bool { return m.entries[i].weight > m.entries[j].weight })
+	return m
+}
+
+// Count returns a copy of the mixer that builds n items.
+func (m *Mixer[T]) Count(n int) *Mixer[T] {
+	out := *m
+	out.count = n
+	return &out
+}
+
+// Make builds the mixed batch without persisting.
+func (m *Mixer[T]) Make(ts ...Trait[T]) []T {
+	items := make([]T, 0, m.count)
+	for _, i := range m.order() {
+		items = append(items, m.entries[i].f.Make(ts...))
+	}
+	return items
+}
+
+// Create creates the mixed batch in interleaved order. Each factory's batch hooks
+// run once with the items it created. On error, returns the items created so far.
+func (m *Mixer[T]) Create(ctx context.Context, ts ...Trait[T]) ([]*T, error) {
+	for _, e := range m.entries {
+		if e.weight > 0 && !e.f.canPersist() {
+			return nil, ErrNoPersist
+		}
+	}
+	items := make([]*T, 0, m.count)
+	per := make([][]*T, len(m.entries))
+	var hookErrs []error
+	for _, i := range m.order() {
+		item, err := m.entries[i].f.Create(ctx, ts...)
+		if item != nil {
+			items = append(items, item)
+			per[i] = append(per[i], item)
+		}
+		var hookErr *HookError
+		if errors.As(err, &hookErr) {
+			hookErrs = append(hookErrs, err)
+			continue
+		}
+		if err != nil {
+			return items, err
+		}
+	}
+	for i, e := range m.entries {
+		if len(per[i]) > 0 {
+			hookErrs = append(hookErrs, e.f.runBatchHooks(ctx, per[i]))
+		}
+	}
+	return items, errors.Join(hookErrs...)
+}
+
+// MustCreate is like Create but panics on error.
+func (m *Mixer[T]) MustCreate(ctx context.Context, ts ...Trait[T]) []*T {
+	items, err := m.Create(ctx, ts...)
+	if err != nil {
+		panic("factory: Mixer.MustCreate failed: " + err.Error())
+	}
+	return items
+}
+
+// order returns the entry index for each item (see mixOrder).
+func (m *Mixer[T]) order() []int {
+	weights := make([]int, len(m.entries))
+	for i, e := range m.entries {
+		weights[i] = e.weight
+	}
+	return mixOrder(weights, m.count)
+}
+
+// mixOrder returns the weight index for each of count items: exact per-weight
+// counts, spread evenly with smooth weighted round-robin. Ties go to the lower index.
+func mixOrder(weights []int, count int) []int {
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+
+	// Largest-remainder shares, so counts add up to count exactly
+	shares := make([]int, len(weights))
+	remainders := make([]int, len(weights))
+	assigned := 0
+	for i, w := range weights {
+		shares[i] = count * w / total
+		remainders[i] = count * w % total
+		assigned += shares[i]
+	}
+	byRemainder := make([]int, len(weights))
+	for i := range byRemainder {
+		byRemainder[i] = i
+	}
+	sort.SliceStable(byRemainder, func(a, b int) bool { return remainders[byRemainder[a]] > remainders[byRemainder[b]] })
+	for _, i := range byRemainder[:count-assigned] {
+		shares[i]++
+	}
+
+	// Smooth weighted round-robin over the shares
+	out := make([]int, 0, count)
+	current := make([]int, len(shares))
+	for len(out) < count {
+		best := -1
+		for i, s := range shares {
+			if s == 0 {
+				continue
+			}
+			current[i] += s
+			if best < 0 || current[i] > current[best] {
+				best = i
+			}
+		}
+		current[best] -= count
+		out = append(out, best)
+	}
+	return out
+}
+
+// AnyMixer interleaves items from factories of different types (see MixAny).
+type AnyMixer struct {
+	entries []anyMixEntry
+	count   int
+}
+
+type anyMixEntry struct {
+	f      FactoryAny
+	weight int
+}
+
+// MixAny is Mix over type-erased factories, for seeding mixed workloads whose
+// models differ (e.g., users, posts, and comments in one run). Equal weights are
+// ordered by Name. Items come back as any: T values from Make, *T from Create.
+// Panics on a negative weight, when every weight is zero, or when equal positive
+// weights share a name.
+// Example: MixAny(map[FactoryAny]int{users: 10, posts: 60, comments: 30}).Count(100).Create(ctx)
+func MixAny(weights map[FactoryAny]int) *AnyMixer {
+	m := &AnyMixer{}
+	total := 0
+	for f, w := range weights {
+		if w < 0 {
+			panic(fmt.Sprintf("factory: MixAny weight %d is negative", w))
+		}
+		total += w
+		m.entries = append(m.entries, anyMixEntry{f: f, weight: w})
+	}
+	if total == 0 {
+		panic("factory: MixAny requires at least one positive weight")
+	}
+	sort.SliceStable(m.entries, func(i, j int) bool {
+		a, b := m.entries[i], m.entries[j]
+		if a.weight != b.weight {
+			return a.weight > b.weight
+		}
+		return a.f.Name() < b.f.Name()
+	})
+	for i := 1; i < len(m.entries); i++ {
+		a, b := m.entries[i-1], m.entries[i]
+		checkMixTie("MixAny", a.weight, b.weight, a.f.Name(), b.f.Name())
+	}
+	return m
+}
+
+// Count returns a copy of the mixer that builds n items.
+func (m *AnyMixer) Count(n int) *AnyMixer {
+	out := *m
+	out.count = n
+	return &out
+}
+
+// Make builds the mixed batch without persisting.
+func (m *AnyMixer) Make() []any {
+	items := make([]any, 0, m.count)
+	for _, i := range m.order() {
+		items = append(items, m.entries[i].f.MakeAny())
+	}
+	return items
+}
+
+// Create creates the mixed batch in interleaved order. Unlike Mixer.Create, batch
+// hooks do not run, since FactoryAny does not expose them. On error, returns the
+// items created so far.
+func (m *AnyMixer) Create(ctx context.Context) ([]any, error) {
+	for _, e := range m.entries {
+		if p, ok := e.f.(interface{ canPersist() bool }); ok && e.weight > 0 && !p.canPersist() {
+			return nil, ErrNoPersist
+		}
+	}
+	items := make([]any, 0, m.count)
+	var hookErrs []error
+	for _, i := range m.order() {
+		item, err := m.entries[i].f.CreateAny(ctx)
+		if item != nil {
+			items = append(items, item)
+		}
+		var hookErr *HookError
+		if errors.As(err, &hookErr) {
+			hookErrs = append(hookErrs, err)
+			continue
+		}
+		if err != nil {
+			return items, err
+		}
+	}
+	return items, errors.Join(hookErrs...)
+}
+
+// MustCreate is like Create but panics on error.
+func (m *AnyMixer) MustCreate(ctx context.Context) []any {
+	items, err := m.Create(ctx)
+	if err != nil {
+		panic("factory: AnyMixer.MustCreate failed: " + err.Error())
+	}
+	return items
+}
+
+// order returns the entry index for each item (see mixOrder).
+func (m *AnyMixer) order() []int {
+	weights := make([]int, len(m.entries))
+	for i, e := range m.entries {
+		weights[i] = e.weight
+	}
+	return mixOrder(weights, m.count)
+}
@@ -0,0 +1,154 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMix(t *testing.T) {
+	newKind := func(kind string) *Factory[Post] {
+		return New(func(seq int64) Post { return Post{Title: kind} }).
+			WithPersist(func(ctx context.Context, p *Post) (*Post, error) { return p, nil })
+	}
+	views, clicks, purchases := newKind("view"), newKind("click"), newKind("purchase")
+	batches := map[string]int{}
+	purchases.AfterCreateBatch(func(ctx context.Context, ps []*Post) error {
+		batches["purchase"] += len(ps)
+		return nil
+	})
+
+	events := Mix(map[*Factory[Post]]int{views: 70, clicks: 25, purchases: 5}).Count(1000).MustCreate(context.Background())
+	if len(events) != 1000 {
+		t.Fatalf("expected 1000 events, got %d", len(events))
+	}
+	counts := map[string]int{}
+	for _, e := range events {
+		counts[e.Title]++
+	}
+	if counts["view"] != 700 || counts["click"] != 250 || counts["purchase"] != 50 {
+		t.Fatalf("expected exact weighted counts, got %v", counts)
+	}
+	if batches["purchase"] != 50 {
+		t.Fatalf("expected batch hooks per factory, got %v", batches)
+	}
+
+	// Interleaved, not in blocks: the first 20 items already contain every kind
+	first := map[string]bool{}
+	for _, e := range events[:20] {
+		first[e.Title] = true
+	}
+	if len(first) != 3 {
+		t.Fatalf("expected interleaving, got kinds %v in the first 20", first)
+	}
+}
+
+func TestMix_Make(t *testing.T) {
+	a := New(func(seq int64) User { return User{Name: "a"} })
+	b := New(func(seq int64) User { return User{Name: "b"} })
+	users := Mix(map[*Factory[User]]int{a: 2, b: 1}).Count(7).Make()
+	var names []string
+	for _, u := range users {
+		names = append(names, u.Name)
+	}
+	got := strings.Join(names, "")
+	if strings.Count(got, "a") != 5 || strings.Count(got, "b") != 2 {
+		t.Fatalf("expected 5 a and 2 b (largest remainder), got %q", got)
+	}
+	if _, err := Mix(map[*Factory[User]]int{a: 1}).Count(1).Create(context.Background()); !errors.Is(err, ErrNoPersist) {
+		t.Fatalf("expected ErrNoPersist, got %v", err)
+	}
+}
+
+func TestMix_InvalidWeights(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for zero total weight")
+		}
+	}()
+	Mix(map[*Factory[User]]int{New(func(seq int64) User { return User{} }): 0})
+}
+
+func TestMix_ReproducibleTies(t *testing.T) {
+	counts := func(m *Mixer[User]) string {
+		var names []string
+		for _, u := range m.Count(3).Make() {
+			names = append(names, u.Name)
+		}
+		return strings.Join(names, "")
+	}
+
+	newNamed := func(name string) *Factory[User] {
+		return New(func(seq int64) User { return User{Name: name} }).WithName(name)
+	}
+	a, b := newNamed("a"), newNamed("b")
+	for i := 0; i < 20; i++ {
+		if got := counts(Mix(map[*Factory[User]]int{b: 1, a: 1})); got != "aba" {
+			t.Fatalf("expected equal weights to be ordered by name, got %q", got)
+		}
+	}
+
+	// Same names: Mix refuses, MixOf keeps the slice order
+	x := New(func(seq int64) User { return User{Name: "x"} })
+	y := New(func(seq int64) User { return User{Name: "y"} })
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected panic for tied weights sharing a name")
+			}
+		}()
+		Mix(map[*Factory[User]]int{x: 1, y: 1})
+	}()
+	if got := counts(Mix(map[*Factory[User]]int{x: 2, y: 1})); got != "xyx" {
+		t.Fatalf("expected distinct weights to need no names, got %q", got)
+	}
+	for i := 0; i < 20; i++ {
+		if got := counts(MixOf(Weighted[User]{y, 1}, Weighted[User]{x, 1})); got != "yxy" {
+			t.Fatalf("expected MixOf to keep the slice order for ties, got %q", got)
+		}
+	}
+}
+
+func TestMixAny(t *testing.T) {
+	users := New(func(seq int64) User { return User{Name: "Ada"} }).WithName("users").
+		WithPersist(func(ctx context.Context, u *User) (*User, error) { return u, nil })
+	posts := New(func(seq int64) Post { return Post{Title: "Hello"} }).WithName("posts").
+		WithPersist(func(ctx context.Context, p *Post) (*Post, error) { return p, nil })
+
+	items := MixAny(map[FactoryAny]int{users: 1, posts: 3}).Count(8).MustCreate(context.Background())
+	counts := map[string]int{}
+	for _, item := range items {
+		switch item.(type) {
+		case *User:
+			counts["users"]++
+		case *Post:
+			counts["posts"]++
+		default:
+			t.Fatalf("unexpected item %#v", item)
+		}
+	}
+	if counts["users"] != 2 || counts["posts"] != 6 {
+		t.Fatalf("expected 2 users and 6 posts, got %v", counts)
+	}
+
+	made := MixAny(map[FactoryAny]int{users: 1, posts: 1}).Count(2).Make()
+	if _, ok := made[0].(Post); !ok {
+		t.Fatalf("expected equal weights ordered by name (posts first), got %#v", made)
+	}
+	if _, ok := made[1].(User); !ok {
+		t.Fatalf("expected a User value second, got %#v", made[1])
+	}
+
+	unsaved := New(func(seq int64) Post { return Post{} })
+	if _, err := MixAny(map[FactoryAny]int{users: 1, unsaved: 1}).Count(2).Create(context.Background()); !errors.Is(err, ErrNoPersist) {
+		t.Fatalf("expected ErrNoPersist, got %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for tied weights sharing a name")
+		}
+	}()
+	MixAny(map[FactoryAny]int{unsaved: 1, New(func(seq int64) Post { return Post{} }): 1})
+}
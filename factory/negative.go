@@ -0,0 +1,80 @@
+package factory
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Labeled is one row of a Complement dataset.
+type Labeled[T any] struct {
+	Item   T
+	Valid  bool
+	State  string // Invalid state applied ("" for valid rows)
+	Reason string // Why the row is invalid ("" for valid rows)
+}
+
+// DefineInvalid registers a named state that makes an item invalid, with the reason
+// an importer should report (e.g., "email is required"). It is also a normal state,
+// usable with State and Apply.
+// Example: factory.DefineInvalid("no-email", "email is required", func(u *User) { u.Email = "" })
+func (f *Factory[T]) DefineInvalid(name, reason string, trait Trait[T]) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.defineState(name, trait)
+	invalid := make(map[string]string, len(f.invalid)+1)
+	for k, v := range f.invalid {
+		invalid[k] = v
+	}
+	invalid[name] = reason
+	f.invalid = invalid
+	return f
+}
+
+// InvalidStates returns the names of states defined with DefineInvalid, sorted.
+func (f *Factory[T]) InvalidStates() []string {
+	v := f.view()
+	f = &v
+	names := make([]string, 0, len(f.invalid))
+	for name := range f.invalid {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Complement builds n labeled rows mixing valid items with items from each invalid
+// state, for testing import endpoints that must accept some rows and reject others
+// with per-row errors. Rows alternate valid/invalid (starting valid), and invalid
+// rows cycle through InvalidStates in order. Panics if no invalid state is defined.
+// Example: for _, row := range factory.Complement(10) { ... row.Valid, row.Reason ... }
+func (f *Factory[T]) Complement(n int, ts ...Trait[T]) []Labeled[T] {
+	v := f.view()
+	f = &v
+	names := f.InvalidStates()
+	if len(names) == 0 {
+		panic(fmt.Sprintf("factory: Complement requires invalid states; use DefineInvalid (%T)", *new(T)))
+	}
+	rows := make([]Labeled[T], n)
+	for i := range rows {
+		if i%2 == 0 {
+			rows[i] = Labeled[T]{Item: f.Make(ts...), Valid: true}
+			continue
+		}
+		name := names[(i/2)%len(names)]
+		rows[i] = Labeled[T]{
+			Item:   f.Make(append(append([]Trait[T]{}, ts...), f.states[name])...),
+			State:  name,
+			Reason: f.invalid[name],
+		}
+	}
+	return rows
+}
+
+// Items returns the items of rows, in order (e.g., to send as one import payload).
+func Items[T any](rows []Labeled[T]) []T {
+	out := make([]T, len(rows))
+	for i, row := range rows {
+		out[i] = row.Item
+	}
+	return out
+}
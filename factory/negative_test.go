@@ -0,0 +1,60 @@
+package factory
+
+import "testing"
+
+func TestFactory_Complement(t *testing.T) {
+	f := New(func(seq int64) User {
+		return User{Name: "Ada", Email: "ada@example.com"}
+	}).
+		DefineInvalid("no-email", "email is required", func(u *User) { u.Email = "" }).
+		DefineInvalid("bad-email", "email is invalid", func(u *User) { u.Email = "not-an-email" }).
+		DefineState("admin", func(u *User) { u.Name = "Admin" })
+
+	if got := f.InvalidStates(); len(got) != 2 || got[0] != "bad-email" || got[1] != "no-email" {
+		t.Fatalf("expected sorted invalid states, got %v", got)
+	}
+
+	rows := f.Complement(5, func(u *User) { u.ID = "x" })
+	if len(rows) != 5 {
+		t.Fatalf("expected 5 rows, got %d", len(rows))
+	}
+	for i, want := range []struct {
+		valid  bool
+		state  string
+		reason string
+		email  string
+	}{
+		{true, "", "", "ada@example.com"},
+		{false, "bad-email", "email is invalid", "not-an-email"},
+		{true, "", "", "ada@example.com"},
+		{false, "no-email", "email is required", ""},
+		{true, "", "", "ada@example.com"},
+	} {
+		row := rows[i]
+		if row.Valid != want.valid || row.State != want.state || row.Reason != want.reason || row.Item.Email != want.email {
+			t.Fatalf("row %d: expected %+v, got %+v", i, want, row)
+		}
+		if row.Item.ID != "x" {
+			t.Fatalf("row %d: expected per-call trait to apply, got %+v", i, row.Item)
+		}
+	}
+
+	if items := Items(rows); len(items) != 5 || items[3].Email != "" {
+		t.Fatalf("unexpected items %+v", items)
+	}
+	if u := f.State("no-email").Make(); u.Email != "" {
+		t.Fatalf("expected invalid state to be usable with State, got %+v", u)
+	}
+	if c := f.Clone(); len(c.InvalidStates()) != 2 {
+		t.Fatalf("expected clone to keep invalid states, got %v", c.InvalidStates())
+	}
+}
+
+func TestFactory_Complement_NoInvalidStates(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic without invalid states")
+		}
+	}()
+	New(func(seq int64) User { return User{} }).Complement(2)
+}
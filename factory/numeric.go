@@ -0,0 +1,59 @@
+package factory
+
+import (
+	"fmt"
+	"math"
+)
+
+// Number is the set of numeric field types Jitter and RandomBetween support.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Jitter returns a trait that varies the selected field by up to ±percent of its value
+// (integers are rounded), using f's group random source when grouped (see Group.WithSeed).
+// Example: productFactory.WithTraits(Jitter(productFactory, func(p *Product) *float64 { return &p.Price }, 10))
+func Jitter[T any, N Number](f *Factory[T], field func(*T) *N, percent float64) Trait[T] {
+	if percent < 0 {
+		panic(fmt.Sprintf("factory: Jitter requires a non-negative percent, got %v", percent))
+	}
+	return func(t *T) {
+		p := field(t)
+		delta := float64(*p) * percent / 100 * (2*f.group.float64() - 1)
+		*p = toNumber[N](float64(*p) + delta)
+	}
+}
+
+// RandomBetween returns a trait that sets the selected field to a random value in
+// [lo, hi] (inclusive for integers; floats are in [lo, hi)). Rolls use the same
+// source as Jitter. Panics if hi < lo.
+// Example: userFactory.WithTraits(RandomBetween(userFactory, func(u *User) *int { return &u.Age }, 18, 90))
+func RandomBetween[T any, N Number](f *Factory[T], field func(*T) *N, lo, hi N) Trait[T] {
+	if hi < lo {
+		panic(fmt.Sprintf("factory: RandomBetween requires lo <= hi, got %v > %v", lo, hi))
+	}
+	return func(t *T) {
+		r := f.group.float64()
+		if isInteger[N]() {
+			span := float64(hi) - float64(lo) + 1
+			*field(t) = lo + N(math.Floor(r*span))
+			return
+		}
+		*field(t) = lo + N(r*(float64(hi)-float64(lo)))
+	}
+}
+
+// isInteger reports whether N is an integer type.
+func isInteger[N Number]() bool {
+	return N(1)/N(2) == 0
+}
+
+// toNumber converts v to N, rounding for integer types.
+func toNumber[N Number](v float64) N {
+	if isInteger[N]() {
+		return N(math.Round(v))
+	}
+	return N(v)
+}
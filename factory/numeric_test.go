@@ -0,0 +1,66 @@
+package factory
+
+import "testing"
+
+type Product struct {
+	Price float64
+	Stock int
+	Views uint32
+}
+
+func TestJitterAndRandomBetween(t *testing.T) {
+	g := NewGroup().WithSeed(7)
+	f := NewIn(g, func(seq int64) Product { return Product{Price: 100, Stock: 50} })
+	f.WithTraits(
+		Jitter(f, func(p *Product) *float64 { return &p.Price }, 10),
+		Jitter(f, func(p *Product) *int { return &p.Stock }, 20),
+		RandomBetween(f, func(p *Product) *uint32 { return &p.Views }, 1, 3),
+	)
+
+	seen := map[uint32]bool{}
+	varied := false
+	for _, p := range f.MakeMany(200) {
+		if p.Price < 90 || p.Price > 110 || p.Stock < 40 || p.Stock > 60 {
+			t.Fatalf("value out of range: %+v", p)
+		}
+		if p.Views < 1 || p.Views > 3 {
+			t.Fatalf("views out of range: %d", p.Views)
+		}
+		seen[p.Views] = true
+		varied = varied || p.Price != 100
+	}
+	if len(seen) != 3 || !varied {
+		t.Fatalf("expected variation across the range, got views %v", seen)
+	}
+
+	// Seeded groups reproduce the same values
+	prices := func() []float64 {
+		f := NewIn(NewGroup().WithSeed(7), func(seq int64) Product { return Product{Price: 100} })
+		f.WithTraits(Jitter(f, func(p *Product) *float64 { return &p.Price }, 10))
+		var out []float64
+		for _, p := range f.MakeMany(5) {
+			out = append(out, p.Price)
+		}
+		return out
+	}
+	if a, b := prices(), prices(); a[0] != b[0] || a[4] != b[4] {
+		t.Fatalf("expected seeded groups to reproduce values, got %v and %v", a, b)
+	}
+
+	// Float ranges and ungrouped factories
+	plain := New(func(seq int64) Product { return Product{} })
+	p := plain.Make(RandomBetween(plain, func(p *Product) *float64 { return &p.Price }, 0.5, 1.5))
+	if p.Price < 0.5 || p.Price >= 1.5 {
+		t.Fatalf("price out of range: %v", p.Price)
+	}
+}
+
+func TestRandomBetweenPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for hi < lo")
+		}
+	}()
+	f := New(func(seq int64) Product { return Product{} })
+	RandomBetween(f, func(p *Product) *int { return &p.Stock }, 5, 1)
+}
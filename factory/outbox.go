@@ -0,0 +1,27 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+)
+
+// EventEmitter fills one event for a created aggregate. version is the event's
+// 1-based position in the aggregate's stream.
+type EventEmitter[T any, E any] func(aggregate *T, event *E, version int)
+
+// WithOutbox returns a copy of f that, after each aggregate is created, creates one
+// event per emitter through events (e.g., an outbox table), in emitter order.
+// Example: users := WithOutbox(userFactory, eventFactory, registered, verified)
+func WithOutbox[T any, E any](f *Factory[T], events *Factory[E], emitters ...EventEmitter[T, E]) *Factory[T] {
+	copy := f.shallow()
+	copy.relationHook(func(ctx context.Context, t *T) error {
+		for i, emit := range emitters {
+			version := i + 1
+			if _, err := events.Create(ctx, func(e *E) { emit(t, e, version) }); err != nil {
+				return fmt.Errorf("factory: outbox event %d: %w", version, err)
+			}
+		}
+		return nil
+	})
+	return copy
+}
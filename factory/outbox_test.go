@@ -0,0 +1,72 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type Event struct {
+	Position    int64
+	AggregateID string
+	Type        string
+	Version     int
+}
+
+func TestWithOutbox(t *testing.T) {
+	var stored []Event
+	eventFactory := New(func(seq int64) Event {
+		return Event{Position: seq}
+	}).WithPersist(func(ctx context.Context, e *Event) (*Event, error) {
+		stored = append(stored, *e)
+		return e, nil
+	})
+	userFactory := New(func(seq int64) User {
+		return User{ID: fmt.Sprintf("user-%d", seq)}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		return u, nil
+	})
+
+	users := WithOutbox(userFactory, eventFactory,
+		func(u *User, e *Event, v int) { e.AggregateID, e.Type, e.Version = u.ID, "UserRegistered", v },
+		func(u *User, e *Event, v int) { e.AggregateID, e.Type, e.Version = u.ID, "EmailVerified", v },
+	)
+	users.MustCreateMany(context.Background(), 2)
+
+	want := []Event{
+		{1, "user-1", "UserRegistered", 1},
+		{2, "user-1", "EmailVerified", 2},
+		{3, "user-2", "UserRegistered", 1},
+		{4, "user-2", "EmailVerified", 2},
+	}
+	if fmt.Sprint(stored) != fmt.Sprint(want) {
+		t.Fatalf("expected %v, got %v", want, stored)
+	}
+
+	// The original factory emits nothing
+	stored = nil
+	userFactory.MustCreate(context.Background())
+	if len(stored) != 0 {
+		t.Fatalf("expected no events from original factory, got %v", stored)
+	}
+}
+
+func TestWithOutbox_EventError(t *testing.T) {
+	boom := errors.New("outbox full")
+	eventFactory := New(func(seq int64) Event {
+		return Event{}
+	}).WithPersist(func(ctx context.Context, e *Event) (*Event, error) {
+		return nil, boom
+	})
+	userFactory := New(func(seq int64) User {
+		return User{}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		return u, nil
+	})
+
+	_, err := WithOutbox(userFactory, eventFactory, func(u *User, e *Event, v int) {}).Create(context.Background())
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected outbox error, got %v", err)
+	}
+}
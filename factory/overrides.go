@@ -0,0 +1,89 @@
+package factory
+
+import (
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+// WarnOverrides logs a warning whenever a per-call trait changes a field that a named
+// state (applied with State) also set in the same build, since silent override ordering
+// makes confusing test data. A nil logger uses slog.Default. Only exported top-level
+// fields of struct types are compared; a state field counts as set when the state changed it.
+// Example: userFactory.WarnOverrides(nil).State("admin").Make(func(u *User) { u.Role = "user" })
+func (f *Factory[T]) WarnOverrides(l *slog.Logger) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	if l == nil {
+		l = slog.Default()
+	}
+	f.overrides = l
+	return f
+}
+
+// applyGlobal applies the global traits. When WarnOverrides is on, it returns the
+// fields changed by named states, mapped to the state that changed them.
+func (f *Factory[T]) applyGlobal(t *T) map[string]string {
+	if f.overrides == nil {
+		for _, tr := range f.traits {
+			tr(t)
+		}
+		return nil
+	}
+	var set map[string]string
+	for i, tr := range f.traits {
+		state, ok := strings.CutPrefix(f.traitName(i), "state:")
+		if !ok {
+			tr(t)
+			continue
+		}
+		before := *t
+		tr(t)
+		for _, field := range changedFields(&before, t) {
+			if set == nil {
+				set = make(map[string]string)
+			}
+			set[field] = state
+		}
+	}
+	return set
+}
+
+// applyPerCall applies per-call traits and warns about fields that overwrite state values.
+func (f *Factory[T]) applyPerCall(t *T, ts []Trait[T], set map[string]string) {
+	var before T
+	if len(set) > 0 {
+		before = *t
+	}
+	for _, tr := range ts {
+		tr(t)
+	}
+	if len(set) == 0 {
+		return
+	}
+	for _, field := range changedFields(&before, t) {
+		if state, ok := set[field]; ok {
+			f.overrides.Warn("factory: per-call trait overrides a field set by a state",
+				"factory", f.Name(), "field", field, "state", state)
+		}
+	}
+}
+
+// changedFields returns the exported top-level fields that differ between a and b.
+func changedFields[T any](a, b *T) []string {
+	va, vb := reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem()
+	if va.Kind() != reflect.Struct {
+		return nil
+	}
+	var out []string
+	for i := 0; i < va.NumField(); i++ {
+		sf := va.Type().Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		if !reflect.DeepEqual(va.Field(i).Interface(), vb.Field(i).Interface()) {
+			out = append(out, sf.Name)
+		}
+	}
+	return out
+}
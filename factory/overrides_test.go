@@ -0,0 +1,54 @@
+package factory
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestFactory_WarnOverrides(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	f := New(func(seq int64) User { return User{Name: "User", Email: "user@example.com"} }).
+		DefineState("admin", func(u *User) { u.Name = "Admin" }).
+		WithTraits(func(u *User) { u.ID = "global" }).
+		WarnOverrides(logger)
+
+	u := f.State("admin").Make(func(u *User) { u.Name = "Custom" })
+	if u.Name != "Custom" {
+		t.Fatalf("expected per-call trait to still win, got %q", u.Name)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "overrides a field set by a state") ||
+		!strings.Contains(out, "field=Name") || !strings.Contains(out, "state=admin") {
+		t.Fatalf("expected override warning, got %q", out)
+	}
+
+	// Fields the state did not set, and global traits, do not warn
+	buf.Reset()
+	f.State("admin").Raw(func(u *User) { u.Email = "x@example.com"; u.ID = "call" })
+	if buf.Len() != 0 {
+		t.Fatalf("expected no warning, got %q", buf.String())
+	}
+}
+
+func TestFactory_WarnOverrides_Off(t *testing.T) {
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	defer slog.SetDefault(prev)
+
+	f := New(func(seq int64) User { return User{} }).
+		DefineState("admin", func(u *User) { u.Name = "Admin" })
+	f.State("admin").Make(func(u *User) { u.Name = "Custom" })
+	if buf.Len() != 0 {
+		t.Fatalf("expected no warning without WarnOverrides, got %q", buf.String())
+	}
+
+	f.Clone().WarnOverrides(nil).State("admin").Make(func(u *User) { u.Name = "Custom" })
+	if !strings.Contains(buf.String(), "field=Name") {
+		t.Fatalf("expected nil logger to use slog.Default, got %q", buf.String())
+	}
+}
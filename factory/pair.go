@@ -0,0 +1,73 @@
+package factory
+
+import "reflect"
+
+// PairFactory builds request/response pairs from a single request factory, so API
+// tests can assert expected responses built from the same source of truth as the
+// request payload.
+type PairFactory[Req any, Resp any] struct {
+	request *Factory[Req]
+	server  []func(req *Req, resp *Resp)
+}
+
+// Pair derives a response factory from a request factory. Each response starts with
+// the request's exported fields copied into same-named fields of Resp (see
+// CopyMatchingFields), then server functions add server-generated values (IDs, timestamps).
+// Example: Pair(createUserReq, func(req *CreateUser, resp *UserResponse) { resp.ID = "generated" })
+func Pair[Req any, Resp any](request *Factory[Req], server ...func(req *Req, resp *Resp)) *PairFactory[Req, Resp] {
+	return &PairFactory[Req, Resp]{request: request, server: server}
+}
+
+// Make builds a request with Raw (so rawDefaults apply) and its expected response.
+func (pf *PairFactory[Req, Resp]) Make(ts ...Trait[Req]) (Req, Resp) {
+	req := pf.request.Raw(ts...)
+	return req, pf.Response(req)
+}
+
+// MakeMany builds count request/response pairs.
+func (pf *PairFactory[Req, Resp]) MakeMany(count int, ts ...Trait[Req]) ([]Req, []Resp) {
+	reqs := make([]Req, count)
+	resps := make([]Resp, count)
+	for i := 0; i < count; i++ {
+		reqs[i], resps[i] = pf.Make(ts...)
+	}
+	return reqs, resps
+}
+
+// Response derives the expected response for an existing request.
+func (pf *PairFactory[Req, Resp]) Response(req Req) Resp {
+	var resp Resp
+	CopyMatchingFields(&resp, &req)
+	for _, fn := range pf.server {
+		fn(&req, &resp)
+	}
+	return resp
+}
+
+// CopyMatchingFields copies exported fields from *src into same-named fields of *dst
+// when the types are assignable. Other fields are left untouched.
+// Panics if dst or src is not a pointer to a struct.
+func CopyMatchingFields(dst, src any) {
+	dv := structValue(dst)
+	sv := structValue(src)
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		sf := st.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		df := dv.FieldByName(sf.Name)
+		if !df.IsValid() || !df.CanSet() || !sf.Type.AssignableTo(df.Type()) {
+			continue
+		}
+		df.Set(sv.Field(i))
+	}
+}
+
+func structValue(p any) reflect.Value {
+	v := reflect.ValueOf(p)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		panic("factory: CopyMatchingFields requires pointers to structs")
+	}
+	return v.Elem()
+}
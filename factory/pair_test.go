@@ -0,0 +1,81 @@
+package factory
+
+import (
+	"fmt"
+	"testing"
+)
+
+type CreateUserRequest struct {
+	Name     string
+	Email    string
+	Password string
+}
+
+type UserResponse struct {
+	ID    string
+	Name  string
+	Email string
+	Admin bool
+}
+
+func TestPair(t *testing.T) {
+	req := New(func(seq int64) CreateUserRequest {
+		return CreateUserRequest{
+			Name:  fmt.Sprintf("User %d", seq),
+			Email: fmt.Sprintf("user%d@example.com", seq),
+		}
+	}).WithRawDefaults(func(r *CreateUserRequest) {
+		r.Password = "secret"
+	})
+
+	pairs := Pair(req, func(r *CreateUserRequest, resp *UserResponse) {
+		resp.ID = "id-" + r.Name
+	})
+
+	r, resp := pairs.Make(func(r *CreateUserRequest) { r.Name = "Custom" })
+	if r.Password != "secret" {
+		t.Fatal("expected request to be built with Raw")
+	}
+	want := UserResponse{ID: "id-Custom", Name: "Custom", Email: r.Email}
+	if resp != want {
+		t.Fatalf("expected %+v, got %+v", want, resp)
+	}
+
+	reqs, resps := pairs.MakeMany(3)
+	if len(reqs) != 3 || len(resps) != 3 {
+		t.Fatalf("expected 3 pairs, got %d/%d", len(reqs), len(resps))
+	}
+	for i := range reqs {
+		if resps[i].Email != reqs[i].Email {
+			t.Fatalf("pair %d: response not derived from request", i)
+		}
+	}
+}
+
+func TestCopyMatchingFields(t *testing.T) {
+	type src struct {
+		Name  string
+		Count int
+		Admin string // Different type than dst.Admin
+		notes string
+	}
+	type dst struct {
+		Name  string
+		Count int
+		Admin bool
+		notes string
+	}
+
+	d := dst{Admin: true}
+	CopyMatchingFields(&d, &src{Name: "n", Count: 3, Admin: "yes", notes: "private"})
+	if d.Name != "n" || d.Count != 3 || !d.Admin || d.notes != "" {
+		t.Fatalf("unexpected copy result: %+v", d)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic for non-pointer")
+		}
+	}()
+	CopyMatchingFields(d, &src{})
+}
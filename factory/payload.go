@@ -0,0 +1,123 @@
+package factory
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// WithRequired lists the top-level fields an API requires, overriding
+// `factory:"required"` struct tags. Minimal keeps only these; Full fills the rest.
+// Panics if T has no exported field with one of the names.
+// Example: userFactory.WithRequired("Name", "Email")
+func (f *Factory[T]) WithRequired(fields ...string) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	var zero T
+	t := reflect.TypeOf(zero)
+	for _, name := range fields {
+		if t == nil || t.Kind() != reflect.Struct {
+			panic(fmt.Sprintf("factory: WithRequired requires a struct type, got %T", zero))
+		}
+		if sf, ok := t.FieldByName(name); !ok || !sf.IsExported() {
+			panic(fmt.Sprintf("factory: WithRequired: %T has no exported field %q", zero, name))
+		}
+	}
+	f.required = append([]string{}, fields...)
+	return f
+}
+
+// Minimal returns a per-call trait that zeroes every optional field, producing the
+// smallest valid payload. Required fields come from WithRequired, or else from
+// fields tagged `factory:"required"`; all other exported fields are optional.
+// Panics if T declares no required fields.
+// Example: body := userFactory.MustRawJSON(userFactory.Minimal())
+func (f *Factory[T]) Minimal() Trait[T] {
+	optional := f.optionalFields("Minimal")
+	return func(t *T) {
+		v := reflect.ValueOf(t).Elem()
+		for _, i := range optional {
+			field := v.Field(i)
+			field.Set(reflect.Zero(field.Type()))
+		}
+	}
+}
+
+// Full returns a per-call trait that populates every optional field the factory left
+// zero with a placeholder: strings get the lowercased field name, numbers 1, bools
+// true, time.Time 2000-01-01 UTC, and nil pointers to those a new filled value.
+// Other kinds (slices, maps, structs) are left as built. Field values set by the
+// factory are kept. Panics if T declares no required fields.
+// Example: body := userFactory.MustRawJSON(userFactory.Full())
+func (f *Factory[T]) Full() Trait[T] {
+	optional := f.optionalFields("Full")
+	return func(t *T) {
+		v := reflect.ValueOf(t).Elem()
+		for _, i := range optional {
+			if field := v.Field(i); field.IsZero() {
+				fillPlaceholder(field, v.Type().Field(i).Name)
+			}
+		}
+	}
+}
+
+// optionalFields returns the indexes of T's exported fields that are not required.
+func (f *Factory[T]) optionalFields(method string) []int {
+	v := f.view()
+	f = &v
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("factory: %s requires a struct type, got %T", method, zero))
+	}
+	required := make(map[string]bool)
+	for _, name := range f.required {
+		required[name] = true
+	}
+	if len(required) == 0 {
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).Tag.Get("factory") == "required" {
+				required[t.Field(i).Name] = true
+			}
+		}
+	}
+	if len(required) == 0 {
+		panic(fmt.Sprintf("factory: %s: %T has no required fields; tag them `factory:\"required\"` or use WithRequired", method, zero))
+	}
+	var out []int
+	for i := 0; i < t.NumField(); i++ {
+		if sf := t.Field(i); sf.IsExported() && !required[sf.Name] {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+var placeholderTime = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// fillPlaceholder sets v to a non-zero placeholder for its kind, if it has one.
+func fillPlaceholder(v reflect.Value, name string) {
+	if v.Type() == reflect.TypeOf(placeholderTime) {
+		v.Set(reflect.ValueOf(placeholderTime))
+		return
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(strings.ToLower(name))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1)
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Pointer:
+		p := reflect.New(v.Type().Elem())
+		fillPlaceholder(p.Elem(), name)
+		if !p.Elem().IsZero() {
+			v.Set(p)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package factory
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type SignupRequest struct {
+	Email      string `json:"email" factory:"required"`
+	Password   string `json:"password" factory:"required"`
+	Name       string `json:"name,omitempty"`
+	Age        int    `json:"age,omitempty"`
+	Newsletter bool   `json:"newsletter,omitempty"`
+	Referrer   *string
+	BirthDate  time.Time
+	Tags       []string
+}
+
+func newSignupFactory() *Factory[SignupRequest] {
+	return New(func(seq int64) SignupRequest {
+		return SignupRequest{Email: "a@example.com", Password: "secret", Name: "Ada"}
+	})
+}
+
+func TestFactory_Minimal(t *testing.T) {
+	f := newSignupFactory()
+	body := string(f.MustRawJSON(f.Minimal()))
+	if !strings.Contains(body, `"email":"a@example.com","password":"secret"`) || strings.Contains(body, "name") {
+		t.Fatalf("expected only required fields, got %s", body)
+	}
+
+	// Config overrides tags
+	req := f.Clone().WithRequired("Email", "Name").Make(f.Minimal())
+	if req.Password != "secret" {
+		t.Fatal("expected the trait built before WithRequired to keep using tags")
+	}
+	g := f.Clone().WithRequired("Email", "Name")
+	if req = g.Make(g.Minimal()); req.Password != "" || req.Name != "Ada" {
+		t.Fatalf("expected WithRequired fields only, got %+v", req)
+	}
+}
+
+func TestFactory_Full(t *testing.T) {
+	f := newSignupFactory()
+	req := f.Make(f.Full())
+	if req.Name != "Ada" {
+		t.Fatalf("expected factory values kept, got %q", req.Name)
+	}
+	if req.Age != 1 || !req.Newsletter || req.Referrer == nil || *req.Referrer != "referrer" ||
+		req.BirthDate.IsZero() || req.Tags != nil {
+		t.Fatalf("expected optional fields populated, got %+v", req)
+	}
+	if req.Email != "a@example.com" {
+		t.Fatalf("expected required fields untouched, got %q", req.Email)
+	}
+}
+
+func TestFactory_MinimalWithoutRequiredPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "no required fields") {
+			t.Fatalf("expected panic, got %v", r)
+		}
+	}()
+	f := New(func(seq int64) User { return User{} })
+	f.Minimal()
+}
@@ -0,0 +1,50 @@
+package factory
+
+import "sync/atomic"
+
+// percentTraits are traits applied to a share of a Count batch (see Percent).
+type percentTraits[T any] struct {
+	p      int
+	traits []Trait[T]
+}
+
+// Percent applies ts to exactly p% of the batch (rounded to the nearest item),
+// spread evenly and deterministically: with Count(10).Percent(30, ...), items 4, 7,
+// and 10 get the traits. It replaces loops like "publish the first 10 posts".
+// Percent traits run after the other per-call traits; each Percent call is spread
+// independently. Panics if p is outside [0, 100].
+// Example: postFactory.Count(20).Percent(25, postFactory.Apply("published")).MustCreate(ctx)
+func (cf *CountedFactory[T]) Percent(p int, ts ...Trait[T]) *CountedFactory[T] {
+	if p < 0 || p > 100 {
+		panic("factory: Percent requires p between 0 and 100")
+	}
+	out := cf.with(cf.factory)
+	out.percents = append(append([]percentTraits[T]{}, cf.percents...), percentTraits[T]{p: p, traits: ts})
+	return out
+}
+
+// withPercents returns ts plus one trait per Percent call. Each trait counts the items
+// of this batch and fires on item i (0-based) when floor((i+1)*k/n) steps up, where
+// k is the number of items that should get the traits.
+func (cf *CountedFactory[T]) withPercents(ts []Trait[T]) []Trait[T] {
+	if len(cf.percents) == 0 {
+		return ts
+	}
+	out := append([]Trait[T]{}, ts...)
+	n := int64(cf.count)
+	for _, pt := range cf.percents {
+		pt := pt
+		k := (int64(pt.p)*n + 50) / 100
+		var next int64
+		out = append(out, func(t *T) {
+			i := atomic.AddInt64(&next, 1) - 1
+			if n == 0 || (i+1)*k/n == i*k/n {
+				return
+			}
+			for _, tr := range pt.traits {
+				tr(t)
+			}
+		})
+	}
+	return out
+}
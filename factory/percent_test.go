@@ -0,0 +1,68 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestCountedFactory_Percent(t *testing.T) {
+	f := New(func(seq int64) Post { return Post{Title: fmt.Sprint(seq)} }).
+		DefineState("published", func(p *Post) { p.AuthorID = "published" })
+
+	posts := f.Count(10).Percent(30, f.Apply("published")).Make()
+	var got []string
+	for _, p := range posts {
+		if p.AuthorID == "published" {
+			got = append(got, p.Title)
+		}
+	}
+	if fmt.Sprint(got) != "[4 7 10]" {
+		t.Fatalf("expected items 4, 7, 10 to be published, got %v", got)
+	}
+
+	// Exact counts, including rounding, 0%, and 100%
+	for _, tc := range []struct{ count, p, want int }{
+		{20, 25, 5}, {7, 50, 4}, {3, 0, 0}, {3, 100, 3}, {0, 50, 0},
+	} {
+		n := 0
+		for _, p := range f.Count(tc.count).Percent(tc.p, f.Apply("published")).Raw() {
+			if p.AuthorID == "published" {
+				n++
+			}
+		}
+		if n != tc.want {
+			t.Fatalf("Count(%d).Percent(%d): expected %d, got %d", tc.count, tc.p, tc.want, n)
+		}
+	}
+}
+
+func TestCountedFactory_PercentCreate(t *testing.T) {
+	f := New(func(seq int64) Post { return Post{} }).
+		WithPersist(func(ctx context.Context, p *Post) (*Post, error) { return p, nil }).
+		DefineState("draft", func(p *Post) { p.Title = "draft" })
+
+	// Works with Distribute and StartingSeqAt; every call spreads afresh
+	batch := f.Count(4).
+		Distribute(map[string]int{"draft": 2, "": 2}).
+		StartingSeqAt(1).
+		Percent(50, func(p *Post) { p.AuthorID = "featured" })
+	for i := 0; i < 2; i++ {
+		featured := 0
+		for _, p := range batch.MustCreate(context.Background()) {
+			if p.AuthorID == "featured" {
+				featured++
+			}
+		}
+		if featured != 2 {
+			t.Fatalf("expected 2 featured posts, got %d", featured)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for p above 100")
+		}
+	}()
+	f.Count(1).Percent(101)
+}
@@ -0,0 +1,50 @@
+package factory
+
+import (
+	"context"
+	"sync"
+)
+
+// Persist middleware: functions that wrap a PersistFn to add behavior.
+
+// memoEntry is one in-flight or completed memoized persist call.
+type memoEntry[T any] struct {
+	done chan struct{}
+	val  *T
+	err  error
+}
+
+// MemoizePersist wraps p so items with the same natural key are persisted once;
+// later calls return the already-created record instead of inserting again.
+// Useful when many children independently trigger creation of the same parent.
+// Concurrent calls for the same key wait for the first one. Failed calls are not
+// remembered, so a later call with the same key retries.
+// Note that BeforeCreate/AfterCreate hooks still run for every Create call.
+// Example: companyFactory.WithPersist(MemoizePersist(repo.Save, func(c *Company) string { return c.Domain }))
+func MemoizePersist[T any](p PersistFn[T], key func(*T) string) PersistFn[T] {
+	var mu sync.Mutex
+	entries := make(map[string]*memoEntry[T])
+
+	return func(ctx context.Context, t *T) (*T, error) {
+		k := key(t)
+
+		mu.Lock()
+		if e, ok := entries[k]; ok {
+			mu.Unlock()
+			<-e.done
+			return e.val, e.err
+		}
+		e := &memoEntry[T]{done: make(chan struct{})}
+		entries[k] = e
+		mu.Unlock()
+
+		e.val, e.err = p(ctx, t)
+		if e.err != nil {
+			mu.Lock()
+			delete(entries, k)
+			mu.Unlock()
+		}
+		close(e.done)
+		return e.val, e.err
+	}
+}
@@ -0,0 +1,82 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMemoizePersist(t *testing.T) {
+	var inserts int32
+	save := func(ctx context.Context, u *User) (*User, error) {
+		n := atomic.AddInt32(&inserts, 1)
+		u.ID = fmt.Sprintf("user-%d", n)
+		return u, nil
+	}
+
+	f := New(func(seq int64) User {
+		return User{Email: "owner@example.com"}
+	}).WithPersist(MemoizePersist(save, func(u *User) string { return u.Email }))
+
+	ctx := context.Background()
+	first := f.MustCreate(ctx)
+	second := f.MustCreate(ctx)
+	other := f.MustCreate(ctx, func(u *User) { u.Email = "other@example.com" })
+
+	if first != second {
+		t.Fatal("expected same record for the same key")
+	}
+	if other.ID == first.ID {
+		t.Fatal("expected a new record for a different key")
+	}
+	if inserts != 2 {
+		t.Fatalf("expected 2 inserts, got %d", inserts)
+	}
+}
+
+func TestMemoizePersistConcurrent(t *testing.T) {
+	var inserts int32
+	p := MemoizePersist(func(ctx context.Context, u *User) (*User, error) {
+		atomic.AddInt32(&inserts, 1)
+		return u, nil
+	}, func(u *User) string { return u.Email })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = p(context.Background(), &User{Email: "same@example.com"})
+		}()
+	}
+	wg.Wait()
+
+	if inserts != 1 {
+		t.Fatalf("expected 1 insert, got %d", inserts)
+	}
+}
+
+func TestMemoizePersistRetriesAfterError(t *testing.T) {
+	calls := 0
+	p := MemoizePersist(func(ctx context.Context, u *User) (*User, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("deadlock")
+		}
+		return u, nil
+	}, func(u *User) string { return u.Email })
+
+	ctx := context.Background()
+	if _, err := p(ctx, &User{Email: "x"}); err == nil {
+		t.Fatal("expected first call to fail")
+	}
+	if _, err := p(ctx, &User{Email: "x"}); err != nil {
+		t.Fatalf("expected retry to succeed, got %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+}
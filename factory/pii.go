@@ -0,0 +1,107 @@
+package factory
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrUnmaskedPII is returned by Export when RequireMaskedExports is set and an
+// export of a factory with PII fields is not masked.
+var ErrUnmaskedPII = errors.New("factory: export contains PII; use ExportOptions{Masked: true}")
+
+// MarkPII marks fields as personally identifiable, so masked exports hide them.
+// Nested struct fields (or pointers to structs) are named by dotted paths; fields
+// inside slices and maps cannot be marked. Panics if T has no exported field at one
+// of the paths.
+// Example: userFactory.MarkPII("Email", "Phone", "Contact.Email")
+func (f *Factory[T]) MarkPII(fields ...string) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	var zero T
+	for _, path := range fields {
+		t := reflect.TypeOf(zero)
+		if t == nil || t.Kind() != reflect.Struct {
+			panic(fmt.Sprintf("factory: MarkPII requires a struct type, got %T", zero))
+		}
+		for _, name := range strings.Split(path, ".") {
+			if t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			sf, ok := reflect.StructField{}, false
+			if t.Kind() == reflect.Struct {
+				sf, ok = t.FieldByName(name)
+			}
+			if !ok || !sf.IsExported() {
+				panic(fmt.Sprintf("factory: MarkPII: %T has no exported field %q", zero, path))
+			}
+			t = sf.Type
+		}
+		f.pii = grow(f.pii, path)
+	}
+	return f
+}
+
+// PIIFields returns the fields marked with MarkPII.
+func (f *Factory[T]) PIIFields() []string {
+	v := f.view()
+	f = &v
+	return append([]string{}, f.pii...)
+}
+
+// RequireMaskedExports makes Export fail with ErrUnmaskedPII unless masking is on,
+// for datasets that are shared outside the team.
+func (f *Factory[T]) RequireMaskedExports() *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.requireMask = true
+	return f
+}
+
+// Mask returns a copy of t with PII fields hidden. Strings become a stable token
+// ("masked:" plus a hash prefix), so equal values still match across rows and
+// tables; other types are zeroed.
+func (f *Factory[T]) Mask(t T) T {
+	fv := f.view()
+	f = &fv
+	v := reflect.ValueOf(&t).Elem()
+	for _, path := range f.pii {
+		maskPath(v, strings.Split(path, "."))
+	}
+	return t
+}
+
+// maskPath masks the field at path under the struct v. Pointers along the way are
+// replaced by masked copies, so the caller's item is never modified.
+func maskPath(v reflect.Value, path []string) {
+	field := v.FieldByName(path[0])
+	if len(path) > 1 {
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				return
+			}
+			cp := reflect.New(field.Type().Elem())
+			cp.Elem().Set(field.Elem())
+			field.Set(cp)
+			field = cp.Elem()
+		}
+		maskPath(field, path[1:])
+		return
+	}
+	if field.Kind() == reflect.String {
+		field.SetString(maskString(field.String()))
+		return
+	}
+	field.Set(reflect.Zero(field.Type()))
+}
+
+func maskString(s string) string {
+	if s == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(s))
+	return "masked:" + hex.EncodeToString(sum[:6])
+}
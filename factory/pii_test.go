@@ -0,0 +1,91 @@
+package factory
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFactory_MarkPII(t *testing.T) {
+	f := New(func(seq int64) User {
+		return User{ID: "1", Name: "Ada", Email: "ada@example.com"}
+	}).MarkPII("Name", "Email")
+
+	if got := f.PIIFields(); len(got) != 2 || got[0] != "Name" || got[1] != "Email" {
+		t.Fatalf("expected [Name Email], got %v", got)
+	}
+
+	u := f.Make()
+	masked := f.Mask(u)
+	if masked.ID != "1" {
+		t.Fatalf("expected non-PII field to be kept, got %q", masked.ID)
+	}
+	if masked.Email == u.Email || !strings.HasPrefix(masked.Email, "masked:") {
+		t.Fatalf("expected masked email, got %q", masked.Email)
+	}
+	if again := f.Mask(u); again.Email != masked.Email {
+		t.Fatalf("expected stable mask, got %q and %q", masked.Email, again.Email)
+	}
+	if u.Email != "ada@example.com" {
+		t.Fatalf("expected original to be unchanged, got %q", u.Email)
+	}
+
+	// Clone keeps the marking independent
+	c := f.Clone().MarkPII("ID")
+	if len(f.PIIFields()) != 2 || len(c.PIIFields()) != 3 {
+		t.Fatalf("expected clone to be independent, got %v and %v", f.PIIFields(), c.PIIFields())
+	}
+}
+
+type piiContact struct {
+	Email string
+	Phone string
+}
+
+type piiCustomer struct {
+	ID      string
+	Contact piiContact
+	Backup  *piiContact
+}
+
+func TestFactory_MarkPII_NestedPaths(t *testing.T) {
+	f := New(func(seq int64) piiCustomer {
+		return piiCustomer{
+			ID:      "c1",
+			Contact: piiContact{Email: "ada@example.com", Phone: "555"},
+			Backup:  &piiContact{Email: "backup@example.com"},
+		}
+	}).MarkPII("Contact.Email", "Backup.Email")
+
+	c := f.Make()
+	masked := f.Mask(c)
+	if !strings.HasPrefix(masked.Contact.Email, "masked:") || !strings.HasPrefix(masked.Backup.Email, "masked:") {
+		t.Fatalf("expected nested emails to be masked, got %+v / %+v", masked.Contact, *masked.Backup)
+	}
+	if masked.Contact.Phone != "555" || masked.ID != "c1" {
+		t.Fatalf("expected unmarked fields to be kept, got %+v", masked)
+	}
+	if c.Backup.Email != "backup@example.com" {
+		t.Fatalf("expected the original's pointed-to struct to be unchanged, got %q", c.Backup.Email)
+	}
+
+	nilBackup := piiCustomer{Contact: piiContact{Email: "x@example.com"}}
+	if got := f.Mask(nilBackup); got.Backup != nil {
+		t.Fatalf("expected a nil pointer to stay nil, got %+v", got.Backup)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic for unknown nested field")
+		}
+	}()
+	f.MarkPII("Contact.Fax")
+}
+
+func TestFactory_MarkPII_UnknownField(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic for unknown field")
+		}
+	}()
+	New(func(seq int64) User { return User{} }).MarkPII("Phone")
+}
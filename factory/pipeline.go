@@ -0,0 +1,77 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+)
+
+// persistStep is one named stage of a persist pipeline.
+type persistStep[T any] struct {
+	name string
+	fn   PersistFn[T]
+}
+
+// AddPersist appends a step to the persist pipeline (DB insert → cache write →
+// index push). Steps run in order, each receiving the previous step's output; a
+// failure stops the pipeline and is returned as a *PersistStepError naming the
+// step. A persist function set earlier with WithPersist becomes the first step,
+// named "persist"; a later WithPersist replaces the whole pipeline.
+// Steps are named "step N"; use AddNamedPersist for clearer errors.
+// Example: userFactory.WithPersist(repo.Insert).AddPersist(cacheUser).AddPersist(indexUser)
+func (f *Factory[T]) AddPersist(step PersistFn[T]) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	n := len(f.steps) + 1
+	if len(f.steps) == 0 && f.persist != nil {
+		n++
+	}
+	f.addStep(fmt.Sprintf("step %d", n), step)
+	return f
+}
+
+// AddNamedPersist is AddPersist with a name used in errors.
+// Example: userFactory.AddNamedPersist("db", repo.Insert).AddNamedPersist("cache", cacheUser)
+func (f *Factory[T]) AddNamedPersist(name string, step PersistFn[T]) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.addStep(name, step)
+	return f
+}
+
+// addStep appends a pipeline step; f must be locked by edit.
+func (f *Factory[T]) addStep(name string, step PersistFn[T]) {
+	if len(f.steps) == 0 && f.persist != nil {
+		f.steps = []persistStep[T]{{name: "persist", fn: f.persist}}
+	}
+	// Copy so factories sharing the slice (State, For, ...) don't see the new step
+	f.steps = append(append([]persistStep[T]{}, f.steps...), persistStep[T]{name: name, fn: step})
+	f.persist = runPipeline(f.steps)
+}
+
+// PersistSteps returns the names of the pipeline's steps, in order.
+func (f *Factory[T]) PersistSteps() []string {
+	v := f.view()
+	f = &v
+	names := make([]string, len(f.steps))
+	for i, st := range f.steps {
+		names[i] = st.name
+	}
+	return names
+}
+
+// runPipeline returns a persist function running steps in order. A step that
+// returns a nil record passes its input on unchanged.
+func runPipeline[T any](steps []persistStep[T]) PersistFn[T] {
+	return func(ctx context.Context, t *T) (*T, error) {
+		for i, st := range steps {
+			out, err := st.fn(ctx, t)
+			if err != nil {
+				return nil, &PersistStepError{Step: i + 1, Name: st.name, Err: err}
+			}
+			if out != nil {
+				t = out
+			}
+		}
+		return t, nil
+	}
+}
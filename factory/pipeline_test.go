@@ -0,0 +1,69 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFactory_AddPersist(t *testing.T) {
+	var calls []string
+	step := func(name string) PersistFn[User] {
+		return func(ctx context.Context, u *User) (*User, error) {
+			calls = append(calls, name+":"+u.ID)
+			out := *u
+			out.ID += name
+			return &out, nil
+		}
+	}
+
+	f := New(func(seq int64) User { return User{} }).
+		WithPersist(step("db")).
+		AddNamedPersist("cache", step("cache")).
+		AddPersist(step("index"))
+
+	if got := strings.Join(f.PersistSteps(), ","); got != "persist,cache,step 3" {
+		t.Fatalf("unexpected steps %q", got)
+	}
+	u := f.MustCreate(context.Background())
+	if u.ID != "dbcacheindex" {
+		t.Fatalf("expected each step to feed the next, got %q", u.ID)
+	}
+	if got := strings.Join(calls, " "); got != "db: cache:db index:dbcache" {
+		t.Fatalf("unexpected call order %q", got)
+	}
+
+	// WithPersist replaces the pipeline
+	f.WithPersist(step("only"))
+	if len(f.PersistSteps()) != 0 || f.MustCreate(context.Background()).ID != "only" {
+		t.Fatal("expected WithPersist to replace the pipeline")
+	}
+}
+
+func TestFactory_AddPersist_StepError(t *testing.T) {
+	boom := errors.New("redis down")
+	indexed := false
+	f := New(func(seq int64) User { return User{} }).
+		AddNamedPersist("db", func(ctx context.Context, u *User) (*User, error) { return u, nil }).
+		AddNamedPersist("cache", func(ctx context.Context, u *User) (*User, error) { return nil, boom }).
+		AddNamedPersist("index", func(ctx context.Context, u *User) (*User, error) {
+			indexed = true
+			return u, nil
+		})
+
+	_, err := f.Create(context.Background())
+	var stepErr *PersistStepError
+	if !errors.As(err, &stepErr) || stepErr.Step != 2 || stepErr.Name != "cache" || !errors.Is(err, boom) {
+		t.Fatalf("expected step 2 (cache) error, got %v", err)
+	}
+	if indexed {
+		t.Fatal("expected later steps to be skipped")
+	}
+
+	// Steps added to a clone don't leak into the original
+	f.Clone().AddNamedPersist("audit", func(ctx context.Context, u *User) (*User, error) { return u, nil })
+	if len(f.PersistSteps()) != 3 {
+		t.Fatalf("expected the original pipeline to keep 3 steps, got %v", f.PersistSteps())
+	}
+}
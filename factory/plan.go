@@ -0,0 +1,150 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Plan is a preview of what a Scenario would create, for --dry-run CLIs and
+// code review bots.
+type Plan struct {
+	Scenario  string
+	BeforeAll []string   // Run-level hooks, in order (not executed by Plan)
+	Steps     []PlanStep // In execution (dependency) order
+	AfterAll  []string   // Run-level hooks, in order (not executed by Plan)
+}
+
+// PlanStep lists the records one step would create, in the order their types
+// first appear (parents before the children created for them).
+type PlanStep struct {
+	Name    string
+	Creates []PlanEntry
+}
+
+// PlanEntry counts records of one type built with one set of states.
+type PlanEntry struct {
+	Type   string   // Go type (e.g., "factory.User")
+	States []string // Named states applied with State (e.g., "admin")
+	Count  int
+}
+
+// Rows returns the estimated number of rows the scenario would insert.
+func (p Plan) Rows() int {
+	n := 0
+	for _, st := range p.Steps {
+		for _, e := range st.Creates {
+			n += e.Count
+		}
+	}
+	return n
+}
+
+// String formats the plan one step per line, for logging.
+func (p Plan) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "scenario %q (%d rows)\n", p.Scenario, p.Rows())
+	for _, name := range p.BeforeAll {
+		fmt.Fprintf(&b, "  before-all %s\n", name)
+	}
+	for i, st := range p.Steps {
+		fmt.Fprintf(&b, "  %d. %s\n", i+1, st.Name)
+		for _, e := range st.Creates {
+			fmt.Fprintf(&b, "     %d x %s", e.Count, e.Type)
+			if len(e.States) > 0 {
+				fmt.Fprintf(&b, " [%s]", strings.Join(e.States, ", "))
+			}
+			b.WriteByte('\n')
+		}
+	}
+	for _, name := range p.AfterAll {
+		fmt.Fprintf(&b, "  after-all %s\n", name)
+	}
+	return b.String()
+}
+
+// Plan runs every step without persisting and returns what would be created.
+// Factory creates inside steps are counted instead of saved: persist functions and
+// user hooks are skipped, while relation hooks (WithOutbox, WithHistory) still run
+// so their records are counted too. Records are not assigned IDs.
+// BeforeAll/AfterAll hooks, assertions, and checkpoints are not run. Steps that
+// do work outside factories should check IsPlanning(ctx).
+func (s *Scenario) Plan(ctx context.Context) (Plan, error) {
+	p := &planner{}
+	ctx = context.WithValue(WithIdentityMap(ctx), planKey{}, p)
+	r := NewResults()
+
+	plan := Plan{Scenario: s.name}
+	for _, st := range s.beforeAll {
+		plan.BeforeAll = append(plan.BeforeAll, st.name)
+	}
+	for _, st := range s.afterAll {
+		plan.AfterAll = append(plan.AfterAll, st.name)
+	}
+	for _, st := range s.steps {
+		err := st.fn(ctx, r)
+		plan.Steps = append(plan.Steps, PlanStep{Name: st.name, Creates: p.take()})
+		if err != nil {
+			return plan, fmt.Errorf("factory: scenario %q plan step %q: %w", s.name, st.name, err)
+		}
+	}
+	return plan, nil
+}
+
+// IsPlanning reports whether ctx belongs to a Scenario.Plan run.
+func IsPlanning(ctx context.Context) bool {
+	return plannerFrom(ctx) != nil
+}
+
+type planKey struct{}
+
+// planner counts creates for the current step. Safe for concurrent use.
+type planner struct {
+	mu      sync.Mutex
+	entries []PlanEntry
+}
+
+func plannerFrom(ctx context.Context) *planner {
+	p, _ := ctx.Value(planKey{}).(*planner)
+	return p
+}
+
+func (p *planner) record(typ string, states []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, e := range p.entries {
+		if e.Type == typ && strings.Join(e.States, ",") == strings.Join(states, ",") {
+			p.entries[i].Count++
+			return
+		}
+	}
+	p.entries = append(p.entries, PlanEntry{Type: typ, States: states, Count: 1})
+}
+
+// take returns and clears the entries recorded so far.
+func (p *planner) take() []PlanEntry {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := p.entries
+	p.entries = nil
+	return out
+}
+
+// planSave counts obj instead of persisting it, then runs relation hooks.
+func (f *Factory[T]) planSave(ctx context.Context, p *planner, obj *T) (*T, error) {
+	var states []string
+	for _, name := range f.traitNames {
+		if state, ok := strings.CutPrefix(name, "state:"); ok {
+			states = append(states, state)
+		}
+	}
+	p.record(fmt.Sprintf("%T", *obj), states)
+
+	for _, i := range f.planHooks {
+		if err := f.after[i](ctx, obj); err != nil {
+			return nil, err
+		}
+	}
+	return obj, nil
+}
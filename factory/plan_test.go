@@ -0,0 +1,99 @@
+package factory
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScenario_Plan(t *testing.T) {
+	persisted := 0
+	userFactory := New(func(seq int64) User { return User{Name: "u"} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) {
+			persisted++
+			return u, nil
+		}).
+		DefineState("admin", func(u *User) { u.Name = "admin" })
+	postFactory := New(func(seq int64) Post { return Post{} }).
+		WithPersist(func(ctx context.Context, p *Post) (*Post, error) {
+			persisted++
+			return p, nil
+		})
+
+	beforeRan := false
+	s := NewScenario("blog").
+		BeforeAll("disable fks", func(ctx context.Context, r *Results) error {
+			beforeRan = true
+			return nil
+		}).
+		Step("admins", CreateStep("admins", userFactory.State("admin"), 2)).
+		Step("authors", func(ctx context.Context, r *Results) error {
+			for i := 0; i < 3; i++ {
+				if _, _, err := Has(userFactory, postFactory, 4, func(u *User, p *Post) {}).Create(ctx); err != nil {
+					return err
+				}
+			}
+			if !IsPlanning(ctx) {
+				t.Error("expected IsPlanning inside Plan")
+			}
+			return nil
+		}).
+		AfterAll("reindex", func(ctx context.Context, r *Results) error { return nil })
+
+	plan, err := s.Plan(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if persisted != 0 || beforeRan {
+		t.Fatalf("expected nothing to run for real, got %d persists, before-all %v", persisted, beforeRan)
+	}
+	if plan.Rows() != 17 || len(plan.Steps) != 2 {
+		t.Fatalf("expected 17 rows in 2 steps, got %d in %d", plan.Rows(), len(plan.Steps))
+	}
+	admins := plan.Steps[0].Creates
+	if len(admins) != 1 || admins[0].Count != 2 || admins[0].Type != "factory.User" || admins[0].States[0] != "admin" {
+		t.Fatalf("unexpected admins step %+v", admins)
+	}
+	authors := plan.Steps[1].Creates
+	if len(authors) != 2 || authors[0].Type != "factory.User" || authors[0].Count != 3 || authors[1].Type != "factory.Post" || authors[1].Count != 12 {
+		t.Fatalf("unexpected authors step %+v", authors)
+	}
+
+	out := plan.String()
+	for _, want := range []string{`scenario "blog" (17 rows)`, "before-all disable fks", "2 x factory.User [admin]", "12 x factory.Post", "after-all reindex"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected plan output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if IsPlanning(context.Background()) {
+		t.Fatal("expected IsPlanning false outside Plan")
+	}
+}
+
+func TestScenario_PlanSkipsUserHooks(t *testing.T) {
+	store := NewMemoryRetentionStore()
+	ret := NewRetention(store, "ci", time.Hour)
+	hookRan := false
+	userFactory := New(func(seq int64) User { return User{Name: "u"} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) { return u, nil }).
+		AfterCreate(func(ctx context.Context, u *User) error {
+			hookRan = true
+			return nil
+		})
+	eventFactory := New(func(seq int64) Event { return Event{} }).
+		WithPersist(func(ctx context.Context, e *Event) (*Event, error) { return e, nil })
+	users := TrackRetention(userFactory, ret, func(u *User) string { return u.Name }, func(ctx context.Context, id string) error { return nil })
+	users = WithOutbox(users, eventFactory, func(u *User, e *Event, v int) { e.Type = "UserRegistered" })
+
+	plan, err := NewScenario("tracked").Step("users", CreateStep("users", users, 2)).Plan(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hookRan || len(store.Records()) != 0 {
+		t.Fatalf("expected user hooks to be skipped, got hook %v and %d retention records", hookRan, len(store.Records()))
+	}
+	if plan.Rows() != 4 {
+		t.Fatalf("expected outbox events to be counted, got %d rows", plan.Rows())
+	}
+}
@@ -0,0 +1,58 @@
+package factory
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// WithPool enables a sync.Pool of scratch values for RawJSON and RawManyJSON.
+// Items are built in a reused *T and serialized directly, saving one allocation per item.
+// Only use this when callers need the bytes; Tap still receives a copy of each item.
+func (f *Factory[T]) WithPool() *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.pool = &sync.Pool{
+		New: func() any { return new(T) },
+	}
+	return f
+}
+
+// pooledRawJSON builds one item into a pooled value and marshals it.
+func (f *Factory[T]) pooledRawJSON(ts ...Trait[T]) ([]byte, error) {
+	t := f.pool.Get().(*T)
+	defer f.release(t)
+
+	f.rawInto(t, ts...)
+	return json.Marshal(t)
+}
+
+// pooledRawManyJSON builds count items one at a time, reusing a single pooled value.
+// The output is identical to marshaling the []T returned by RawMany.
+func (f *Factory[T]) pooledRawManyJSON(count int, ts ...Trait[T]) ([]byte, error) {
+	t := f.pool.Get().(*T)
+	defer f.release(t)
+
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		f.rawInto(t, ts...)
+		data, err := json.Marshal(t)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// release zeroes t so the pool does not retain references, then returns it.
+func (f *Factory[T]) release(t *T) {
+	var zero T
+	*t = zero
+	f.pool.Put(t)
+}
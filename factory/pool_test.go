@@ -0,0 +1,64 @@
+package factory
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestFactory_WithPoolRawJSON(t *testing.T) {
+	newFactory := func() *Factory[User] {
+		return New(func(seq int64) User {
+			return User{
+				ID:   fmt.Sprintf("user-%d", seq),
+				Name: fmt.Sprintf("User %d", seq),
+			}
+		}).WithRawDefaults(func(u *User) {
+			u.Email = "raw@example.com"
+		})
+	}
+
+	plain := newFactory()
+	pooled := newFactory().WithPool()
+
+	for i := 0; i < 3; i++ {
+		want := plain.MustRawJSON()
+		got := pooled.MustRawJSON()
+		if !bytes.Equal(want, got) {
+			t.Fatalf("item %d: expected %s, got %s", i, want, got)
+		}
+	}
+}
+
+func TestFactory_WithPoolRawManyJSON(t *testing.T) {
+	newFactory := func() *Factory[User] {
+		return New(func(seq int64) User {
+			return User{ID: fmt.Sprintf("user-%d", seq)}
+		})
+	}
+
+	want := newFactory().MustRawManyJSON(4)
+	got := newFactory().WithPool().MustRawManyJSON(4)
+	if !bytes.Equal(want, got) {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	empty := newFactory().WithPool().MustRawManyJSON(0)
+	if string(empty) != "[]" {
+		t.Fatalf("expected empty array, got %s", empty)
+	}
+}
+
+func TestFactory_WithPoolDoesNotLeakState(t *testing.T) {
+	f := New(func(seq int64) User {
+		return User{ID: fmt.Sprintf("user-%d", seq)}
+	}).WithPool()
+
+	// First item sets a field the second item never touches
+	f.MustRawJSON(func(u *User) { u.Email = "first@example.com" })
+	data := f.MustRawJSON()
+
+	if bytes.Contains(data, []byte("first@example.com")) {
+		t.Fatalf("expected pooled value to be reset, got %s", data)
+	}
+}
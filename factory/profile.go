@@ -0,0 +1,200 @@
+package factory
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// DataProfile summarizes the values a factory generates, to check that defaults
+// produce realistic variety before seeding a large environment.
+type DataProfile struct {
+	N      int          // Items sampled
+	Fields []FieldStats // One entry per leaf field, in struct order
+}
+
+// FieldStats describes the generated values of one field.
+type FieldStats struct {
+	Field     string       // Dotted path, e.g. "Address.City"
+	Kind      string       // reflect kind of the field ("string", "int", "ptr", ...)
+	Distinct  int          // Number of distinct values
+	Empty     int          // Nil pointers/slices/maps and zero values
+	EmptyRate float64      // Empty divided by the number of items
+	Numeric   bool         // Whether Min, Max, and Mean are set
+	Min       float64      // Smallest numeric value (non-nil only)
+	Max       float64      // Largest numeric value (non-nil only)
+	Mean      float64      // Mean numeric value (non-nil only)
+	Top       []ValueCount // Most common values, most frequent first (at most 5)
+}
+
+// ValueCount is a value and how many sampled items had it.
+type ValueCount struct {
+	Value string
+	Count int
+}
+
+// Profile builds n items (without advancing the sequence or running Tap) and returns
+// per-field statistics: distinct counts, empty rates, min/max/mean for numbers, and
+// the most common values. Profile(0) returns an empty profile; panics if n is negative.
+// Example: t.Log(userFactory.Profile(1000))
+func (f *Factory[T]) Profile(n int, ts ...Trait[T]) DataProfile {
+	if n < 0 {
+		panic("factory: Profile requires n >= 0")
+	}
+	items := f.dryRun().MakeMany(n, ts...)
+
+	var order []string
+	acc := make(map[string]*fieldAcc)
+	for i := range items {
+		record := func(path string, v reflect.Value, empty bool) {
+			a, ok := acc[path]
+			if !ok {
+				a = &fieldAcc{kind: v.Kind().String(), counts: make(map[string]int), min: math.Inf(1), max: math.Inf(-1)}
+				acc[path] = a
+				order = append(order, path)
+			}
+			a.add(v, empty)
+		}
+
+		v := reflect.ValueOf(&items[i]).Elem()
+		if v.Kind() == reflect.Struct && v.Type() != timeType {
+			walkFields(v, "", record)
+		} else {
+			record("(value)", v, isEmpty(v))
+		}
+	}
+
+	p := DataProfile{N: n, Fields: make([]FieldStats, len(order))}
+	for i, path := range order {
+		p.Fields[i] = acc[path].stats(path, n)
+	}
+	return p
+}
+
+// String formats the profile as an aligned table.
+func (p DataProfile) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d items\n", p.N)
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FIELD\tKIND\tDISTINCT\tEMPTY\tMIN\tMAX\tMEAN\tTOP")
+	for _, s := range p.Fields {
+		empty := fmt.Sprintf("%.0f%%", s.EmptyRate*100)
+		minV, maxV, mean := "-", "-", "-"
+		if s.Numeric {
+			minV, maxV, mean = fmt.Sprint(s.Min), fmt.Sprint(s.Max), fmt.Sprintf("%.2f", s.Mean)
+		}
+		top := make([]string, len(s.Top))
+		for i, vc := range s.Top {
+			top[i] = fmt.Sprintf("%q×%d", vc.Value, vc.Count)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\n", s.Field, s.Kind, s.Distinct, empty, minV, maxV, mean, strings.Join(top, " "))
+	}
+	w.Flush() // Writes to a strings.Builder, which cannot fail
+	return b.String()
+}
+
+// fieldAcc accumulates values for one field.
+type fieldAcc struct {
+	kind     string
+	counts   map[string]int
+	empty    int
+	numeric  bool
+	min, max float64
+	sum      float64
+	nums     int
+}
+
+func (a *fieldAcc) add(v reflect.Value, empty bool) {
+	if empty {
+		a.empty++
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			a.counts["<nil>"]++
+			return
+		}
+		v = v.Elem()
+	}
+	a.counts[fmt.Sprint(v.Interface())]++
+
+	if x, ok := numeric(v); ok {
+		a.numeric = true
+		a.min = math.Min(a.min, x)
+		a.max = math.Max(a.max, x)
+		a.sum += x
+		a.nums++
+	}
+}
+
+func (a *fieldAcc) stats(path string, n int) FieldStats {
+	s := FieldStats{Field: path, Kind: a.kind, Distinct: len(a.counts), Empty: a.empty}
+	if n > 0 {
+		s.EmptyRate = float64(a.empty) / float64(n)
+	}
+	if a.numeric {
+		s.Numeric, s.Min, s.Max, s.Mean = true, a.min, a.max, a.sum/float64(a.nums)
+	}
+
+	for value, count := range a.counts {
+		s.Top = append(s.Top, ValueCount{Value: value, Count: count})
+	}
+	sort.Slice(s.Top, func(i, j int) bool {
+		if s.Top[i].Count != s.Top[j].Count {
+			return s.Top[i].Count > s.Top[j].Count
+		}
+		return s.Top[i].Value < s.Top[j].Value
+	})
+	if len(s.Top) > 5 {
+		s.Top = s.Top[:5]
+	}
+	return s
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// walkFields calls fn for every exported leaf field of v, descending into nested
+// structs (but not time.Time, which is a leaf).
+func walkFields(v reflect.Value, prefix string, fn func(path string, v reflect.Value, empty bool)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		path := sf.Name
+		if prefix != "" {
+			path = prefix + "." + sf.Name
+		}
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Struct && fv.Type() != timeType {
+			walkFields(fv, path, fn)
+			continue
+		}
+		fn(path, fv, isEmpty(fv))
+	}
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+func numeric(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,87 @@
+package factory
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type Listing struct {
+	ID      string
+	Price   int
+	Rating  *float64
+	Tags    []string
+	Address struct {
+		City string
+	}
+	secret string
+}
+
+func TestFactory_Profile(t *testing.T) {
+	cities := []string{"Paris", "Paris", "Oslo", "Lima"}
+	f := New(func(seq int64) Listing {
+		l := Listing{ID: fmt.Sprintf("listing-%d", seq), Price: int(seq) * 10, secret: "hidden"}
+		if seq%2 == 0 {
+			r := 4.5
+			l.Rating = &r
+		}
+		l.Address.City = cities[(seq-1)%4]
+		return l
+	})
+
+	p := f.Profile(4)
+	if p.N != 4 || len(p.Fields) != 5 {
+		t.Fatalf("expected 5 exported leaf fields for 4 items, got %+v", p)
+	}
+
+	byName := map[string]FieldStats{}
+	for _, s := range p.Fields {
+		byName[s.Field] = s
+	}
+
+	if s := byName["ID"]; s.Distinct != 4 || s.Numeric {
+		t.Fatalf("expected 4 distinct IDs, got %+v", s)
+	}
+	if s := byName["Price"]; !s.Numeric || s.Min != 10 || s.Max != 40 || s.Mean != 25 {
+		t.Fatalf("expected price stats 10/40/25, got %+v", s)
+	}
+	if s := byName["Rating"]; s.Empty != 2 || s.EmptyRate != 0.5 || s.Mean != 4.5 {
+		t.Fatalf("expected half nil ratings, got %+v", s)
+	}
+	if s := byName["Tags"]; s.EmptyRate != 1 {
+		t.Fatalf("expected empty tags, got %+v", s)
+	}
+	if s := byName["Address.City"]; s.Distinct != 3 || s.Top[0] != (ValueCount{Value: "Paris", Count: 2}) {
+		t.Fatalf("expected Paris as top city, got %+v", s)
+	}
+
+	// Profiling does not advance the sequence
+	if next := f.Make(); next.ID != "listing-1" {
+		t.Fatalf("expected sequence to be untouched, got %s", next.ID)
+	}
+
+	out := p.String()
+	if !strings.Contains(out, "Address.City") || !strings.Contains(out, `"Paris"×2`) {
+		t.Fatalf("expected table output, got:\n%s", out)
+	}
+}
+
+func TestFactory_ProfileNonStruct(t *testing.T) {
+	p := New(func(seq int64) int { return int(seq % 2) }).Profile(4)
+	if len(p.Fields) != 1 || p.Fields[0].Field != "(value)" || p.Fields[0].Distinct != 2 {
+		t.Fatalf("expected a single value field, got %+v", p.Fields)
+	}
+}
+
+func TestFactory_ProfileEmpty(t *testing.T) {
+	p := New(func(seq int64) Listing { return Listing{} }).Profile(0)
+	if p.N != 0 || len(p.Fields) != 0 {
+		t.Fatalf("expected an empty profile, got %+v", p)
+	}
+	if out := p.String(); strings.Contains(out, "NaN") {
+		t.Fatalf("expected no NaN in the report, got:\n%s", out)
+	}
+	if s := (&fieldAcc{counts: map[string]int{}}).stats("X", 0); s.EmptyRate != 0 {
+		t.Fatalf("expected a zero empty rate for no items, got %v", s.EmptyRate)
+	}
+}
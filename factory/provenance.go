@@ -0,0 +1,115 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Provenance describes where test data came from, so rows found in shared
+// environments can be traced back to the seeder that created them.
+type Provenance struct {
+	Factory  string   `json:"factory"`            // Factory name (see WithName)
+	States   []string `json:"states,omitempty"`   // States applied with State
+	Seed     int64    `json:"seed,omitempty"`     // Group seed (see Group.WithSeed) or config seed
+	Revision string   `json:"revision,omitempty"` // VCS revision of the binary (see BuildRevision)
+}
+
+// String formats p as space-separated key=value pairs, e.g.
+// "factory=users states=admin,verified seed=42 rev=1a2b3c4".
+func (p Provenance) String() string {
+	parts := []string{"factory=" + p.Factory}
+	if len(p.States) > 0 {
+		parts = append(parts, "states="+strings.Join(p.States, ","))
+	}
+	if p.Seed != 0 {
+		parts = append(parts, fmt.Sprintf("seed=%d", p.Seed))
+	}
+	if p.Revision != "" {
+		parts = append(parts, "rev="+p.Revision)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Provenance returns the provenance of items built by the factory as configured now.
+func (f *Factory[T]) Provenance() Provenance {
+	v := f.view()
+	f = &v
+	p := Provenance{Factory: f.Name(), Seed: f.probConfig.Seed, Revision: BuildRevision()}
+	if f.group != nil && f.group.Seed() != 0 {
+		p.Seed = f.group.Seed()
+	}
+	for i := range f.traits {
+		// Probabilistic config states are not applied to every item, so they are left out
+		if state, ok := strings.CutPrefix(f.traitName(i), "state:"); ok && !slices.Contains(f.probTraits, i) {
+			p.States = append(p.States, state)
+		}
+	}
+	return p
+}
+
+// WithProvenance stamps every built item's field with Provenance().String(). It runs
+// in Make after all traits, so created records and fixtures exported from made items
+// both carry it.
+// Example: userFactory.WithProvenance(func(u *User) *string { return &u.SeededBy })
+func (f *Factory[T]) WithProvenance(field func(*T) *string) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.provenance = field
+	return f
+}
+
+// SidecarFn records an item's provenance outside the item itself.
+type SidecarFn[T any] func(ctx context.Context, item *T, p Provenance) error
+
+// WithProvenanceSidecar calls fn with each persisted item and its provenance, before
+// AfterCreate hooks, for models without a spare field (e.g., insert a row into a
+// seed_provenance table). An error fails Create like a persist error.
+func (f *Factory[T]) WithProvenanceSidecar(fn SidecarFn[T]) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.sidecar = fn
+	return f
+}
+
+func (f *Factory[T]) applyProvenance(t *T) {
+	if f.provenance != nil {
+		*f.provenance(t) = f.Provenance().String()
+	}
+}
+
+var (
+	revisionOnce sync.Once
+	revision     string
+)
+
+// BuildRevision returns the VCS revision the running binary was built from (shortened
+// to 12 characters, with "-dirty" for modified trees), or "" when unknown (e.g., in
+// `go test` binaries, which carry no VCS stamp).
+func BuildRevision() string {
+	revisionOnce.Do(func() {
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		var dirty bool
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				revision = s.Value
+			case "vcs.modified":
+				dirty = s.Value == "true"
+			}
+		}
+		if len(revision) > 12 {
+			revision = revision[:12]
+		}
+		if revision != "" && dirty {
+			revision += "-dirty"
+		}
+	})
+	return revision
+}
@@ -0,0 +1,116 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type Seeded struct {
+	ID       string
+	Role     string
+	SeededBy string
+}
+
+func TestFactory_WithProvenance(t *testing.T) {
+	g := NewGroup().WithSeed(42)
+	f := NewIn(g, func(seq int64) Seeded { return Seeded{} }).
+		WithName("seeded").
+		DefineState("admin", func(s *Seeded) { s.Role = "admin" }).
+		WithProvenance(func(s *Seeded) *string { return &s.SeededBy })
+
+	want := "factory=seeded states=admin seed=42"
+	if rev := BuildRevision(); rev != "" {
+		want += " rev=" + rev
+	}
+	if got := f.State("admin").Make().SeededBy; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	p := f.State("admin").Provenance()
+	if p.Factory != "seeded" || len(p.States) != 1 || p.States[0] != "admin" || p.Seed != 42 {
+		t.Fatalf("unexpected provenance %+v", p)
+	}
+
+	if got := f.Compile()().SeededBy; got != f.Provenance().String() {
+		t.Fatalf("expected Compile to stamp %q, got %q", f.Provenance().String(), got)
+	}
+}
+
+func TestFactory_WithProvenanceSidecar(t *testing.T) {
+	var records []Provenance
+	f := New(func(seq int64) Seeded { return Seeded{ID: "s1"} }).
+		WithPersist(func(ctx context.Context, s *Seeded) (*Seeded, error) { return s, nil }).
+		WithProvenanceSidecar(func(ctx context.Context, s *Seeded, p Provenance) error {
+			records = append(records, p)
+			return nil
+		})
+	ctx := context.Background()
+
+	f.MustCreate(ctx)
+	if len(records) != 1 || records[0].Factory != "factory.Seeded" {
+		t.Fatalf("expected one sidecar record for factory.Seeded, got %+v", records)
+	}
+
+	boom := errors.New("boom")
+	f.WithProvenanceSidecar(func(ctx context.Context, s *Seeded, p Provenance) error { return boom })
+	if _, err := f.Create(ctx); !errors.Is(err, boom) {
+		t.Fatalf("expected sidecar error, got %v", err)
+	}
+}
+
+func TestFactory_WithProvenanceSidecarBatch(t *testing.T) {
+	var ids []string
+	f := New(func(seq int64) Seeded { return Seeded{} }).
+		WithPersist(func(ctx context.Context, s *Seeded) (*Seeded, error) { return s, nil }).
+		WithPersistMany(func(ctx context.Context, items []*Seeded) ([]*Seeded, error) {
+			for i, s := range items {
+				s.ID = fmt.Sprintf("s%d", i+1)
+			}
+			return items, nil
+		}).
+		DefineState("admin", func(s *Seeded) { s.Role = "admin" }).
+		WithProvenanceSidecar(func(ctx context.Context, s *Seeded, p Provenance) error {
+			if len(p.States) != 1 || p.States[0] != "admin" {
+				t.Errorf("expected the admin state in the provenance, got %+v", p)
+			}
+			ids = append(ids, s.ID)
+			return nil
+		})
+	ctx := context.Background()
+
+	if _, err := f.State("admin").CreateManyBatch(ctx, 3); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 || ids[0] != "s1" || ids[2] != "s3" {
+		t.Fatalf("expected a sidecar record per persisted item, got %v", ids)
+	}
+
+	boom := errors.New("boom")
+	f.WithProvenanceSidecar(func(ctx context.Context, s *Seeded, p Provenance) error { return boom })
+	if _, err := f.CreateManyBatch(ctx, 2); !errors.Is(err, boom) {
+		t.Fatalf("expected sidecar error from the batch, got %v", err)
+	}
+}
+
+func TestFactory_Provenance_StateNamedLikeProbability(t *testing.T) {
+	f := newMemberFactory().DefineState("odd (p=0.50)", func(m *Member) { m.Role = "odd" })
+	configured, err := ApplyConfig(f.State("odd (p=0.50)"), FactoryConfig{Probabilities: map[string]float64{"admin": 0.5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := configured.Factory().Provenance().States; len(got) != 1 || got[0] != "odd (p=0.50)" {
+		t.Fatalf("expected only the applied state in provenance, got %q", got)
+	}
+}
+
+func TestProvenance_String(t *testing.T) {
+	p := Provenance{Factory: "users", States: []string{"admin", "verified"}, Seed: 7, Revision: "abc"}
+	if got := p.String(); got != "factory=users states=admin,verified seed=7 rev=abc" {
+		t.Fatalf("unexpected %q", got)
+	}
+	if got := (Provenance{Factory: "users"}).String(); got != "factory=users" {
+		t.Fatalf("unexpected %q", got)
+	}
+}
@@ -0,0 +1,126 @@
+package factory
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Results is a bag of records produced by a Scenario, keyed by name.
+// Values are typically []*T slices from CreateStep or FixtureStep.
+type Results struct {
+	values map[string]any
+	keys   []string // Insertion order
+}
+
+// NewResults creates an empty result bag.
+func NewResults() *Results {
+	return &Results{values: make(map[string]any)}
+}
+
+// Set stores v under key, replacing any previous value.
+func (r *Results) Set(key string, v any) {
+	if _, ok := r.values[key]; !ok {
+		r.keys = append(r.keys, key)
+	}
+	r.values[key] = v
+}
+
+// Keys returns the stored keys in insertion order.
+func (r *Results) Keys() []string {
+	return append([]string{}, r.keys...)
+}
+
+// Lookup returns the value stored under key if it exists and has type V.
+func Lookup[V any](r *Results, key string) (V, bool) {
+	v, ok := decode[V](r, key).(V)
+	return v, ok
+}
+
+// Get returns the value stored under key as type V.
+// Panics if the key is missing or holds a different type (programming error).
+// Example: authors := Get[[]*User](results, "authors")
+func Get[V any](r *Results, key string) V {
+	if _, ok := r.values[key]; !ok {
+		panic("factory: no result stored under '" + key + "'")
+	}
+	v, ok := decode[V](r, key).(V)
+	if !ok {
+		panic("factory: result '" + key + "' has a different type")
+	}
+	return v
+}
+
+// Add appends items to the []*T stored under key, creating it if needed.
+// Panics if key already holds a different type.
+func Add[T any](r *Results, key string, items ...*T) {
+	if _, ok := r.values[key]; !ok {
+		r.Set(key, append([]*T{}, items...))
+		return
+	}
+	existing := Get[[]*T](r, key)
+	r.values[key] = append(existing, items...)
+}
+
+// All returns every *T in the bag across all keys, in insertion order.
+// Values stored as []*T or *T are included; other types are skipped.
+// Example: users := All[User](results)
+func All[T any](r *Results) []*T {
+	var out []*T
+	for _, key := range r.keys {
+		switch v := records[T](r, key).(type) {
+		case []*T:
+			out = append(out, v...)
+		case *T:
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// First returns the first *T stored under key, or nil if there is none.
+// Example: post := First[Post](results, "published")
+func First[T any](r *Results, key string) *T {
+	switch v := records[T](r, key).(type) {
+	case []*T:
+		if len(v) > 0 {
+			return v[0]
+		}
+	case *T:
+		return v
+	}
+	return nil
+}
+
+// restored is a result loaded from a checkpoint. It stays JSON until it is first
+// read as its original type.
+type restored struct {
+	data json.RawMessage
+	typ  string // %T of the original value
+}
+
+// decode returns the value stored under key, decoding a restored value into V
+// (and caching it) when V is the type it was saved as.
+func decode[V any](r *Results, key string) any {
+	rv, ok := r.values[key].(restored)
+	if !ok {
+		return r.values[key]
+	}
+	var v V
+	if fmt.Sprintf("%T", v) != rv.typ {
+		return rv
+	}
+	if err := json.Unmarshal(rv.data, &v); err != nil {
+		return rv
+	}
+	r.values[key] = v
+	return v
+}
+
+// records returns the value stored under key, decoding restored []*T or *T values.
+func records[T any](r *Results, key string) any {
+	v := decode[[]*T](r, key)
+	if _, ok := v.(restored); ok {
+		return decode[*T](r, key)
+	}
+	return v
+}
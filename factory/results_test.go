@@ -0,0 +1,43 @@
+package factory
+
+import (
+	"testing"
+)
+
+func TestResults_TypedAccessors(t *testing.T) {
+	r := NewResults()
+	Add(r, "admins", &User{ID: "admin-1"})
+	Add(r, "authors", &User{ID: "author-1"}, &User{ID: "author-2"})
+	Add(r, "admins", &User{ID: "admin-2"})
+	r.Set("owner", &User{ID: "owner"})
+	r.Set("published", []*Post{{ID: "post-1"}, {ID: "post-2"}})
+	r.Set("count", 3)
+
+	users := All[User](r)
+	want := []string{"admin-1", "admin-2", "author-1", "author-2", "owner"}
+	if len(users) != len(want) {
+		t.Fatalf("expected %d users, got %d", len(want), len(users))
+	}
+	for i, u := range users {
+		if u.ID != want[i] {
+			t.Fatalf("user %d: expected %q, got %q", i, want[i], u.ID)
+		}
+	}
+
+	if posts := All[Post](r); len(posts) != 2 {
+		t.Fatalf("expected 2 posts, got %d", len(posts))
+	}
+
+	if p := First[Post](r, "published"); p == nil || p.ID != "post-1" {
+		t.Fatalf("expected first published post, got %+v", p)
+	}
+	if u := First[User](r, "owner"); u == nil || u.ID != "owner" {
+		t.Fatalf("expected owner, got %+v", u)
+	}
+	if p := First[Post](r, "admins"); p != nil {
+		t.Fatalf("expected nil for mismatched type, got %+v", p)
+	}
+	if u := First[User](r, "missing"); u != nil {
+		t.Fatalf("expected nil for missing key, got %+v", u)
+	}
+}
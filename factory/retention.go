@@ -0,0 +1,199 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RetentionRecord marks one seeded row for garbage collection.
+type RetentionRecord struct {
+	Type      string    // Go type of the row (e.g., "factory.User")
+	ID        string    // Row identifier passed to the type's delete function
+	SeededBy  string    // Who or what seeded it (e.g., a CI job or developer)
+	ExpiresAt time.Time // When PurgeExpired may delete it
+}
+
+// RetentionStore is the companion table that tracks seeded rows.
+// NewMemoryRetentionStore is an in-process implementation; a database-backed one
+// lets any later process purge expired rows.
+type RetentionStore interface {
+	Track(ctx context.Context, rec RetentionRecord) error
+	Expired(ctx context.Context, now time.Time) ([]RetentionRecord, error)
+	Forget(ctx context.Context, rec RetentionRecord) error
+}
+
+// Retention stamps seeded rows with a "seeded-by/expires-at" marker and purges
+// them once expired, so shared environments can garbage-collect factory data.
+type Retention struct {
+	store    RetentionStore
+	seededBy string
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu       sync.Mutex
+	deleters map[string]func(ctx context.Context, id string) error
+	sweepers []func(ctx context.Context, now time.Time) (int, error)
+}
+
+// NewRetention creates a retention policy: rows are tagged with seededBy and expire after ttl.
+// store may be nil when only field stamps and sweepers are used.
+// Example: ret := NewRetention(store, "ci-job-42", 24*time.Hour)
+func NewRetention(store RetentionStore, seededBy string, ttl time.Duration) *Retention {
+	return &Retention{
+		store:    store,
+		seededBy: seededBy,
+		ttl:      ttl,
+		now:      time.Now,
+		deleters: make(map[string]func(ctx context.Context, id string) error),
+	}
+}
+
+// WithClock sets the clock used for expiry times and PurgeExpired.
+func (r *Retention) WithClock(now func() time.Time) *Retention {
+	r.now = now
+	return r
+}
+
+// ExpiresAt returns the expiry time for a row seeded now.
+func (r *Retention) ExpiresAt() time.Time {
+	return r.now().Add(r.ttl)
+}
+
+// Sweep registers a function that deletes rows whose expiry field is before now
+// (e.g., DELETE FROM users WHERE expires_at < ?), for rows stamped with RetentionStamp.
+// It returns how many rows were deleted.
+func (r *Retention) Sweep(fn func(ctx context.Context, now time.Time) (int, error)) *Retention {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sweepers = append(r.sweepers, fn)
+	return r
+}
+
+// PurgeExpired deletes every expired row: tracked rows through their type's delete
+// function (then forgets them), and stamped rows through the sweepers.
+// Returns how many rows were deleted; failures are joined and don't stop the purge.
+func (r *Retention) PurgeExpired(ctx context.Context) (int, error) {
+	now := r.now()
+	r.mu.Lock()
+	deleters := make(map[string]func(ctx context.Context, id string) error, len(r.deleters))
+	for k, v := range r.deleters {
+		deleters[k] = v
+	}
+	sweepers := append([]func(context.Context, time.Time) (int, error){}, r.sweepers...)
+	r.mu.Unlock()
+
+	purged := 0
+	var errs []error
+	if r.store != nil {
+		expired, err := r.store.Expired(ctx, now)
+		if err != nil {
+			return 0, fmt.Errorf("factory: retention: %w", err)
+		}
+		for _, rec := range expired {
+			del, ok := deleters[rec.Type]
+			if !ok {
+				errs = append(errs, fmt.Errorf("factory: retention: no delete function for %s", rec.Type))
+				continue
+			}
+			if err := del(ctx, rec.ID); err != nil {
+				errs = append(errs, fmt.Errorf("factory: retention: delete %s %s: %w", rec.Type, rec.ID, err))
+				continue
+			}
+			if err := r.store.Forget(ctx, rec); err != nil {
+				errs = append(errs, fmt.Errorf("factory: retention: forget %s %s: %w", rec.Type, rec.ID, err))
+			}
+			purged++
+		}
+	}
+	for _, sweep := range sweepers {
+		n, err := sweep(ctx, now)
+		purged += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("factory: retention: sweep: %w", err))
+		}
+	}
+	return purged, errors.Join(errs...)
+}
+
+// RetentionStamp returns a trait that writes the marker into fields of the row itself.
+// Example: f.WithTraits(RetentionStamp(ret, func(u *User, by string, at time.Time) { u.SeededBy, u.ExpiresAt = by, at }))
+func RetentionStamp[T any](r *Retention, set func(t *T, seededBy string, expiresAt time.Time)) Trait[T] {
+	return func(t *T) {
+		set(t, r.seededBy, r.ExpiresAt())
+	}
+}
+
+// TrackRetention returns a copy of f that records each created row in the
+// retention store (the companion table), and registers del so PurgeExpired can
+// delete the row by id. Panics if r has no store.
+// Example: users := TrackRetention(userFactory, ret, func(u *User) string { return u.ID }, deleteUser)
+func TrackRetention[T any](f *Factory[T], r *Retention, id func(*T) string, del func(ctx context.Context, id string) error) *Factory[T] {
+	if r.store == nil {
+		panic("factory: TrackRetention requires a Retention with a store")
+	}
+	typ := typeName[T]()
+	r.mu.Lock()
+	r.deleters[typ] = del
+	r.mu.Unlock()
+
+	tracked := f.shallow()
+	tracked.after = grow(tracked.after, func(ctx context.Context, t *T) error {
+		return r.store.Track(ctx, RetentionRecord{Type: typ, ID: id(t), SeededBy: r.seededBy, ExpiresAt: r.ExpiresAt()})
+	})
+	return tracked
+}
+
+// MemoryRetentionStore is an in-process RetentionStore. Safe for concurrent use.
+type MemoryRetentionStore struct {
+	mu      sync.Mutex
+	records []RetentionRecord
+}
+
+// NewMemoryRetentionStore creates an empty store.
+func NewMemoryRetentionStore() *MemoryRetentionStore {
+	return &MemoryRetentionStore{}
+}
+
+// Track adds rec.
+func (s *MemoryRetentionStore) Track(ctx context.Context, rec RetentionRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, rec)
+	return nil
+}
+
+// Expired returns the records whose ExpiresAt is not after now.
+func (s *MemoryRetentionStore) Expired(ctx context.Context, now time.Time) ([]RetentionRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []RetentionRecord
+	for _, rec := range s.records {
+		if !rec.ExpiresAt.After(now) {
+			out = append(out, rec)
+		}
+	}
+	return out, nil
+}
+
+// Forget removes rec.
+func (s *MemoryRetentionStore) Forget(ctx context.Context, rec RetentionRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, r := range s.records {
+		if r == rec {
+			s.records = append(s.records[:i], s.records[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// Records returns a copy of every tracked record.
+func (s *MemoryRetentionStore) Records() []RetentionRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]RetentionRecord{}, s.records...)
+}
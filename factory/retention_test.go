@@ -0,0 +1,94 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type retainedUser struct {
+	ID        string
+	SeededBy  string
+	ExpiresAt time.Time
+}
+
+func TestRetention_TrackAndPurge(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryRetentionStore()
+	ret := NewRetention(store, "ci-42", time.Hour).WithClock(func() time.Time { return now })
+
+	db := map[string]bool{}
+	f := New(func(seq int64) User { return User{Name: "seeded"} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) {
+			u.ID = fmt.Sprint(len(db) + 1)
+			db[u.ID] = true
+			return u, nil
+		})
+	users := TrackRetention(f, ret, func(u *User) string { return u.ID }, func(ctx context.Context, id string) error {
+		delete(db, id)
+		return nil
+	})
+
+	ctx := context.Background()
+	users.Count(3).MustCreate(ctx)
+	f.MustCreate(ctx) // untracked
+	recs := store.Records()
+	if len(recs) != 3 || recs[0].Type != "factory.User" || recs[0].SeededBy != "ci-42" || !recs[0].ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("expected 3 tracked records, got %+v", recs)
+	}
+
+	if n, err := ret.PurgeExpired(ctx); err != nil || n != 0 {
+		t.Fatalf("expected nothing to purge before expiry, got %d, %v", n, err)
+	}
+
+	now = now.Add(2 * time.Hour)
+	n, err := ret.PurgeExpired(ctx)
+	if err != nil || n != 3 {
+		t.Fatalf("expected 3 purged, got %d, %v", n, err)
+	}
+	if len(db) != 1 || !db["4"] || len(store.Records()) != 0 {
+		t.Fatalf("expected only the untracked row to remain, got %v and %d records", db, len(store.Records()))
+	}
+}
+
+func TestRetention_StampAndSweep(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ret := NewRetention(nil, "alice", 24*time.Hour).WithClock(func() time.Time { return now })
+
+	f := New(func(seq int64) retainedUser { return retainedUser{ID: fmt.Sprint(seq)} }).
+		WithTraits(RetentionStamp(ret, func(u *retainedUser, by string, at time.Time) {
+			u.SeededBy, u.ExpiresAt = by, at
+		}))
+	rows := f.MakeMany(2)
+	if rows[0].SeededBy != "alice" || !rows[0].ExpiresAt.Equal(now.Add(24*time.Hour)) {
+		t.Fatalf("expected stamped row, got %+v", rows[0])
+	}
+
+	ret.Sweep(func(ctx context.Context, at time.Time) (int, error) {
+		n := 0
+		for _, r := range rows {
+			if r.ExpiresAt.Before(at) {
+				n++
+			}
+		}
+		return n, errors.New("partial")
+	})
+	now = now.Add(48 * time.Hour)
+	n, err := ret.PurgeExpired(context.Background())
+	if n != 2 || err == nil {
+		t.Fatalf("expected 2 swept with error, got %d, %v", n, err)
+	}
+}
+
+func TestRetention_MissingDeleter(t *testing.T) {
+	store := NewMemoryRetentionStore()
+	if err := store.Track(context.Background(), RetentionRecord{Type: "other.Row", ID: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := NewRetention(store, "ci", 0).PurgeExpired(context.Background())
+	if err == nil {
+		t.Fatal("expected error for a type without a delete function")
+	}
+}
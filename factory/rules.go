@@ -0,0 +1,127 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Rules records the items created by every factory that Creates with a context from
+// WithRules, including nested creates, and verifies cross-factory invariants against
+// them at the end of a seeding run (e.g., every Comment.AuthorID is a created User).
+// It catches wiring bugs in relationship link functions. Safe for concurrent use.
+type Rules struct {
+	mu      sync.Mutex
+	created map[string][]any // Persisted *T by type name
+	rules   []rule
+}
+
+type rule struct {
+	name  string
+	check func(r *Rules) error
+}
+
+type rulesKey struct{}
+
+// NewRules creates an empty rule set.
+func NewRules() *Rules {
+	return &Rules{created: make(map[string][]any)}
+}
+
+// WithRules returns a context under which created items are recorded in r.
+func WithRules(ctx context.Context, r *Rules) context.Context {
+	return context.WithValue(ctx, rulesKey{}, r)
+}
+
+// RulesFrom returns the rule set attached to ctx, or nil.
+func RulesFrom(ctx context.Context) *Rules {
+	r, _ := ctx.Value(rulesKey{}).(*Rules)
+	return r
+}
+
+// Rule declares a named invariant. check reads the recorded items with Created.
+// Example: rules.Rule("one admin", func(r *Rules) error { ... Created[User](r) ... })
+func (r *Rules) Rule(name string, check func(r *Rules) error) *Rules {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules = append(r.rules, rule{name: name, check: check})
+	return r
+}
+
+// References declares that every created C's foreign key (from fk) matches the key
+// (from pk) of some created P. Empty foreign keys are treated as optional and skipped.
+// Example: References(rules, "comment authors exist", func(c *Comment) string { return c.AuthorID }, func(u *User) string { return u.ID })
+func References[C, P any](r *Rules, name string, fk func(*C) string, pk func(*P) string) *Rules {
+	return r.Rule(name, func(r *Rules) error {
+		keys := make(map[string]bool)
+		for _, p := range Created[P](r) {
+			keys[pk(p)] = true
+		}
+		var missing []string
+		children := Created[C](r)
+		for _, c := range children {
+			if key := fk(c); key != "" && !keys[key] {
+				missing = append(missing, fmt.Sprintf("%q", key))
+			}
+		}
+		if len(missing) == 0 {
+			return nil
+		}
+		const shown = 5
+		list := strings.Join(missing[:min(shown, len(missing))], ", ")
+		if len(missing) > shown {
+			list += ", ..."
+		}
+		return fmt.Errorf("factory: %d of %d %s reference a missing %s: %s",
+			len(missing), len(children), typeName[C](), typeName[P](), list)
+	})
+}
+
+// Created returns the recorded *T items in creation order.
+func Created[T any](r *Rules) []*T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	items := r.created[typeName[T]()]
+	out := make([]*T, 0, len(items))
+	for _, item := range items {
+		if t, ok := item.(*T); ok {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Verify runs every rule and returns their failures joined, each naming its rule.
+func (r *Rules) Verify() error {
+	r.mu.Lock()
+	rules := append([]rule{}, r.rules...)
+	r.mu.Unlock()
+
+	var errs []error
+	for _, ru := range rules {
+		if err := ru.check(r); err != nil {
+			errs = append(errs, fmt.Errorf("factory: rule %q: %w", ru.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Assertion adapts Verify for Scenario.Assert. The scenario's context must carry r
+// (see WithRules) so its steps' creates are recorded.
+func (r *Rules) Assertion() Assertion {
+	return func(*Results) error { return r.Verify() }
+}
+
+// recordCreated adds a persisted item to the rule set attached to ctx, if any.
+func recordCreated[T any](ctx context.Context, t *T) {
+	r := RulesFrom(ctx)
+	if r == nil || t == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	name := typeName[T]()
+	r.created[name] = append(r.created[name], t)
+}
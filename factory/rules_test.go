@@ -0,0 +1,71 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func newRulesFactories() (*Factory[User], *Factory[Post]) {
+	ids := 0
+	users := New(func(seq int64) User { return User{} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) {
+			ids++
+			u.ID = fmt.Sprintf("u%d", ids)
+			return u, nil
+		})
+	posts := New(func(seq int64) Post { return Post{} }).
+		WithPersist(func(ctx context.Context, p *Post) (*Post, error) { return p, nil })
+	return users, posts
+}
+
+func TestRules_References(t *testing.T) {
+	users, posts := newRulesFactories()
+	rules := References(NewRules(), "post authors exist",
+		func(p *Post) string { return p.AuthorID },
+		func(u *User) string { return u.ID })
+	ctx := WithRules(context.Background(), rules)
+
+	Has(users, posts, 2, func(u *User, p *Post) { p.AuthorID = u.ID }).MustCreate(ctx)
+	posts.MustCreate(ctx) // no author: optional
+	if err := rules.Verify(); err != nil {
+		t.Fatalf("expected consistent data, got %v", err)
+	}
+	if len(Created[User](rules)) != 1 || len(Created[Post](rules)) != 3 {
+		t.Fatalf("expected recorded items, got %d users and %d posts", len(Created[User](rules)), len(Created[Post](rules)))
+	}
+
+	// A buggy link function points at a user that was never created
+	posts.MustCreate(ctx, func(p *Post) { p.AuthorID = "u9" })
+	err := rules.Verify()
+	if err == nil || !strings.Contains(err.Error(), `rule "post authors exist": factory: 1 of 4 factory.Post reference a missing factory.User: "u9"`) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestRules_CustomRuleInScenario(t *testing.T) {
+	users, _ := newRulesFactories()
+	tooMany := errors.New("too many users")
+	rules := NewRules().Rule("at most one user", func(r *Rules) error {
+		if len(Created[User](r)) > 1 {
+			return tooMany
+		}
+		return nil
+	})
+
+	_, err := NewScenario("seed").
+		Step("users", CreateStep("users", users, 2)).
+		Assert(rules.Assertion()).
+		Create(WithRules(context.Background(), rules))
+	if !errors.Is(err, tooMany) {
+		t.Fatalf("expected the rule to fail the scenario, got %v", err)
+	}
+
+	// Items created without WithRules are not recorded
+	users.MustCreate(context.Background())
+	if len(Created[User](rules)) != 2 {
+		t.Fatalf("expected 2 recorded users, got %d", len(Created[User](rules)))
+	}
+}
@@ -0,0 +1,64 @@
+package factory
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"strings"
+)
+
+// RunPrefixEnv is read by NewRunPrefix so CI can pin the prefix (e.g., to the job ID).
+const RunPrefixEnv = "FACTORY_RUN_PREFIX"
+
+// WithRunPrefix prepends prefix to the given string fields of every built item
+// (emails, usernames, slugs), so concurrent CI jobs seeding a shared database
+// don't collide, and cleanup can delete every row matching the prefix.
+// The prefix is applied after all traits; empty and already-prefixed values are left alone.
+// Example: userFactory.WithRunPrefix(prefix, func(u *User) *string { return &u.Email })
+func (f *Factory[T]) WithRunPrefix(prefix string, fields ...func(*T) *string) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.runPrefix = prefix
+	f.prefixed = grow(f.prefixed, fields...)
+	return f
+}
+
+// RunPrefix returns the prefix set by WithRunPrefix ("" when unset).
+func (f *Factory[T]) RunPrefix() string {
+	v := f.view()
+	f = &v
+	return f.runPrefix
+}
+
+func (f *Factory[T]) applyRunPrefix(t *T) {
+	if f.runPrefix == "" {
+		return
+	}
+	for _, field := range f.prefixed {
+		p := field(t)
+		if *p != "" && !strings.HasPrefix(*p, f.runPrefix) {
+			*p = f.runPrefix + *p
+		}
+	}
+}
+
+// NewRunPrefix returns the FACTORY_RUN_PREFIX environment variable if set,
+// otherwise a random prefix like "run-1a2b3c4d-".
+func NewRunPrefix() string {
+	if p := os.Getenv(RunPrefixEnv); p != "" {
+		return p
+	}
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		panic("factory: NewRunPrefix: " + err.Error())
+	}
+	return "run-" + hex.EncodeToString(b) + "-"
+}
+
+// RunPrefixPattern returns a SQL LIKE pattern matching values that start with
+// prefix, with LIKE wildcards in the prefix escaped (use ESCAPE '\').
+// Example: db.Exec(`DELETE FROM users WHERE email LIKE ? ESCAPE '\'`, RunPrefixPattern(prefix))
+func RunPrefixPattern(prefix string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return r.Replace(prefix) + "%"
+}
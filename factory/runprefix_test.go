@@ -0,0 +1,56 @@
+package factory
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFactory_WithRunPrefix(t *testing.T) {
+	f := New(func(seq int64) User {
+		return User{ID: "1", Name: "alice", Email: "alice@example.com"}
+	}).WithRunPrefix("ci42-",
+		func(u *User) *string { return &u.Name },
+		func(u *User) *string { return &u.Email },
+	)
+
+	u := f.Make(func(u *User) { u.Name = "bob" })
+	if u.Name != "ci42-bob" || u.Email != "ci42-alice@example.com" || u.ID != "1" {
+		t.Fatalf("expected prefixed name and email, got %+v", u)
+	}
+	if f.RunPrefix() != "ci42-" {
+		t.Fatalf("expected RunPrefix ci42-, got %q", f.RunPrefix())
+	}
+
+	raw := f.Raw(func(u *User) { u.Email = "ci42-kept@example.com" })
+	if raw.Email != "ci42-kept@example.com" || raw.Name != "ci42-alice" {
+		t.Fatalf("expected Raw to prefix once, got %+v", raw)
+	}
+
+	empty := f.Make(func(u *User) { u.Name = "" })
+	if empty.Name != "" {
+		t.Fatalf("expected empty field to stay empty, got %q", empty.Name)
+	}
+
+	if c := f.Clone().Make(); c.Email != "ci42-alice@example.com" {
+		t.Fatalf("expected clone to keep the prefix, got %q", c.Email)
+	}
+}
+
+func TestNewRunPrefix(t *testing.T) {
+	t.Setenv(RunPrefixEnv, "")
+	a, b := NewRunPrefix(), NewRunPrefix()
+	if !strings.HasPrefix(a, "run-") || !strings.HasSuffix(a, "-") || a == b {
+		t.Fatalf("expected distinct random prefixes, got %q and %q", a, b)
+	}
+
+	t.Setenv(RunPrefixEnv, "job-7-")
+	if p := NewRunPrefix(); p != "job-7-" {
+		t.Fatalf("expected prefix from environment, got %q", p)
+	}
+}
+
+func TestRunPrefixPattern(t *testing.T) {
+	if got := RunPrefixPattern(`run_1%\`); got != `run\_1\%\\%` {
+		t.Fatalf("expected escaped LIKE pattern, got %q", got)
+	}
+}
@@ -0,0 +1,183 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// Step is one unit of work in a Scenario. It can read earlier results and add its own.
+type Step func(ctx context.Context, r *Results) error
+
+// Scenario composes factories, relationships, and fixture values into a named,
+// reusable setup (e.g., "blog with 3 authors and 20 posts").
+// Steps run in the order they were added.
+type Scenario struct {
+	name      string
+	steps     []scenarioStep
+	beforeAll []scenarioStep
+	afterAll  []scenarioStep
+	backfills []scenarioStep // Finishing passes (see Backfill)
+	asserts   []Assertion
+	uses      []usedFactory // Validated before the run (see Uses)
+
+	checkpoint string // Progress file (see WithCheckpoint)
+	sequences  []trackedSequence
+	rand       *Group
+	seed       int64
+}
+
+type scenarioStep struct {
+	name string
+	fn   Step
+}
+
+// NewScenario creates an empty scenario.
+func NewScenario(name string) *Scenario {
+	return &Scenario{name: name}
+}
+
+// Name returns the scenario name.
+func (s *Scenario) Name() string {
+	return s.name
+}
+
+// Step appends a named step.
+// Example: s.Step("posts", func(ctx context.Context, r *Results) error { ... })
+func (s *Scenario) Step(name string, fn Step) *Scenario {
+	s.steps = append(s.steps, scenarioStep{name: name, fn: fn})
+	return s
+}
+
+// BeforeAll appends a run-level hook that runs before the first step
+// (e.g., disable foreign key checks). Hooks run in the order they were added.
+func (s *Scenario) BeforeAll(name string, fn Step) *Scenario {
+	s.beforeAll = append(s.beforeAll, scenarioStep{name: name, fn: fn})
+	return s
+}
+
+// AfterAll appends a run-level hook that runs after the last step (e.g., refresh
+// materialized views or rebuild a search index). Once every BeforeAll hook has
+// succeeded, AfterAll hooks always run, even if a step failed, so they can undo
+// BeforeAll work. Hooks run in the order they were added.
+func (s *Scenario) AfterAll(name string, fn Step) *Scenario {
+	s.afterAll = append(s.afterAll, scenarioStep{name: name, fn: fn})
+	return s
+}
+
+// Create validates the factories registered with Uses, then runs BeforeAll hooks, every
+// step, Backfill passes, assertions, then AfterAll hooks, and returns the collected results.
+// The run is one identity scope (see WithIdentity).
+// On error, returns the results collected so far and an error naming the failed step or hook.
+func (s *Scenario) Create(ctx context.Context) (*Results, error) {
+	ctx = WithIdentityMap(ctx)
+	r := NewResults()
+	if err := s.Validate(); err != nil {
+		return r, err
+	}
+	var completed []string
+	done := make(map[string]bool)
+	if s.checkpoint != "" {
+		cp, err := s.loadCheckpoint(r)
+		if err != nil {
+			return r, fmt.Errorf("factory: scenario %q: %w", s.name, err)
+		}
+		if cp != nil {
+			completed = cp.Completed
+			for _, name := range completed {
+				done[name] = true
+			}
+		}
+		ctx = context.WithValue(ctx, checkpointKey{}, func(r *Results) error {
+			return s.saveCheckpoint(completed, r)
+		})
+	}
+
+	for _, st := range s.beforeAll {
+		if err := st.fn(ctx, r); err != nil {
+			return r, fmt.Errorf("factory: scenario %q before-all %q: %w", s.name, st.name, err)
+		}
+	}
+
+	var errs []error
+	for i, st := range s.steps {
+		if done[st.name] {
+			continue
+		}
+		if s.rand != nil {
+			s.rand.WithSeed(s.seed + int64(i))
+		}
+		if err := st.fn(ctx, r); err != nil {
+			errs = append(errs, fmt.Errorf("factory: scenario %q step %q: %w", s.name, st.name, err))
+			break
+		}
+		if s.checkpoint != "" {
+			completed = append(completed, st.name)
+			if err := s.saveCheckpoint(completed, r); err != nil {
+				errs = append(errs, fmt.Errorf("factory: scenario %q checkpoint: %w", s.name, err))
+				break
+			}
+		}
+	}
+	if len(errs) == 0 {
+		for _, st := range s.backfills {
+			if err := st.fn(ctx, r); err != nil {
+				errs = append(errs, fmt.Errorf("factory: scenario %q backfill %q: %w", s.name, st.name, err))
+				break
+			}
+		}
+	}
+	if len(errs) == 0 {
+		for _, a := range s.asserts {
+			if err := a(r); err != nil {
+				errs = append(errs, fmt.Errorf("factory: scenario %q assertion failed: %w", s.name, err))
+			}
+		}
+	}
+	for _, st := range s.afterAll {
+		if err := st.fn(ctx, r); err != nil {
+			errs = append(errs, fmt.Errorf("factory: scenario %q after-all %q: %w", s.name, st.name, err))
+		}
+	}
+	if len(errs) == 0 && s.checkpoint != "" {
+		if err := os.Remove(s.checkpoint); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("factory: scenario %q checkpoint: %w", s.name, err))
+		}
+	}
+	return r, errors.Join(errs...)
+}
+
+// MustCreate runs every step and returns the results. Panics on error.
+func (s *Scenario) MustCreate(ctx context.Context) *Results {
+	r, err := s.Create(ctx)
+	if err != nil {
+		panic("factory: Scenario.MustCreate failed: " + err.Error())
+	}
+	return r
+}
+
+// CreateStep returns a step that creates count items and stores them as []*T under key.
+// Example: s.Step("authors", CreateStep("authors", userFactory.State("author"), 3))
+func CreateStep[T any](key string, f *Factory[T], count int, ts ...Trait[T]) Step {
+	return func(ctx context.Context, r *Results) error {
+		items, err := f.CreateMany(ctx, count, ts...)
+		r.Set(key, items)
+		return err
+	}
+}
+
+// FixtureStep returns a step that stores explicit values as []*T under key,
+// for records that must have exact, known contents.
+func FixtureStep[T any](key string, values ...T) Step {
+	return func(ctx context.Context, r *Results) error {
+		items := make([]*T, len(values))
+		for i := range values {
+			v := values[i]
+			items[i] = &v
+		}
+		r.Set(key, items)
+		return nil
+	}
+}
@@ -0,0 +1,172 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func newBlogScenario() *Scenario {
+	userFactory := New(func(seq int64) User {
+		return User{Name: fmt.Sprintf("User %d", seq)}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		u.ID = fmt.Sprintf("user-%s", u.Name)
+		return u, nil
+	})
+
+	postFactory := New(func(seq int64) Post {
+		return Post{Title: fmt.Sprintf("Post %d", seq)}
+	}).WithPersist(func(ctx context.Context, p *Post) (*Post, error) {
+		p.ID = fmt.Sprintf("post-%s", p.Title)
+		return p, nil
+	})
+
+	return NewScenario("blog with 3 authors and 6 posts").
+		Step("admin", FixtureStep("admin", User{ID: "admin", Name: "Admin"})).
+		Step("authors", CreateStep("authors", userFactory, 3)).
+		Step("posts", func(ctx context.Context, r *Results) error {
+			authors := Get[[]*User](r, "authors")
+			var posts []*Post
+			for _, a := range authors {
+				items, err := Recycle(postFactory, a, func(p *Post, u *User) {
+					p.AuthorID = u.ID
+				}).CreateMany(ctx, 2)
+				if err != nil {
+					return err
+				}
+				posts = append(posts, items...)
+			}
+			r.Set("posts", posts)
+			return nil
+		})
+}
+
+func TestScenario_Create(t *testing.T) {
+	s := newBlogScenario()
+	if s.Name() != "blog with 3 authors and 6 posts" {
+		t.Fatalf("unexpected scenario name %q", s.Name())
+	}
+
+	r, err := s.Create(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if keys := strings.Join(r.Keys(), ","); keys != "admin,authors,posts" {
+		t.Fatalf("unexpected result keys %q", keys)
+	}
+
+	admin := Get[[]*User](r, "admin")
+	if len(admin) != 1 || admin[0].ID != "admin" {
+		t.Fatalf("expected admin fixture, got %+v", admin)
+	}
+
+	authors := Get[[]*User](r, "authors")
+	if len(authors) != 3 {
+		t.Fatalf("expected 3 authors, got %d", len(authors))
+	}
+
+	posts := Get[[]*Post](r, "posts")
+	if len(posts) != 6 {
+		t.Fatalf("expected 6 posts, got %d", len(posts))
+	}
+	if posts[0].AuthorID != authors[0].ID || posts[5].AuthorID != authors[2].ID {
+		t.Fatal("expected posts linked to authors")
+	}
+
+	// Scenarios are reusable
+	again := s.MustCreate(context.Background())
+	if len(Get[[]*Post](again, "posts")) != 6 {
+		t.Fatal("expected scenario to be rerunnable")
+	}
+}
+
+func TestScenario_StepError(t *testing.T) {
+	boom := errors.New("boom")
+	r, err := NewScenario("failing").
+		Step("first", FixtureStep("first", User{ID: "1"})).
+		Step("second", func(ctx context.Context, r *Results) error { return boom }).
+		Create(context.Background())
+
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected wrapped step error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `step "second"`) {
+		t.Fatalf("expected error to name the step, got %v", err)
+	}
+	if _, ok := Lookup[[]*User](r, "first"); !ok {
+		t.Fatal("expected partial results to be returned")
+	}
+}
+
+func TestResults_GetPanics(t *testing.T) {
+	r := NewResults()
+	r.Set("users", []*User{})
+
+	if _, ok := Lookup[[]*Post](r, "users"); ok {
+		t.Fatal("expected Lookup to fail for mismatched type")
+	}
+
+	defer func() {
+		if rec := recover(); rec == nil {
+			t.Fatal("expected panic for missing key")
+		}
+	}()
+	Get[[]*User](r, "missing")
+}
+
+func TestScenario_BeforeAndAfterAll(t *testing.T) {
+	var order []string
+	record := func(name string) Step {
+		return func(ctx context.Context, r *Results) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	s := NewScenario("seed").
+		AfterAll("refresh views", record("after:views")).
+		Step("users", record("step:users")).
+		BeforeAll("disable fk", record("before:fk")).
+		Step("posts", record("step:posts")).
+		AfterAll("enable fk", record("after:fk"))
+
+	s.MustCreate(context.Background())
+	if got := strings.Join(order, ","); got != "before:fk,step:users,step:posts,after:views,after:fk" {
+		t.Fatalf("unexpected order %s", got)
+	}
+}
+
+func TestScenario_AfterAllRunsOnStepFailure(t *testing.T) {
+	boom := errors.New("insert failed")
+	cleaned := false
+
+	_, err := NewScenario("seed").
+		Step("users", func(ctx context.Context, r *Results) error { return boom }).
+		Step("posts", func(ctx context.Context, r *Results) error {
+			t.Fatal("expected later steps to be skipped")
+			return nil
+		}).
+		AfterAll("enable fk", func(ctx context.Context, r *Results) error {
+			cleaned = true
+			return nil
+		}).
+		Create(context.Background())
+
+	if !errors.Is(err, boom) || !cleaned {
+		t.Fatalf("expected step error and cleanup, got %v, cleaned=%v", err, cleaned)
+	}
+
+	// A failing BeforeAll skips everything else
+	ran := false
+	_, err = NewScenario("seed").
+		BeforeAll("disable fk", func(ctx context.Context, r *Results) error { return boom }).
+		Step("users", func(ctx context.Context, r *Results) error { ran = true; return nil }).
+		AfterAll("enable fk", func(ctx context.Context, r *Results) error { ran = true; return nil }).
+		Create(context.Background())
+	if !errors.Is(err, boom) || ran || !strings.Contains(err.Error(), `before-all "disable fk"`) {
+		t.Fatalf("expected before-all error to stop the run, got %v, ran=%v", err, ran)
+	}
+}
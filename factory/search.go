@@ -0,0 +1,155 @@
+package factory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// SearchIndexer sends one document to a search index. Adapt any client
+// (Elasticsearch, OpenSearch, Meilisearch, ...) or use the HTTP indexers below.
+type SearchIndexer interface {
+	Index(ctx context.Context, index, id string, doc map[string]any) error
+}
+
+// SearchIndexerFunc adapts a function to SearchIndexer.
+type SearchIndexerFunc func(ctx context.Context, index, id string, doc map[string]any) error
+
+// Index calls fn.
+func (fn SearchIndexerFunc) Index(ctx context.Context, index, id string, doc map[string]any) error {
+	return fn(ctx, index, id, doc)
+}
+
+// IndexPersist wraps p so every saved record is also pushed to a search index, letting
+// search features be tested against seeded data without separate indexing scripts.
+//
+// Fields are mapped with `search` struct tags: `search:"title"` indexes the field as
+// "title", `search:"-"` skips it, and the ",id" option marks the document ID
+// (`search:"id,id"`). Without any search tags, every exported field is indexed under
+// its Go name. Without an ",id" field, a field named ID is used.
+// Example: postFactory.WithPersist(IndexPersist(repo.Save, NewElasticsearchIndexer(esURL, nil), "posts"))
+func IndexPersist[T any](p PersistFn[T], idx SearchIndexer, index string) PersistFn[T] {
+	return func(ctx context.Context, t *T) (*T, error) {
+		out, err := p(ctx, t)
+		if err != nil {
+			return out, err
+		}
+		id, doc, err := searchDocument(out)
+		if err != nil {
+			return out, fmt.Errorf("factory: indexing %s: %w", index, err)
+		}
+		if err := idx.Index(ctx, index, id, doc); err != nil {
+			return out, fmt.Errorf("factory: indexing %s/%s: %w", index, id, err)
+		}
+		return out, nil
+	}
+}
+
+// searchDocument maps a struct to its search document using `search` tags.
+func searchDocument(v any) (string, map[string]any, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return "", nil, fmt.Errorf("%T is not a struct", v)
+	}
+	rt := rv.Type()
+
+	tagged := false
+	for i := 0; i < rt.NumField(); i++ {
+		if _, ok := rt.Field(i).Tag.Lookup("search"); ok {
+			tagged = true
+			break
+		}
+	}
+
+	id := ""
+	doc := make(map[string]any)
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag, ok := sf.Tag.Lookup("search")
+		if tagged && !ok || tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = sf.Name
+		}
+		value := rv.Field(i).Interface()
+		doc[name] = value
+		if opts == "id" || (id == "" && sf.Name == "ID") {
+			id = fmt.Sprint(value)
+		}
+	}
+	return id, doc, nil
+}
+
+// NewElasticsearchIndexer returns an indexer that PUTs documents to
+// {baseURL}/{index}/_doc/{id}?refresh=true, which works for Elasticsearch and
+// OpenSearch. Documents without an ID are POSTed to {baseURL}/{index}/_doc instead,
+// so the engine assigns one. refresh=true makes documents searchable as soon as
+// Create returns. A nil client uses http.DefaultClient.
+func NewElasticsearchIndexer(baseURL string, client *http.Client) SearchIndexer {
+	return SearchIndexerFunc(func(ctx context.Context, index, id string, doc map[string]any) error {
+		base := fmt.Sprintf("%s/%s/_doc", strings.TrimRight(baseURL, "/"), url.PathEscape(index))
+		if id == "" {
+			return sendJSON(ctx, client, http.MethodPost, base+"?refresh=true", nil, doc)
+		}
+		return sendJSON(ctx, client, http.MethodPut, base+"/"+url.PathEscape(id)+"?refresh=true", nil, doc)
+	})
+}
+
+// NewMeilisearchIndexer returns an indexer that POSTs documents to
+// {baseURL}/indexes/{index}/documents. apiKey may be empty for unsecured instances.
+// Meilisearch indexes asynchronously; wait for its task queue before searching.
+// A nil client uses http.DefaultClient.
+func NewMeilisearchIndexer(baseURL, apiKey string, client *http.Client) SearchIndexer {
+	var header http.Header
+	if apiKey != "" {
+		header = http.Header{"Authorization": {"Bearer " + apiKey}}
+	}
+	return SearchIndexerFunc(func(ctx context.Context, index, id string, doc map[string]any) error {
+		target := fmt.Sprintf("%s/indexes/%s/documents", strings.TrimRight(baseURL, "/"), url.PathEscape(index))
+		return sendJSON(ctx, client, http.MethodPost, target, header, []map[string]any{doc})
+	})
+}
+
+// sendJSON sends body as JSON and returns an error for non-2xx responses.
+func sendJSON(ctx context.Context, client *http.Client, method, target string, header http.Header, body any) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, readErr := io.ReadAll(io.LimitReader(resp.Body, 512))
+		if readErr != nil {
+			msg = nil
+		}
+		return fmt.Errorf("%s %s: %s: %s", method, target, resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package factory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type Article struct {
+	Slug   string `search:"slug,id"`
+	Title  string `search:"title"`
+	Body   string `search:"-"`
+	Author string
+}
+
+func TestIndexPersist_TagMapping(t *testing.T) {
+	var got []map[string]any
+	var ids []string
+	idx := SearchIndexerFunc(func(ctx context.Context, index, id string, doc map[string]any) error {
+		if index != "articles" {
+			t.Fatalf("expected articles index, got %s", index)
+		}
+		ids = append(ids, id)
+		got = append(got, doc)
+		return nil
+	})
+
+	f := New(func(seq int64) Article {
+		return Article{Slug: "hello", Title: "Hello", Body: "long text", Author: "ada"}
+	}).WithPersist(IndexPersist(func(ctx context.Context, a *Article) (*Article, error) {
+		return a, nil
+	}, idx, "articles"))
+
+	f.MustCreate(context.Background())
+	if len(got) != 1 || ids[0] != "hello" {
+		t.Fatalf("expected one document with id hello, got %v %v", ids, got)
+	}
+	if len(got[0]) != 2 || got[0]["title"] != "Hello" || got[0]["slug"] != "hello" {
+		t.Fatalf("expected only tagged fields, got %v", got[0])
+	}
+
+	// Untagged structs index every exported field, with ID as the document ID
+	userIdx := SearchIndexerFunc(func(ctx context.Context, index, id string, doc map[string]any) error {
+		if id != "user-1" || doc["Email"] != "a@example.com" {
+			t.Fatalf("expected untagged mapping, got %s %v", id, doc)
+		}
+		return nil
+	})
+	users := New(func(seq int64) User {
+		return User{ID: "user-1", Email: "a@example.com"}
+	}).WithPersist(IndexPersist(func(ctx context.Context, u *User) (*User, error) {
+		return u, nil
+	}, userIdx, "users"))
+	users.MustCreate(context.Background())
+}
+
+func TestIndexPersist_Errors(t *testing.T) {
+	boom := errors.New("index down")
+	indexed := false
+	idx := SearchIndexerFunc(func(ctx context.Context, index, id string, doc map[string]any) error {
+		indexed = true
+		return boom
+	})
+	save := func(ctx context.Context, u *User) (*User, error) { return u, nil }
+
+	if _, err := IndexPersist(save, idx, "users")(context.Background(), &User{}); !errors.Is(err, boom) {
+		t.Fatalf("expected index error, got %v", err)
+	}
+
+	indexed = false
+	failing := func(ctx context.Context, u *User) (*User, error) { return nil, errors.New("insert failed") }
+	if _, err := IndexPersist(failing, idx, "users")(context.Background(), &User{}); err == nil || indexed {
+		t.Fatalf("expected persist error without indexing, got %v, indexed=%v", err, indexed)
+	}
+}
+
+func TestSearchIndexers_HTTP(t *testing.T) {
+	var method, path, auth string
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, auth = r.Method, r.URL.RequestURI(), r.Header.Get("Authorization")
+		body, _ = io.ReadAll(r.Body)
+		if strings.Contains(r.URL.Path, "fail") {
+			http.Error(w, "bad", http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	doc := map[string]any{"title": "Hello"}
+
+	if err := NewElasticsearchIndexer(srv.URL+"/", nil).Index(ctx, "posts", "a b", doc); err != nil {
+		t.Fatal(err)
+	}
+	if method != http.MethodPut || path != "/posts/_doc/a%20b?refresh=true" || string(body) != `{"title":"Hello"}` {
+		t.Fatalf("unexpected Elasticsearch request %s %s %s", method, path, body)
+	}
+
+	if err := NewElasticsearchIndexer(srv.URL, nil).Index(ctx, "posts", "", doc); err != nil {
+		t.Fatal(err)
+	}
+	if method != http.MethodPost || path != "/posts/_doc?refresh=true" {
+		t.Fatalf("expected an auto-ID POST without an ID, got %s %s", method, path)
+	}
+
+	if err := NewMeilisearchIndexer(srv.URL, "secret", srv.Client()).Index(ctx, "posts", "1", doc); err != nil {
+		t.Fatal(err)
+	}
+	var docs []map[string]any
+	if err := json.Unmarshal(body, &docs); err != nil || len(docs) != 1 {
+		t.Fatalf("expected array body, got %s", body)
+	}
+	if method != http.MethodPost || path != "/indexes/posts/documents" || auth != "Bearer secret" {
+		t.Fatalf("unexpected Meilisearch request %s %s %s", method, path, auth)
+	}
+
+	if err := NewElasticsearchIndexer(srv.URL, nil).Index(ctx, "posts", "fail", doc); err == nil {
+		t.Fatal("expected error status to be reported")
+	}
+}
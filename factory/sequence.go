@@ -0,0 +1,98 @@
+package factory
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// SequenceMode controls how a cloned factory numbers its items.
+type SequenceMode int
+
+const (
+	// SequenceReset starts the clone at 0 with its own counter (the Clone() default).
+	SequenceReset SequenceMode = iota
+	// SequenceContinue starts the clone at the original's current value with its own counter.
+	SequenceContinue
+	// SequenceShared makes the clone and the original draw from the same counter,
+	// so items from either factory never reuse a sequence number.
+	SequenceShared
+)
+
+// CloneWithSequence is like Clone but lets the caller choose how the clone's
+// sequence relates to the original's. Panics on an unknown mode.
+// Example: adminFactory := userFactory.CloneWithSequence(SequenceShared).State("admin")
+func (f *Factory[T]) CloneWithSequence(mode SequenceMode) *Factory[T] {
+	clone := f.Clone()
+	switch mode {
+	case SequenceContinue:
+		*clone.seq = f.CurrentSequence()
+	case SequenceShared:
+		clone.sharedSeq = f.counter()
+	case SequenceReset: // Clone already starts at 0
+	default:
+		panic(fmt.Sprintf("factory: unknown SequenceMode %d", mode))
+	}
+	return clone
+}
+
+// CurrentSequence returns the last sequence number handed out (0 if none yet).
+func (f *Factory[T]) CurrentSequence() int64 {
+	return atomic.LoadInt64(f.counter())
+}
+
+// SwapSequence atomically sets the sequence counter to n and returns the previous value.
+// The next item built receives n+1. SwapSequence(0) is a ResetSequence that reports
+// how many numbers were handed out. Panics if n is negative.
+func (f *Factory[T]) SwapSequence(n int64) int64 {
+	if n < 0 {
+		panic("factory: SwapSequence called with negative value")
+	}
+	return atomic.SwapInt64(f.counter(), n)
+}
+
+// StartingSeqAt makes the batch occupy the sequence range n..n+count-1, so tests
+// can reference "the 150th user" predictably even when other tests also use the
+// factory. The window has its own counter: every call on the returned batch
+// starts again at n, and the factory's own sequence is not advanced.
+// Panics if n < 1.
+// Example: users := userFactory.Count(100).StartingSeqAt(100).Make() // seq 100-199
+func (cf *CountedFactory[T]) StartingSeqAt(n int64) *CountedFactory[T] {
+	if n < 1 {
+		panic("factory: StartingSeqAt requires n >= 1")
+	}
+	out := cf.with(cf.factory)
+	out.start = n
+	return out
+}
+
+// Offset skips the first n sequence numbers: the batch occupies n+1..n+count,
+// like a SQL OFFSET. Offset(0) starts at 1 regardless of the factory's counter.
+// Example: userFactory.Count(100).Offset(99).Make() // seq 100-199
+func (cf *CountedFactory[T]) Offset(n int64) *CountedFactory[T] {
+	if n < 0 {
+		panic("factory: Offset requires n >= 0")
+	}
+	return cf.StartingSeqAt(n + 1)
+}
+
+// WithBatchSequences makes every Count(n) batch number its items 1..n on a private
+// counter (as if StartingSeqAt(1) were called), without advancing the factory's own
+// sequence. Concurrent batches therefore get the same predictable names ("User 1..10").
+// Make, MakeMany, Create, and CreateMany keep using the factory's sequence.
+// Example: userFactory.WithBatchSequences().Count(10).Make() // seq 1-10 on every call
+func (f *Factory[T]) WithBatchSequences() *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.batchSeq = true
+	return f
+}
+
+// window returns a copy of the batch whose factory counts from start on a private counter.
+func (cf *CountedFactory[T]) window() *CountedFactory[T] {
+	f := cf.factory.shallow()
+	counter := cf.start - 1
+	f.sharedSeq = &counter
+	out := cf.with(f)
+	out.start = 0
+	return out
+}
@@ -0,0 +1,186 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestFactory_CloneWithSequence(t *testing.T) {
+	newFactory := func() *Factory[User] {
+		f := New(func(seq int64) User {
+			return User{Name: fmt.Sprintf("User %d", seq)}
+		})
+		f.MakeMany(3)
+		return f
+	}
+
+	reset := newFactory().CloneWithSequence(SequenceReset)
+	if u := reset.Make(); u.Name != "User 1" {
+		t.Fatalf("SequenceReset: expected 'User 1', got %q", u.Name)
+	}
+
+	original := newFactory()
+	cont := original.CloneWithSequence(SequenceContinue)
+	if u := cont.Make(); u.Name != "User 4" {
+		t.Fatalf("SequenceContinue: expected 'User 4', got %q", u.Name)
+	}
+	if u := original.Make(); u.Name != "User 4" {
+		t.Fatalf("SequenceContinue: expected original to be independent, got %q", u.Name)
+	}
+
+	original = newFactory()
+	shared := original.CloneWithSequence(SequenceShared)
+	if u := shared.Make(); u.Name != "User 4" {
+		t.Fatalf("SequenceShared: expected 'User 4', got %q", u.Name)
+	}
+	if u := original.Make(); u.Name != "User 5" {
+		t.Fatalf("SequenceShared: expected original to continue at 'User 5', got %q", u.Name)
+	}
+}
+
+func TestFactory_SequenceSharedConcurrent(t *testing.T) {
+	base := New(func(seq int64) User {
+		return User{Name: fmt.Sprintf("User %d", seq)}
+	})
+	clone := base.CloneWithSequence(SequenceShared)
+
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	var wg sync.WaitGroup
+	for _, f := range []*Factory[User]{base, clone} {
+		wg.Add(1)
+		go func(f *Factory[User]) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				u := f.Make()
+				mu.Lock()
+				seen[u.Name] = true
+				mu.Unlock()
+			}
+		}(f)
+	}
+	wg.Wait()
+
+	if len(seen) != 200 {
+		t.Fatalf("expected 200 unique sequence numbers, got %d", len(seen))
+	}
+}
+
+func TestFactory_SwapSequence(t *testing.T) {
+	f := New(func(seq int64) User {
+		return User{Name: fmt.Sprintf("User %d", seq)}
+	})
+	f.MakeMany(5)
+
+	if got := f.CurrentSequence(); got != 5 {
+		t.Fatalf("expected current sequence 5, got %d", got)
+	}
+
+	prev := f.SwapSequence(0)
+	if prev != 5 {
+		t.Fatalf("expected previous sequence 5, got %d", prev)
+	}
+	if u := f.Make(); u.Name != "User 1" {
+		t.Fatalf("expected 'User 1' after swap, got %q", u.Name)
+	}
+
+	f.SwapSequence(100)
+	if u := f.Make(); u.Name != "User 101" {
+		t.Fatalf("expected 'User 101', got %q", u.Name)
+	}
+}
+
+func TestFactory_SwapSequenceNegativePanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic for negative sequence")
+		}
+	}()
+
+	New(func(seq int64) User { return User{} }).SwapSequence(-1)
+}
+
+func TestFactory_SequenceOverflowPanics(t *testing.T) {
+	f := New(func(seq int64) User { return User{} })
+	f.SwapSequence(math.MaxInt64 - 1)
+	f.Make() // Hands out MaxInt64
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "overflowed") {
+			t.Fatalf("expected an overflow panic, got %v", r)
+		}
+	}()
+	f.Make()
+}
+
+func TestFactory_CloneWithSequenceUnknownModePanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic for unknown mode")
+		}
+	}()
+	New(func(seq int64) User { return User{} }).CloneWithSequence(SequenceMode(42))
+}
+
+func TestCountedFactory_StartingSeqAt(t *testing.T) {
+	f := New(func(seq int64) User { return User{ID: fmt.Sprint(seq)} }).
+		DefineState("admin", func(u *User) { u.Name = "admin" })
+	f.Make() // other tests used the factory
+
+	users := f.Count(100).StartingSeqAt(100).Make()
+	if users[0].ID != "100" || users[50].ID != "150" || users[99].ID != "199" {
+		t.Fatalf("expected seq 100-199, got %s, %s, %s", users[0].ID, users[50].ID, users[99].ID)
+	}
+	if f.CurrentSequence() != 1 {
+		t.Fatalf("expected the factory sequence to be untouched, got %d", f.CurrentSequence())
+	}
+
+	batch := f.Count(2).Offset(9).State("admin")
+	first, second := batch.Make(), batch.Raw()
+	if first[0].ID != "10" || second[0].ID != "10" || first[1].Name != "admin" {
+		t.Fatalf("expected each call to start at 10, got %+v and %+v", first, second)
+	}
+
+	ctx := context.Background()
+	created := f.WithFallbackToMake().Count(3).
+		Distribute(map[string]int{"admin": 1, "": 2}).
+		StartingSeqAt(5).
+		MustCreate(ctx)
+	if created[0].ID != "5" || created[2].ID != "7" {
+		t.Fatalf("expected distributed window 5-7, got %s..%s", created[0].ID, created[2].ID)
+	}
+}
+
+func TestFactory_WithBatchSequences(t *testing.T) {
+	f := New(func(seq int64) User { return User{Name: fmt.Sprintf("User %d", seq)} }).
+		WithBatchSequences()
+	f.Make()
+
+	var wg sync.WaitGroup
+	batches := make([][]User, 4)
+	for i := range batches {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			batches[i] = f.Count(10).Make()
+		}(i)
+	}
+	wg.Wait()
+	for _, users := range batches {
+		if users[0].Name != "User 1" || users[9].Name != "User 10" {
+			t.Fatalf("expected each batch to be User 1..10, got %s..%s", users[0].Name, users[9].Name)
+		}
+	}
+	if f.CurrentSequence() != 1 {
+		t.Fatalf("expected the factory sequence to be untouched, got %d", f.CurrentSequence())
+	}
+
+	// An explicit window still wins, and clones keep the option
+	if users := f.Clone().Count(2).StartingSeqAt(5).Make(); users[0].Name != "User 5" {
+		t.Fatalf("expected explicit window, got %s", users[0].Name)
+	}
+}
@@ -0,0 +1,39 @@
+package factory
+
+import "errors"
+
+// ErrNoShard is returned by Create when the shard router yields no persist function.
+var ErrNoShard = errors.New("factory: shard router returned no persist function")
+
+// WithShardRouter routes each item to a persist function chosen from its fields
+// (e.g., tenant or region), for seeding horizontally-partitioned systems.
+// The router runs after BeforeCreate hooks and takes precedence over WithPersist.
+// Example: factory.WithShardRouter(func(u *User) PersistFn[User] { return shards[u.Region] })
+func (f *Factory[T]) WithShardRouter(router func(*T) PersistFn[T]) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	f.router = router
+	return f
+}
+
+// persistFor returns the persist function for t, consulting the shard router if set
+// and falling back to the in-memory persist of WithFallbackToMake.
+// WithChaos and group middleware, if any, wrap the result.
+func (f *Factory[T]) persistFor(t *T) (PersistFn[T], error) {
+	p := f.persist
+	if p == nil && f.fallbackIDs != nil {
+		p = f.fallbackPersist
+	}
+	if f.router != nil {
+		if p = f.router(t); p == nil {
+			return nil, ErrNoShard
+		}
+	}
+	if f.chaos != nil {
+		p = f.wrapChaos(p)
+	}
+	if f.group != nil {
+		p = wrapPersist(f.group, p)
+	}
+	return p, nil
+}
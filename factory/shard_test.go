@@ -0,0 +1,60 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFactory_WithShardRouter(t *testing.T) {
+	saved := map[string][]string{}
+	shard := func(name string) PersistFn[User] {
+		return func(ctx context.Context, u *User) (*User, error) {
+			saved[name] = append(saved[name], u.Name)
+			u.ID = name + "-" + u.Name
+			return u, nil
+		}
+	}
+	shards := map[string]PersistFn[User]{
+		"eu": shard("eu"),
+		"us": shard("us"),
+	}
+
+	f := New(func(seq int64) User {
+		return User{Name: fmt.Sprintf("User %d", seq)}
+	}).Sequence(
+		func(u *User) { u.Email = "eu" },
+		func(u *User) { u.Email = "us" },
+	).WithShardRouter(func(u *User) PersistFn[User] {
+		return shards[u.Email]
+	})
+
+	users, err := f.CreateMany(context.Background(), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(saved["eu"]) != 2 || len(saved["us"]) != 2 {
+		t.Fatalf("expected 2 users per shard, got %v", saved)
+	}
+	if users[0].ID != "eu-User 1" {
+		t.Fatalf("expected first user routed to eu, got %q", users[0].ID)
+	}
+	if users[1].ID != "us-User 2" {
+		t.Fatalf("expected second user routed to us, got %q", users[1].ID)
+	}
+}
+
+func TestFactory_WithShardRouterNoShard(t *testing.T) {
+	f := New(func(seq int64) User {
+		return User{}
+	}).WithShardRouter(func(u *User) PersistFn[User] {
+		return nil
+	})
+
+	_, err := f.Create(context.Background())
+	if !errors.Is(err, ErrNoShard) {
+		t.Fatalf("expected ErrNoShard, got %v", err)
+	}
+}
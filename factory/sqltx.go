@@ -0,0 +1,115 @@
+package factory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// DBTX is the part of *sql.DB, *sql.Tx, and *sql.Conn that SQL persist functions use.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// TxLookup finds a transaction (or connection) a test framework stored in ctx under
+// its own key. It reports false when ctx carries none.
+type TxLookup func(ctx context.Context) (DBTX, bool)
+
+type txKey struct{}
+
+// WithTx returns a context that routes SQLPersist through tx, e.g., from a test
+// helper that wraps each test in a rolled-back transaction.
+func WithTx(ctx context.Context, tx *sql.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFrom returns the transaction stored by WithTx.
+func TxFrom(ctx context.Context) (*sql.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	return tx, ok && tx != nil
+}
+
+// SQLPersist returns a persist function that runs insert against the transaction in
+// the Create context (found with lookups, in order, then WithTx), or against db.
+// Example: userFactory.WithPersist(SQLPersist(db, insertUser))
+func SQLPersist[T any](db DBTX, insert func(ctx context.Context, q DBTX, t *T) (*T, error), lookups ...TxLookup) PersistFn[T] {
+	return func(ctx context.Context, t *T) (*T, error) {
+		return insert(ctx, queryerFor(ctx, db, lookups), t)
+	}
+}
+
+// queryerFor picks the transaction in ctx, falling back to db.
+func queryerFor(ctx context.Context, db DBTX, lookups []TxLookup) DBTX {
+	for _, lookup := range lookups {
+		if q, ok := lookup(ctx); ok {
+			return q
+		}
+	}
+	if tx, ok := TxFrom(ctx); ok {
+		return tx
+	}
+	return db
+}
+
+// TxBeginner starts transactions; *sql.DB and *sql.Conn implement it.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// WithinTx runs fn with a transaction begun on db stored in ctx (see WithTx), committing
+// when fn returns nil and rolling back on an error or panic. Nested calls join the outer one.
+// Example: err := WithinTx(ctx, db, func(ctx context.Context) error { _, err := f.Create(ctx); return err })
+func WithinTx(ctx context.Context, db TxBeginner, fn func(ctx context.Context) error) (err error) {
+	if _, ok := TxFrom(ctx); ok {
+		return fn(ctx)
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				err = errors.Join(err, rbErr)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+	return fn(WithTx(ctx, tx))
+}
+
+// CreateInTx is Create run inside WithinTx.
+func (f *Factory[T]) CreateInTx(ctx context.Context, db TxBeginner, ts ...Trait[T]) (*T, error) {
+	var item *T
+	err := WithinTx(ctx, db, func(ctx context.Context) error {
+		var err error
+		item, err = f.Create(ctx, ts...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// CreateManyInTx is CreateMany run inside WithinTx: either all count items are
+// committed or none are.
+func (f *Factory[T]) CreateManyInTx(ctx context.Context, db TxBeginner, count int, ts ...Trait[T]) ([]*T, error) {
+	var items []*T
+	err := WithinTx(ctx, db, func(ctx context.Context) error {
+		var err error
+		items, err = f.CreateMany(ctx, count, ts...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
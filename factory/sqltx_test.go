@@ -0,0 +1,216 @@
+package factory
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+)
+
+// recordingDriver is a minimal database/sql driver that records executed
+// statements and whether they ran inside a transaction.
+type recordingDriver struct {
+	mu        sync.Mutex
+	execs     []string
+	commits   int
+	rollbacks int
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) { return &recordingConn{d: d}, nil }
+
+func (d *recordingDriver) record(s string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.execs = append(d.execs, s)
+}
+
+type recordingConn struct {
+	d    *recordingDriver
+	inTx bool
+}
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return &recordingStmt{c: c, query: query}, nil
+}
+func (c *recordingConn) Close() error { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	c.inTx = true
+	return recordingTx{c: c}, nil
+}
+
+type recordingTx struct{ c *recordingConn }
+
+func (tx recordingTx) Commit() error   { tx.c.inTx = false; tx.c.d.commits++; return nil }
+func (tx recordingTx) Rollback() error { tx.c.inTx = false; tx.c.d.rollbacks++; return nil }
+
+type recordingStmt struct {
+	c     *recordingConn
+	query string
+}
+
+func (s *recordingStmt) Close() error  { return nil }
+func (s *recordingStmt) NumInput() int { return -1 }
+func (s *recordingStmt) Exec([]driver.Value) (driver.Result, error) {
+	prefix := "db: "
+	if s.c.inTx {
+		prefix = "tx: "
+	}
+	s.c.d.record(prefix + s.query)
+	return driver.RowsAffected(1), nil
+}
+func (s *recordingStmt) Query([]driver.Value) (driver.Rows, error) { return nil, io.EOF }
+
+func openRecordingDB(t *testing.T) (*sql.DB, *recordingDriver) {
+	d := &recordingDriver{}
+	db := sql.OpenDB(connector{d})
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	return db, d
+}
+
+type connector struct{ d *recordingDriver }
+
+func (c connector) Connect(context.Context) (driver.Conn, error) { return c.d.Open("") }
+func (c connector) Driver() driver.Driver                        { return c.d }
+
+func TestSQLPersist(t *testing.T) {
+	db, d := openRecordingDB(t)
+	db.SetMaxOpenConns(1)
+
+	f := New(func(seq int64) User { return User{Name: "Ada"} }).
+		WithPersist(SQLPersist(db, func(ctx context.Context, q DBTX, u *User) (*User, error) {
+			_, err := q.ExecContext(ctx, "INSERT INTO users")
+			return u, err
+		}))
+
+	ctx := context.Background()
+	f.MustCreate(ctx)
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.MustCreate(WithTx(ctx, tx))
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(d.execs) != 2 || d.execs[0] != "db: INSERT INTO users" || d.execs[1] != "tx: INSERT INTO users" {
+		t.Fatalf("expected one insert on the db and one in the transaction, got %v", d.execs)
+	}
+	if got, ok := TxFrom(WithTx(ctx, tx)); !ok || got != tx {
+		t.Fatal("expected TxFrom to return the stored transaction")
+	}
+	if _, ok := TxFrom(ctx); ok {
+		t.Fatal("expected no transaction in a plain context")
+	}
+}
+
+type frameworkTxKey struct{}
+
+type stubDBTX struct {
+	DBTX
+	calls int
+}
+
+func (s *stubDBTX) ExecContext(context.Context, string, ...any) (sql.Result, error) {
+	s.calls++
+	return nil, errors.New("stub")
+}
+
+func TestSQLPersist_Lookup(t *testing.T) {
+	fallback, framework := &stubDBTX{}, &stubDBTX{}
+	lookup := func(ctx context.Context) (DBTX, bool) {
+		q, ok := ctx.Value(frameworkTxKey{}).(DBTX)
+		return q, ok
+	}
+	persist := SQLPersist(fallback, func(ctx context.Context, q DBTX, u *User) (*User, error) {
+		_, err := q.ExecContext(ctx, "INSERT")
+		return u, err
+	}, lookup)
+
+	ctx := context.WithValue(context.Background(), frameworkTxKey{}, DBTX(framework))
+	if _, err := persist(ctx, &User{}); err == nil {
+		t.Fatal("expected stub error")
+	}
+	if framework.calls != 1 || fallback.calls != 0 {
+		t.Fatalf("expected the framework transaction to be used, got %d/%d", framework.calls, fallback.calls)
+	}
+}
+
+func TestWithinTx(t *testing.T) {
+	db, d := openRecordingDB(t)
+	db.SetMaxOpenConns(1)
+
+	insert := func(table string) PersistFn[User] {
+		return SQLPersist(db, func(ctx context.Context, q DBTX, u *User) (*User, error) {
+			_, err := q.ExecContext(ctx, "INSERT INTO "+table)
+			return u, err
+		})
+	}
+	users := New(func(seq int64) User { return User{Name: "Ada"} }).WithPersist(insert("users"))
+	ctx := context.Background()
+
+	if _, err := users.CreateManyInTx(ctx, db, 2); err != nil {
+		t.Fatal(err)
+	}
+	if d.commits != 1 || len(d.execs) != 2 || d.execs[0] != "tx: INSERT INTO users" {
+		t.Fatalf("expected both inserts in one committed transaction, got %d commits, %v", d.commits, d.execs)
+	}
+
+	// A failing child rolls back the parent too
+	boom := errors.New("boom")
+	posts := New(func(seq int64) Post { return Post{} }).
+		WithPersist(func(ctx context.Context, p *Post) (*Post, error) { return nil, boom })
+	err := WithinTx(ctx, db, func(ctx context.Context) error {
+		_, _, err := Has(users, posts, 2, func(u *User, p *Post) { p.AuthorID = u.ID }).Create(ctx)
+		return err
+	})
+	if !errors.Is(err, boom) || d.rollbacks != 1 || d.commits != 1 {
+		t.Fatalf("expected a rollback with the child error, got %v (%d rollbacks)", err, d.rollbacks)
+	}
+
+	// Panics roll back and propagate
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected the panic to propagate")
+			}
+		}()
+		_ = WithinTx(ctx, db, func(ctx context.Context) error {
+			users.MustCreate(ctx, func(u *User) { panic("bad trait") })
+			return nil
+		})
+	}()
+	if d.rollbacks != 2 {
+		t.Fatalf("expected the panic to roll back, got %d rollbacks", d.rollbacks)
+	}
+}
+
+func TestWithinTx_Nested(t *testing.T) {
+	db, d := openRecordingDB(t)
+	db.SetMaxOpenConns(1)
+	users := New(func(seq int64) User { return User{} }).
+		WithPersist(SQLPersist(db, func(ctx context.Context, q DBTX, u *User) (*User, error) {
+			_, err := q.ExecContext(ctx, "INSERT INTO users")
+			return u, err
+		}))
+	ctx := context.Background()
+
+	err := WithinTx(ctx, db, func(ctx context.Context) error {
+		_, err := users.CreateInTx(ctx, db)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.commits != 1 || len(d.execs) != 1 || d.execs[0] != "tx: INSERT INTO users" {
+		t.Fatalf("expected the inner call to join the outer transaction, got %d commits, %v", d.commits, d.execs)
+	}
+}
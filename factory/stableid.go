@@ -0,0 +1,72 @@
+package factory
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+)
+
+// WithDeterministicID derives each item's ID from a hash of its natural key (e.g.,
+// email), so repeated seeding runs produce the same IDs and cross-environment
+// references and cached fixtures stay stable. It runs after all traits (and after
+// WithRunPrefix) and only fills a zero "ID" field: string IDs get a UUID-formatted
+// hash (see StableID), integer IDs a positive hash (see StableIntID).
+// Example: userFactory.WithDeterministicID(func(u *User) string { return u.Email })
+func (f *Factory[T]) WithDeterministicID(key func(*T) string) *Factory[T] {
+	f = f.edit()
+	defer f.mu.Unlock()
+	var zero T
+	v := reflect.ValueOf(&zero).Elem()
+	if v.Kind() != reflect.Struct || !v.FieldByName("ID").CanSet() {
+		panic(fmt.Sprintf("factory: WithDeterministicID requires a struct with an exported ID field, got %T", zero))
+	}
+	switch v.FieldByName("ID").Kind() {
+	case reflect.String, reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+	default:
+		panic(fmt.Sprintf("factory: WithDeterministicID: unsupported ID type %s", v.FieldByName("ID").Type()))
+	}
+	f.idKey = key
+	return f
+}
+
+// StableID returns the string ID WithDeterministicID assigns to a T with natural
+// key key, so other records can reference it without creating it first. The type
+// is part of the hash, so equal keys of different types get different IDs.
+// Example: post.AuthorID = StableID[User]("alice@example.com")
+func StableID[T any](key string) string {
+	b := stableHash[T](key)
+	b[6] = b[6]&0x0f | 0x80 // UUID version 8 (custom)
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// StableIntID returns the positive integer ID WithDeterministicID assigns to a T with natural key key.
+func StableIntID[T any](key string) int64 {
+	b := stableHash[T](key)
+	return int64(binary.BigEndian.Uint64(b[:8]) &^ (1 << 63)) //nolint:gosec // top bit cleared, fits int64
+}
+
+func stableHash[T any](key string) [sha256.Size]byte {
+	return sha256.Sum256([]byte(typeName[T]() + "\x00" + key))
+}
+
+// applyDeterministicID fills t's zero ID field from its natural key.
+func (f *Factory[T]) applyDeterministicID(t *T) {
+	if f.idKey == nil {
+		return
+	}
+	field := reflect.ValueOf(t).Elem().FieldByName("ID")
+	if !field.IsZero() {
+		return
+	}
+	key := f.idKey(t)
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(StableID[T](key))
+	case reflect.Int, reflect.Int64:
+		field.SetInt(StableIntID[T](key))
+	case reflect.Uint, reflect.Uint64:
+		field.SetUint(uint64(StableIntID[T](key))) //nolint:gosec // StableIntID is never negative
+	}
+}
@@ -0,0 +1,60 @@
+package factory
+
+import (
+	"regexp"
+	"testing"
+)
+
+type intIDRow struct {
+	ID    int64
+	Email string
+}
+
+func TestFactory_WithDeterministicID(t *testing.T) {
+	newFactory := func() *Factory[User] {
+		return New(func(seq int64) User {
+			return User{Email: "alice@example.com"}
+		}).WithDeterministicID(func(u *User) string { return u.Email })
+	}
+
+	a, b := newFactory().Make(), newFactory().Make()
+	if a.ID == "" || a.ID != b.ID {
+		t.Fatalf("expected the same ID across runs, got %q and %q", a.ID, b.ID)
+	}
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-8[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(a.ID) {
+		t.Fatalf("expected a UUID-formatted ID, got %q", a.ID)
+	}
+	if a.ID != StableID[User]("alice@example.com") {
+		t.Fatalf("expected StableID to match, got %q", StableID[User]("alice@example.com"))
+	}
+	if StableID[Post]("alice@example.com") == a.ID {
+		t.Fatal("expected IDs to differ across types")
+	}
+
+	bob := newFactory().Make(func(u *User) { u.Email = "bob@example.com" })
+	if bob.ID == a.ID {
+		t.Fatal("expected different keys to give different IDs")
+	}
+	explicit := newFactory().Make(func(u *User) { u.ID = "fixed" })
+	if explicit.ID != "fixed" {
+		t.Fatalf("expected explicit ID to be kept, got %q", explicit.ID)
+	}
+}
+
+func TestFactory_WithDeterministicID_Int(t *testing.T) {
+	f := New(func(seq int64) intIDRow { return intIDRow{Email: "a@example.com"} }).
+		WithDeterministicID(func(r *intIDRow) string { return r.Email })
+	row := f.Raw()
+	if row.ID <= 0 || row.ID != StableIntID[intIDRow]("a@example.com") {
+		t.Fatalf("expected positive stable int ID, got %d", row.ID)
+	}
+}
+
+func TestFactory_WithDeterministicID_Unsupported(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for a type without an ID field")
+		}
+	}()
+	New(func(seq int64) UserRole { return UserRole{} }).WithDeterministicID(func(r *UserRole) string { return r.UserID })
+}
@@ -0,0 +1,84 @@
+package factory
+
+import (
+	"fmt"
+	"sync"
+)
+
+// maxUniqueAttempts bounds how many times Unique regenerates a colliding value.
+const maxUniqueAttempts = 100
+
+// UniqueRegistry tracks unique values handed out across factories within a run.
+// Share one registry between factories that target the same table or column
+// (e.g., userFactory and adminFactory both writing users.email).
+type UniqueRegistry struct {
+	mu   sync.Mutex
+	seen map[string]map[string]struct{}
+}
+
+// NewUniqueRegistry creates an empty registry.
+func NewUniqueRegistry() *UniqueRegistry {
+	return &UniqueRegistry{seen: make(map[string]map[string]struct{})}
+}
+
+// Claim reserves value in namespace. Returns false if it was already claimed.
+func (r *UniqueRegistry) Claim(namespace, value string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	values, ok := r.seen[namespace]
+	if !ok {
+		values = make(map[string]struct{})
+		r.seen[namespace] = values
+	}
+	if _, taken := values[value]; taken {
+		return false
+	}
+	values[value] = struct{}{}
+	return true
+}
+
+// Release frees a claimed value in namespace, e.g., when the record that claimed it
+// failed to save.
+func (r *UniqueRegistry) Release(namespace, value string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.seen[namespace], value)
+}
+
+// Has reports whether value has been claimed in namespace.
+func (r *UniqueRegistry) Has(namespace, value string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.seen[namespace][value]
+	return ok
+}
+
+// Reset forgets all claimed values (useful between test runs).
+func (r *UniqueRegistry) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seen = make(map[string]map[string]struct{})
+}
+
+// Unique returns a trait that keeps a string field unique within namespace.
+// If the field's current value is empty or already claimed, gen is called until it
+// produces an unclaimed value. Panics after 100 collisions in a row.
+// Add it with WithTraits so it runs after defaults have filled the field.
+// Example: Unique(reg, "users.email", func(u *User) *string { return &u.Email }, faker.Email)
+func Unique[T any](reg *UniqueRegistry, namespace string, field func(*T) *string, gen func() string) Trait[T] {
+	return func(t *T) {
+		p := field(t)
+		if *p != "" && reg.Claim(namespace, *p) {
+			return
+		}
+		for i := 0; i < maxUniqueAttempts; i++ {
+			v := gen()
+			if reg.Claim(namespace, v) {
+				*p = v
+				return
+			}
+		}
+		panic(fmt.Sprintf("factory: could not generate unique value for %q after %d attempts", namespace, maxUniqueAttempts))
+	}
+}
@@ -0,0 +1,87 @@
+package factory
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFactory_UniqueAcrossFactories(t *testing.T) {
+	reg := NewUniqueRegistry()
+	emails := []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com"}
+	next := 0
+	gen := func() string {
+		v := emails[next%len(emails)]
+		next++
+		return v
+	}
+	email := func(u *User) *string { return &u.Email }
+
+	// Both factories default to the same email, which must not collide
+	userFactory := New(func(seq int64) User {
+		return User{Email: "a@example.com"}
+	}).WithTraits(Unique(reg, "users.email", email, gen))
+
+	adminFactory := New(func(seq int64) User {
+		return User{Name: "admin", Email: "a@example.com"}
+	}).WithTraits(Unique(reg, "users.email", email, gen))
+
+	seen := map[string]bool{}
+	for _, u := range append(userFactory.MakeMany(2), adminFactory.MakeMany(2)...) {
+		if seen[u.Email] {
+			t.Fatalf("duplicate email %q", u.Email)
+		}
+		seen[u.Email] = true
+	}
+	if len(seen) != 4 {
+		t.Fatalf("expected 4 unique emails, got %d", len(seen))
+	}
+}
+
+func TestFactory_UniqueExhaustedPanics(t *testing.T) {
+	reg := NewUniqueRegistry()
+	f := New(func(seq int64) User {
+		return User{}
+	}).WithTraits(Unique(reg, "users.email", func(u *User) *string { return &u.Email }, func() string {
+		return "same@example.com"
+	}))
+
+	f.Make()
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("expected panic when no unique value can be generated")
+		}
+		if msg := fmt.Sprint(r); msg != `factory: could not generate unique value for "users.email" after 100 attempts` {
+			t.Fatalf("unexpected panic message: %s", msg)
+		}
+	}()
+	f.Make()
+}
+
+func TestUniqueRegistry_NamespacesAndReset(t *testing.T) {
+	reg := NewUniqueRegistry()
+
+	if !reg.Claim("users.email", "x") {
+		t.Fatal("expected first claim to succeed")
+	}
+	if reg.Claim("users.email", "x") {
+		t.Fatal("expected second claim to fail")
+	}
+	if !reg.Claim("admins.email", "x") {
+		t.Fatal("expected namespaces to be independent")
+	}
+	if !reg.Has("users.email", "x") {
+		t.Fatal("expected value to be registered")
+	}
+
+	reg.Release("users.email", "x")
+	if reg.Has("users.email", "x") || !reg.Claim("users.email", "x") {
+		t.Fatal("expected a released value to be claimable again")
+	}
+
+	reg.Reset()
+	if reg.Has("users.email", "x") {
+		t.Fatal("expected Reset to clear values")
+	}
+}
@@ -0,0 +1,120 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Validate reports configuration problems up front instead of midway through a large seed.
+// It dry-builds one item with no state and one per defined state (recovering panics from
+// traits), and checks that hooks are not configured without a persist function.
+// Nothing is persisted, f's own sequence is not advanced, and Tap is not called; Unique
+// registries and the sequences of factories reached through For still advance.
+// All problems are returned together (see errors.Join).
+func (f *Factory[T]) Validate() error {
+	// makeFn is only set by New, so it can be checked before locking (a zero Factory has no lock)
+	if f.makeFn == nil {
+		return errors.New("factory: no make function; use New")
+	}
+	v := f.view()
+	f = &v
+
+	var errs []error
+	dry := f.dryRun()
+	if err := tryBuild(dry); err != nil {
+		errs = append(errs, fmt.Errorf("factory: default build: %w", err))
+	}
+
+	for _, name := range f.States() {
+		if err := tryBuild(dry.State(name)); err != nil {
+			errs = append(errs, fmt.Errorf("factory: state %q: %w", name, err))
+		}
+	}
+
+	if !f.canPersist() && (len(f.before) > 0 || len(f.after) > 0 || len(f.afterDiff) > 0) {
+		errs = append(errs, errors.New("factory: create hooks configured without persist function; use WithPersist"))
+	}
+	return errors.Join(errs...)
+}
+
+// Validator is implemented by *Factory[T] for any T.
+type Validator interface {
+	Validate() error
+}
+
+// usedFactory is a factory registered with Scenario.Uses.
+type usedFactory struct {
+	name string
+	f    Validator
+}
+
+// Uses registers the factories the scenario's steps build from, so Validate (and
+// Create, before anything is persisted) can check them. Steps are plain functions,
+// so the scenario cannot discover them on its own.
+// Example: s.Uses("users", userFactory).Uses("posts", postFactory)
+func (s *Scenario) Uses(name string, f Validator) *Scenario {
+	s.uses = append(s.uses, usedFactory{name: name, f: f})
+	return s
+}
+
+// Validate runs Validate on every factory registered with Uses and returns all
+// problems together, each prefixed with the scenario and factory names.
+func (s *Scenario) Validate() error {
+	var errs []error
+	for _, u := range s.uses {
+		if err := u.f.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("factory: scenario %q uses %q: %w", s.name, u.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateCreate runs Validate, then creates one item through the full
+// BeforeCreate → persist → AfterCreate path inside rollback, which should run the
+// given function in a transaction and roll it back afterwards.
+// Example:
+//
+//	err := f.ValidateCreate(ctx, func(ctx context.Context, run func(context.Context) error) error {
+//		tx, _ := db.BeginTx(ctx, nil)
+//		defer tx.Rollback()
+//		return run(WithTx(ctx, tx))
+//	})
+func (f *Factory[T]) ValidateCreate(ctx context.Context, rollback func(ctx context.Context, run func(ctx context.Context) error) error) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	v := f.view()
+	f = &v
+	if !f.canPersist() {
+		return errors.New("factory: no persist function; use WithPersist")
+	}
+	dry := f.dryRun()
+	err := rollback(ctx, func(ctx context.Context) error {
+		_, err := dry.Create(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("factory: dry create: %w", err)
+	}
+	return nil
+}
+
+// dryRun returns a copy suitable for validation: own sequence, no Tap.
+func (f *Factory[T]) dryRun() *Factory[T] {
+	dry := f.Clone()
+	*dry.seq = f.CurrentSequence()
+	dry.tapFn = nil
+	return dry
+}
+
+// tryBuild makes one item, converting a panic into an error.
+func tryBuild[T any](f *Factory[T]) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	f.Make()
+	return nil
+}
@@ -0,0 +1,134 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFactory_Validate(t *testing.T) {
+	tapped := 0
+	f := New(func(seq int64) User {
+		return User{Name: fmt.Sprintf("User %d", seq)}
+	}).DefineState("admin", func(u *User) {
+		u.Email = "admin@example.com"
+	}).Tap(func(u User) {
+		tapped++
+	})
+
+	if err := f.Validate(); err != nil {
+		t.Fatalf("expected valid factory, got %v", err)
+	}
+	if tapped != 0 {
+		t.Fatalf("expected Tap not to be called, got %d calls", tapped)
+	}
+	if u := f.Make(); u.Name != "User 1" {
+		t.Fatalf("expected sequence not to advance, got %q", u.Name)
+	}
+}
+
+func TestFactory_ValidateReportsProblems(t *testing.T) {
+	var names []string
+	f := New(func(seq int64) User {
+		return User{}
+	}).DefineState("broken", func(u *User) {
+		_ = names[5] // index out of range
+	}).AfterCreate(func(ctx context.Context, u *User) error {
+		return nil
+	})
+
+	err := f.Validate()
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, `state "broken"`) {
+		t.Fatalf("expected broken state to be reported, got %v", err)
+	}
+	if !strings.Contains(msg, "without persist function") {
+		t.Fatalf("expected missing persist to be reported, got %v", err)
+	}
+
+	var empty Factory[User]
+	if err := empty.Validate(); err == nil {
+		t.Fatal("expected error for factory without make function")
+	}
+}
+
+func TestFactory_ValidateCreate(t *testing.T) {
+	var pending []string
+	f := New(func(seq int64) User {
+		return User{Name: fmt.Sprintf("User %d", seq)}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		pending = append(pending, u.Name)
+		return u, nil
+	})
+
+	rolledBack := false
+	err := f.ValidateCreate(context.Background(), func(ctx context.Context, run func(context.Context) error) error {
+		defer func() {
+			pending = nil
+			rolledBack = true
+		}()
+		return run(ctx)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rolledBack || len(pending) != 0 {
+		t.Fatal("expected dry create to be rolled back")
+	}
+
+	boom := errors.New("table missing")
+	failing := f.UsingPersist(func(ctx context.Context, u *User) (*User, error) {
+		return nil, boom
+	})
+	err = failing.ValidateCreate(context.Background(), func(ctx context.Context, run func(context.Context) error) error {
+		return run(ctx)
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected persist error, got %v", err)
+	}
+
+	noPersist := New(func(seq int64) User { return User{} })
+	err = noPersist.ValidateCreate(context.Background(), func(ctx context.Context, run func(context.Context) error) error {
+		return run(ctx)
+	})
+	if err == nil {
+		t.Fatal("expected error for missing persist function")
+	}
+}
+
+func TestScenario_Validate(t *testing.T) {
+	good := New(func(seq int64) User { return User{} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) { return u, nil })
+	broken := New(func(seq int64) Post { return Post{} }).
+		DefineState("broken", func(p *Post) { panic("boom") })
+
+	ran := false
+	s := NewScenario("seed").
+		Uses("users", good).
+		Uses("posts", broken).
+		Step("users", func(ctx context.Context, r *Results) error {
+			ran = true
+			return nil
+		})
+
+	err := s.Validate()
+	if err == nil || !strings.Contains(err.Error(), `uses "posts"`) || !strings.Contains(err.Error(), `state "broken"`) {
+		t.Fatalf("expected the broken factory to be reported, got %v", err)
+	}
+	if strings.Contains(err.Error(), `"users"`) {
+		t.Fatalf("expected only the broken factory to be reported, got %v", err)
+	}
+
+	if _, err := s.Create(context.Background()); err == nil || ran {
+		t.Fatalf("expected Create to fail validation before running steps, got %v (ran=%v)", err, ran)
+	}
+
+	if err := NewScenario("ok").Uses("users", good).Validate(); err != nil {
+		t.Fatalf("expected a valid scenario, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package factory
+
+import "context"
+
+// CreateV is like Create but returns the persisted item by value.
+func (f *Factory[T]) CreateV(ctx context.Context, ts ...Trait[T]) (T, error) {
+	item, err := f.Create(ctx, ts...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return *item, nil
+}
+
+// CreateManyV is like CreateMany but returns the persisted items as a value slice.
+// On error, returns the items created so far.
+func (f *Factory[T]) CreateManyV(ctx context.Context, count int, ts ...Trait[T]) ([]T, error) {
+	items, err := f.CreateMany(ctx, count, ts...)
+	return Values(items), err
+}
+
+// MustCreateV is like MustCreate but returns the persisted item by value. Panics on error.
+func (f *Factory[T]) MustCreateV(ctx context.Context, ts ...Trait[T]) T {
+	return *f.MustCreate(ctx, ts...)
+}
+
+// CreateV builds, persists, and returns count items as a value slice.
+func (cf *CountedFactory[T]) CreateV(ctx context.Context, ts ...Trait[T]) ([]T, error) {
+	items, err := cf.Create(ctx, ts...)
+	return Values(items), err
+}
+
+// MustCreateV builds, persists, and returns count items as a value slice. Panics on error.
+func (cf *CountedFactory[T]) MustCreateV(ctx context.Context, ts ...Trait[T]) []T {
+	return Values(cf.MustCreate(ctx, ts...))
+}
+
+// Values dereferences a slice of pointers (nil pointers become zero values).
+// Useful for APIs that consume []T rather than []*T.
+func Values[T any](items []*T) []T {
+	out := make([]T, len(items))
+	for i, item := range items {
+		if item != nil {
+			out[i] = *item
+		}
+	}
+	return out
+}
+
+// Pluck maps each created item through fn (nil items are skipped), e.g., to collect IDs.
+// Example: ids := Pluck(users, func(u *User) string { return u.ID })
+func Pluck[T any, V any](items []*T, fn func(*T) V) []V {
+	out := make([]V, 0, len(items))
+	for _, item := range items {
+		if item != nil {
+			out = append(out, fn(item))
+		}
+	}
+	return out
+}
+
+// PluckCreate creates count items and returns fn applied to each, for bookkeeping
+// like collecting IDs in one line. On error, returns the values of the items created so far.
+// Example: ids, err := PluckCreate(ctx, userFactory, 10, func(u *User) string { return u.ID })
+func PluckCreate[T any, V any](ctx context.Context, f *Factory[T], count int, fn func(*T) V, ts ...Trait[T]) ([]V, error) {
+	items, err := f.CreateMany(ctx, count, ts...)
+	return Pluck(items, fn), err
+}
+
+// GroupBy groups created items by key, keeping their order within each group
+// (nil items are skipped).
+// Example: byRole := GroupBy(users, func(u *User) string { return u.Role })
+func GroupBy[T any, K comparable](items []*T, key func(*T) K) map[K][]*T {
+	out := make(map[K][]*T)
+	for _, item := range items {
+		if item != nil {
+			k := key(item)
+			out[k] = append(out[k], item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,94 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func newValueUserFactory() *Factory[User] {
+	return New(func(seq int64) User {
+		return User{Name: fmt.Sprintf("User %d", seq)}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		u.ID = fmt.Sprintf("id-%s", u.Name)
+		return u, nil
+	})
+}
+
+func TestFactory_CreateV(t *testing.T) {
+	f := newValueUserFactory()
+	ctx := context.Background()
+
+	u, err := f.CreateV(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.ID != "id-User 1" {
+		t.Fatalf("expected persisted value, got %+v", u)
+	}
+
+	if u := f.MustCreateV(ctx); u.ID != "id-User 2" {
+		t.Fatalf("expected persisted value, got %+v", u)
+	}
+
+	users, err := f.CreateManyV(ctx, 2)
+	if err != nil || len(users) != 2 || users[1].ID != "id-User 4" {
+		t.Fatalf("unexpected CreateManyV result: %v, %v", users, err)
+	}
+
+	counted := f.Count(3).MustCreateV(ctx)
+	if len(counted) != 3 || counted[0].ID == "" {
+		t.Fatalf("unexpected counted values: %v", counted)
+	}
+	counted, err = f.Count(2).CreateV(ctx)
+	if err != nil || len(counted) != 2 {
+		t.Fatalf("unexpected counted values: %v, %v", counted, err)
+	}
+}
+
+func TestFactory_CreateVError(t *testing.T) {
+	boom := errors.New("boom")
+	f := New(func(seq int64) User {
+		return User{Name: "x"}
+	}).WithPersist(func(ctx context.Context, u *User) (*User, error) {
+		return nil, boom
+	})
+
+	u, err := f.CreateV(context.Background())
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected boom, got %v", err)
+	}
+	if u != (User{}) {
+		t.Fatalf("expected zero value on error, got %+v", u)
+	}
+}
+
+func TestValues(t *testing.T) {
+	got := Values([]*User{{ID: "1"}, nil, {ID: "3"}})
+	if len(got) != 3 || got[0].ID != "1" || got[1].ID != "" || got[2].ID != "3" {
+		t.Fatalf("unexpected values: %v", got)
+	}
+}
+
+func TestPluckCreate(t *testing.T) {
+	ids, err := PluckCreate(context.Background(), newValueUserFactory(), 3, func(u *User) string { return u.ID })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 || ids[0] != "id-User 1" || ids[2] != "id-User 3" {
+		t.Fatalf("unexpected ids: %v", ids)
+	}
+
+	if got := Pluck([]*User{{Name: "a"}, nil, {Name: "b"}}, func(u *User) string { return u.Name }); len(got) != 2 || got[1] != "b" {
+		t.Fatalf("expected nil items to be skipped, got %v", got)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	roles := []*UserRole{{UserID: "1", RoleID: "admin"}, {UserID: "2", RoleID: "member"}, nil, {UserID: "3", RoleID: "admin"}}
+	groups := GroupBy(roles, func(r *UserRole) string { return r.RoleID })
+	if len(groups) != 2 || len(groups["admin"]) != 2 || groups["admin"][1].UserID != "3" || len(groups["member"]) != 1 {
+		t.Fatalf("unexpected groups: %v", groups)
+	}
+}
@@ -0,0 +1,178 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Workflow seeds a multi-actor flow on one subject (e.g., an author writes a post,
+// a moderator approves it, an admin features it). Each role's actor is created once,
+// every action runs on the subject in order with a timestamp one interval after the
+// previous one, and the subject is then created in its final state, so workflow and
+// permission tests get consistent actor IDs and ordered timestamps in one call.
+type Workflow[S any, A any] struct {
+	subject *Factory[S]
+	roles   map[string]*Factory[A]
+	order   []string // Roles in first-use order
+	actions []workflowAction[S, A]
+	start   time.Time     // Zero means the subject's group clock, or time.Now
+	every   time.Duration // Gap between action timestamps
+}
+
+type workflowAction[S any, A any] struct {
+	role string
+	fn   func(subject *S, actor *A, at time.Time)
+}
+
+// Flow is the result of a Workflow run.
+type Flow[S any, A any] struct {
+	Subject *S
+	Actors  map[string]*A // By role
+	Times   []time.Time   // Timestamp of each action, in order
+}
+
+// NewWorkflow starts a workflow on items from subject. Actions are one minute apart
+// by default (see Every).
+// Example: NewWorkflow[Post, User](postFactory).Act("author", authors, func(p *Post, u *User, at time.Time) { p.AuthorID = u.ID; p.CreatedAt = at })
+func NewWorkflow[S any, A any](subject *Factory[S]) *Workflow[S, A] {
+	return &Workflow[S, A]{subject: subject, roles: make(map[string]*Factory[A]), every: time.Minute}
+}
+
+// Act appends an action performed by role. The first Act for a role sets the factory
+// its actor is created from; later actions by the same role reuse that actor, so
+// actors may be nil after the first use.
+// Panics if a role's first Act has a nil actors factory.
+func (w *Workflow[S, A]) Act(role string, actors *Factory[A], fn func(subject *S, actor *A, at time.Time)) *Workflow[S, A] {
+	if _, ok := w.roles[role]; !ok {
+		if actors == nil {
+			panic(fmt.Sprintf("factory: Workflow role %q needs an actor factory", role))
+		}
+		w.roles[role] = actors
+		w.order = append(w.order, role)
+	}
+	w.actions = append(w.actions, workflowAction[S, A]{role: role, fn: fn})
+	return w
+}
+
+// StartingAt sets the timestamp of the first action.
+func (w *Workflow[S, A]) StartingAt(t time.Time) *Workflow[S, A] {
+	w.start = t
+	return w
+}
+
+// Every sets the gap between consecutive action timestamps.
+func (w *Workflow[S, A]) Every(d time.Duration) *Workflow[S, A] {
+	w.every = d
+	return w
+}
+
+// UpTo returns a copy that stops after the first n actions (e.g., a post that was
+// approved but not yet featured). Actors whose only actions were dropped are not created.
+// Panics if n is out of range.
+func (w *Workflow[S, A]) UpTo(n int) *Workflow[S, A] {
+	if n < 0 || n > len(w.actions) {
+		panic(fmt.Sprintf("factory: Workflow.UpTo(%d) out of range (%d actions)", n, len(w.actions)))
+	}
+	out := *w
+	out.actions = w.actions[:n:n]
+	return &out
+}
+
+// Make runs the workflow in memory: actors and the subject are built, not persisted.
+func (w *Workflow[S, A]) Make() Flow[S, A] {
+	flow := w.begin()
+	for _, role := range w.used() {
+		actor := w.roles[role].Make()
+		flow.Actors[role] = &actor
+	}
+	subject := w.subject.Make(w.trait(&flow))
+	flow.Subject = &subject
+	return flow
+}
+
+// Create creates each role's actor, then creates the subject with every action applied.
+// On error, returns the flow so far.
+func (w *Workflow[S, A]) Create(ctx context.Context) (Flow[S, A], error) {
+	flow := w.begin()
+	for _, role := range w.used() {
+		actor, err := w.roles[role].Create(ctx)
+		if err != nil {
+			return flow, fmt.Errorf("factory: workflow actor %q: %w", role, err)
+		}
+		flow.Actors[role] = actor
+	}
+	subject, err := w.subject.Create(ctx, w.trait(&flow))
+	if err != nil {
+		return flow, fmt.Errorf("factory: workflow subject: %w", err)
+	}
+	flow.Subject = subject
+	return flow, nil
+}
+
+// MustCreate is Create that panics on error.
+func (w *Workflow[S, A]) MustCreate(ctx context.Context) Flow[S, A] {
+	flow, err := w.Create(ctx)
+	if err != nil {
+		panic("factory: Workflow.MustCreate failed: " + err.Error())
+	}
+	return flow
+}
+
+// WorkflowStep returns a scenario step that runs w and stores the subject as []*S
+// under key and each actor as []*A under "key.role".
+// Example: s.Step("approved post", WorkflowStep("post", approval))
+func WorkflowStep[S any, A any](key string, w *Workflow[S, A]) Step {
+	return func(ctx context.Context, r *Results) error {
+		flow, err := w.Create(ctx)
+		for _, role := range w.used() {
+			if actor, ok := flow.Actors[role]; ok {
+				r.Set(key+"."+role, []*A{actor})
+			}
+		}
+		if flow.Subject != nil {
+			r.Set(key, []*S{flow.Subject})
+		}
+		return err
+	}
+}
+
+// begin returns an empty flow with every action's timestamp.
+func (w *Workflow[S, A]) begin() Flow[S, A] {
+	start := w.start
+	if start.IsZero() {
+		start = time.Now()
+		if g := w.subject.group; g != nil {
+			start = g.Now()
+		}
+	}
+	flow := Flow[S, A]{Actors: make(map[string]*A), Times: make([]time.Time, len(w.actions))}
+	for i := range flow.Times {
+		flow.Times[i] = start.Add(time.Duration(i) * w.every)
+	}
+	return flow
+}
+
+// used returns the roles that have at least one action, in first-use order.
+func (w *Workflow[S, A]) used() []string {
+	seen := make(map[string]bool)
+	for _, a := range w.actions {
+		seen[a.role] = true
+	}
+	var out []string
+	for _, role := range w.order {
+		if seen[role] {
+			out = append(out, role)
+		}
+	}
+	return out
+}
+
+// trait applies every action to the subject in order.
+func (w *Workflow[S, A]) trait(flow *Flow[S, A]) Trait[S] {
+	return func(s *S) {
+		for i, a := range w.actions {
+			a.fn(s, flow.Actors[a.role], flow.Times[i])
+		}
+	}
+}
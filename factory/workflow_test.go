@@ -0,0 +1,93 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type Submission struct {
+	ID         string
+	AuthorID   string
+	CreatedAt  time.Time
+	ApprovedBy string
+	ApprovedAt time.Time
+	FeaturedBy string
+	EditedAt   time.Time
+}
+
+func newApprovalWorkflow() *Workflow[Submission, User] {
+	users := New(func(seq int64) User { return User{} }).
+		WithPersist(func(ctx context.Context, u *User) (*User, error) {
+			u.ID = fmt.Sprintf("%s-%d", u.Name, len(u.Name))
+			return u, nil
+		}).
+		DefineState("author", func(u *User) { u.Name = "author" }).
+		DefineState("moderator", func(u *User) { u.Name = "moderator" }).
+		DefineState("admin", func(u *User) { u.Name = "admin" })
+	submissions := New(func(seq int64) Submission { return Submission{} }).
+		WithPersist(func(ctx context.Context, a *Submission) (*Submission, error) {
+			a.ID = "submission-1"
+			return a, nil
+		})
+
+	return NewWorkflow[Submission, User](submissions).
+		StartingAt(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)).
+		Every(time.Hour).
+		Act("author", users.State("author"), func(a *Submission, u *User, at time.Time) {
+			a.AuthorID, a.CreatedAt = u.ID, at
+		}).
+		Act("moderator", users.State("moderator"), func(a *Submission, u *User, at time.Time) {
+			a.ApprovedBy, a.ApprovedAt = u.ID, at
+		}).
+		Act("author", nil, func(a *Submission, u *User, at time.Time) {
+			if u.ID != a.AuthorID {
+				panic("expected the same author")
+			}
+			a.EditedAt = at
+		}).
+		Act("admin", users.State("admin"), func(a *Submission, u *User, at time.Time) {
+			a.FeaturedBy = u.ID
+		})
+}
+
+func TestWorkflow_Create(t *testing.T) {
+	flow := newApprovalWorkflow().MustCreate(context.Background())
+	a := flow.Subject
+	if a.ID != "submission-1" || a.AuthorID != "author-6" || a.ApprovedBy != "moderator-9" || a.FeaturedBy != "admin-5" {
+		t.Fatalf("expected linked actors, got %+v", a)
+	}
+	if a.ApprovedAt.Sub(a.CreatedAt) != time.Hour || a.EditedAt.Sub(a.CreatedAt) != 2*time.Hour {
+		t.Fatalf("expected ordered timestamps, got %v, %v, %v", a.CreatedAt, a.ApprovedAt, a.EditedAt)
+	}
+	if len(flow.Actors) != 3 || len(flow.Times) != 4 || flow.Actors["moderator"].ID != "moderator-9" {
+		t.Fatalf("unexpected flow %+v", flow)
+	}
+}
+
+func TestWorkflow_UpToAndStep(t *testing.T) {
+	approved := newApprovalWorkflow().UpTo(2)
+	flow := approved.MustCreate(context.Background())
+	if flow.Subject.ApprovedBy == "" || flow.Subject.FeaturedBy != "" || flow.Actors["admin"] != nil {
+		t.Fatalf("expected an approved, unfeatured submission without an admin, got %+v", flow)
+	}
+
+	// Make builds without persisting
+	if flow = approved.Make(); flow.Subject.ID != "" || flow.Actors["author"].Name != "author" {
+		t.Fatalf("expected an in-memory flow, got %+v", flow.Subject)
+	}
+
+	r, err := NewScenario("moderation").
+		Step("post", WorkflowStep("post", approved)).
+		Create(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if First[Submission](r, "post").ApprovedBy != First[User](r, "post.moderator").ID {
+		t.Fatal("expected scenario results linked to the moderator")
+	}
+	if _, ok := Lookup[[]*User](r, "post.admin"); ok {
+		t.Fatal("expected no admin result")
+	}
+}
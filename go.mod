@@ -1,3 +1,5 @@
 module github.com/b3ndoi/factory-go
 
 go 1.21
+
+require github.com/google/go-cmp v0.7.0